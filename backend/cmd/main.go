@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+
 	"github.com/aws-cakap-intern/book-store/config"
 	"github.com/aws-cakap-intern/book-store/internal/builder"
 	"github.com/aws-cakap-intern/book-store/pkg/db"
+	"github.com/aws-cakap-intern/book-store/pkg/scheduler"
 	"github.com/aws-cakap-intern/book-store/pkg/server"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
 )
 
 func main() {
@@ -14,11 +18,30 @@ func main() {
 	database, err := db.InitDB(&cfg.Database)
 	checkError(err)
 
+	fileStorage, err := storage.NewStorage(&cfg.Storage)
+	checkError(err)
+
+	privateStorage, err := storage.NewPrivateStorage(&cfg.Storage)
+	checkError(err)
+
 
-	publicRoutes := builder.BuildAppPublicRoutes(database)
+	routeGroups, grpcServer := builder.BuildApp(database, fileStorage, privateStorage, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheduler.Start(ctx, builder.BuildAppJobs(database, fileStorage, cfg))
+
+	// Only local storage needs the server to hand out the files itself
+	uploadsDir := ""
+	if cfg.Storage.Driver == storage.DriverLocal {
+		uploadsDir = cfg.Storage.LocalDir
+	}
 
+	grpcServer.Run(cfg.GRPC.Port)
+	defer grpcServer.GracefulStop()
 
-	srv := server.NewServer(publicRoutes)
+	srv := server.NewServer(routeGroups, cfg.JWTSecretKey, uploadsDir)
 	srv.Run(cfg.Port)
 }
 
@@ -0,0 +1,38 @@
+// Command move-invoices moves the invoices that were stored in the public
+// storage, where anyone with the link could read them, to the private
+// storage. It only needs to run once; invoices have been written to the
+// private storage since.
+package main
+
+import (
+	"fmt"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/builder"
+	"github.com/aws-cakap-intern/book-store/pkg/db"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+func main() {
+	cfg, err := config.NewConfig(".env")
+	checkError(err)
+
+	database, err := db.InitDB(&cfg.Database)
+	checkError(err)
+
+	fileStorage, err := storage.NewStorage(&cfg.Storage)
+	checkError(err)
+
+	privateStorage, err := storage.NewPrivateStorage(&cfg.Storage)
+	checkError(err)
+
+	moved, err := builder.BuildInvoiceService(database, fileStorage, privateStorage, cfg).MoveInvoicesToPrivateStorage()
+	fmt.Printf("moved %d invoices to the private storage\n", moved)
+	checkError(err)
+}
+
+func checkError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,47 @@
+// Command openapi writes the OpenAPI document built from the routes to
+// docs/openapi.json. With -check it writes nothing and exits with status 1
+// when the committed document no longer matches the code, so a change to a
+// route, binder or DTO cannot land without the document.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/aws-cakap-intern/book-store/internal/builder"
+)
+
+func main() {
+	output := flag.String("o", "docs/openapi.json", "file the document is written to")
+	check := flag.Bool("check", false, "only check that the file is up to date")
+	flag.Parse()
+
+	var document bytes.Buffer
+	encoder := json.NewEncoder(&document)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	checkError(encoder.Encode(builder.BuildOpenAPI()))
+
+	if *check {
+		committed, err := os.ReadFile(*output)
+		if err != nil && !os.IsNotExist(err) {
+			checkError(err)
+		}
+		if !bytes.Equal(committed, document.Bytes()) {
+			fmt.Fprintf(os.Stderr, "%s is out of date, run: go run ./cmd/openapi\n", *output)
+			os.Exit(1)
+		}
+		return
+	}
+
+	checkError(os.WriteFile(*output, document.Bytes(), 0644))
+}
+
+func checkError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
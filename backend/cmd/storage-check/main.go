@@ -0,0 +1,47 @@
+// Command storage-check compares the stored book images with the database
+// once and prints the report as JSON. Unused files older than the grace
+// period are deleted with -delete. It exits with status 1 when a book points
+// to a missing file.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/builder"
+	"github.com/aws-cakap-intern/book-store/pkg/db"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+func main() {
+	deleteOrphans := flag.Bool("delete", false, "delete unused files older than the grace period")
+	flag.Parse()
+
+	cfg, err := config.NewConfig(".env")
+	checkError(err)
+
+	database, err := db.InitDB(&cfg.Database)
+	checkError(err)
+
+	fileStorage, err := storage.NewStorage(&cfg.Storage)
+	checkError(err)
+
+	report, err := builder.BuildStorageCheckService(database, fileStorage, cfg).CheckStorage(*deleteOrphans)
+	checkError(err)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	checkError(encoder.Encode(report))
+
+	if len(report.Missing) > 0 {
+		os.Exit(1)
+	}
+}
+
+func checkError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
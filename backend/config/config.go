@@ -2,16 +2,29 @@ package config
 
 import (
 	"errors"
+	"time"
 
 	"github.com/caarlos0/env/v10"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Env         string         `env:"ENV" envDefault:"dev"`
-	Port        string         `env:"PORT" envDefault:"8080"`
-	Database    DatabaseConfig `envPrefix:"DATABASE_"`
-	JWTSecretKey string `env:"JWT_SECRET_KEY" envDefault:"secret"`
+	Env            string               `env:"ENV" envDefault:"dev"`
+	Port           string               `env:"PORT" envDefault:"8080"`
+	Database       DatabaseConfig       `envPrefix:"DATABASE_"`
+	JWTSecretKey   string               `env:"JWT_SECRET_KEY" envDefault:"secret"`
+	URLSigningKey  string               `env:"URL_SIGNING_KEY,notEmpty"`
+	Tax            TaxConfig            `envPrefix:"TAX_"`
+	Shipping       ShippingConfig       `envPrefix:"SHIPPING_"`
+	Recommendation RecommendationConfig `envPrefix:"RECOMMENDATION_"`
+	Store          StoreConfig          `envPrefix:"STORE_"`
+	Preorder       PreorderConfig       `envPrefix:"PREORDER_"`
+	Loyalty        LoyaltyConfig        `envPrefix:"LOYALTY_"`
+	Storage        StorageConfig        `envPrefix:"STORAGE_"`
+	Upload         UploadConfig         `envPrefix:"UPLOAD_"`
+	Ebook          EbookConfig          `envPrefix:"EBOOK_"`
+	API            APIConfig            `envPrefix:"API_"`
+	GRPC           GRPCConfig           `envPrefix:"GRPC_"`
 }
 
 type DatabaseConfig struct {
@@ -22,6 +35,132 @@ type DatabaseConfig struct {
 	Database string `env:"DATABASE" envDefault:"database"`
 }
 
+// TaxConfig controls how tax is calculated on carts and orders. Rates are in
+// basis points; DefaultRate applies to books whose categories have no tax rate.
+type TaxConfig struct {
+	PricesIncludeTax bool `env:"PRICES_INCLUDE_TAX" envDefault:"false"`
+	DefaultRate      int  `env:"DEFAULT_RATE" envDefault:"0"`
+}
+
+// ShippingConfig holds the order total from which shipping is free. A
+// threshold of 0 turns free shipping off.
+type ShippingConfig struct {
+	FreeThreshold int `env:"FREE_THRESHOLD" envDefault:"0"`
+}
+
+// RecommendationConfig controls the "customers also bought" job. Pairs bought
+// together in fewer than MinSupport orders are ignored.
+type RecommendationConfig struct {
+	RefreshInterval time.Duration `env:"REFRESH_INTERVAL" envDefault:"1h"`
+	MinSupport      int           `env:"MIN_SUPPORT" envDefault:"2"`
+	Limit           int           `env:"LIMIT" envDefault:"10"`
+}
+
+// StoreConfig holds the seller details printed on invoices.
+type StoreConfig struct {
+	Name    string `env:"NAME" envDefault:"Book Store"`
+	Address string `env:"ADDRESS" envDefault:""`
+	Phone   string `env:"PHONE" envDefault:""`
+	Email   string `env:"EMAIL" envDefault:""`
+	TaxID   string `env:"TAX_ID" envDefault:""`
+}
+
+// PreorderConfig sets how often released pre-order books are checked for.
+// Pre-orders hold the payment from checkout until release, and providers let
+// go of an uncaptured hold after AuthorizationHold, about 7 days for most
+// cards and up to 30 for some. A book can only be pre-ordered once its release
+// date is within that time. Postponing a release past the holds of earlier
+// pre-orders makes their capture fail, leaving them pre-ordered for staff.
+type PreorderConfig struct {
+	ReleaseInterval   time.Duration `env:"RELEASE_INTERVAL" envDefault:"1h"`
+	AuthorizationHold time.Duration `env:"AUTHORIZATION_HOLD" envDefault:"168h"`
+}
+
+// LoyaltyConfig controls the points program. Customers earn a point for every
+// RupiahPerPoint spent, and each point is worth PointValue Rupiah at checkout.
+// An ExpiryPeriod of 0 keeps points forever.
+type LoyaltyConfig struct {
+	RupiahPerPoint int           `env:"RUPIAH_PER_POINT" envDefault:"1000"`
+	PointValue     int           `env:"POINT_VALUE" envDefault:"10"`
+	ExpiryPeriod   time.Duration `env:"EXPIRY_PERIOD" envDefault:"8760h"`
+	ExpireInterval time.Duration `env:"EXPIRE_INTERVAL" envDefault:"1h"`
+}
+
+// StorageConfig chooses where uploaded files are kept. The local driver
+// writes to LocalDir and the server serves it at /api/uploads; the s3 driver
+// works with any S3-compatible service. PublicURL is the base URL files are
+// linked from, and may be left empty for s3 to link straight to the bucket.
+//
+// Private files such as ebooks and invoices are never served directly. They
+// are kept in PrivateDir with the local driver and in S3PrivateBucket with
+// the s3 driver, which must not be readable by the public.
+//
+// The storage check runs every CheckInterval. It reports files no book
+// points to and books pointing to missing files, and deletes the unused files
+// once they are older than OrphanGracePeriod if DeleteOrphans is set.
+type StorageConfig struct {
+	Driver            string        `env:"DRIVER" envDefault:"local"`
+	LocalDir          string        `env:"LOCAL_DIR" envDefault:"uploads"`
+	PublicURL         string        `env:"PUBLIC_URL" envDefault:"/api/uploads"`
+	PrivateDir        string        `env:"PRIVATE_DIR" envDefault:"private"`
+	S3Endpoint        string        `env:"S3_ENDPOINT" envDefault:""`
+	S3Region          string        `env:"S3_REGION" envDefault:""`
+	S3Bucket          string        `env:"S3_BUCKET" envDefault:""`
+	S3PrivateBucket   string        `env:"S3_PRIVATE_BUCKET" envDefault:""`
+	S3AccessKey       string        `env:"S3_ACCESS_KEY" envDefault:""`
+	S3SecretKey       string        `env:"S3_SECRET_KEY" envDefault:""`
+	S3UseSSL          bool          `env:"S3_USE_SSL" envDefault:"true"`
+	CheckInterval     time.Duration `env:"CHECK_INTERVAL" envDefault:"24h"`
+	OrphanGracePeriod time.Duration `env:"ORPHAN_GRACE_PERIOD" envDefault:"24h"`
+	DeleteOrphans     bool          `env:"DELETE_ORPHANS" envDefault:"false"`
+}
+
+// UploadConfig limits the images that may be uploaded. The type of a file is
+// read from its content, not its name, and must be one of ImageTypes.
+// Uploaded images are also stored scaled down to each of VariantWidths, and
+// as WebP when VariantWebP is set.
+//
+// Direct uploads get a signed URL valid for URLExpiry, and must be used by a
+// book within ClaimPeriod. With local storage the URL points at DirectURL,
+// which should be the public address of /api/uploads.
+type UploadConfig struct {
+	ImageTypes     []string      `env:"IMAGE_TYPES" envDefault:"image/jpeg,image/png,image/webp" envSeparator:","`
+	MaxImageBytes  int64         `env:"MAX_IMAGE_BYTES" envDefault:"5242880"`
+	MaxImageWidth  int           `env:"MAX_IMAGE_WIDTH" envDefault:"4000"`
+	MaxImageHeight int           `env:"MAX_IMAGE_HEIGHT" envDefault:"4000"`
+	VariantWidths  []int         `env:"VARIANT_WIDTHS" envDefault:"150,400,800" envSeparator:","`
+	VariantWebP    bool          `env:"VARIANT_WEBP" envDefault:"true"`
+	DirectURL      string        `env:"DIRECT_URL" envDefault:"/api/uploads"`
+	URLExpiry      time.Duration `env:"URL_EXPIRY" envDefault:"15m"`
+	ClaimPeriod    time.Duration `env:"CLAIM_PERIOD" envDefault:"24h"`
+	ExpireInterval time.Duration `env:"EXPIRE_INTERVAL" envDefault:"1h"`
+}
+
+// EbookConfig controls the ebook files sold with books. Buyers get download
+// links valid for LinkExpiry that point at DownloadURL, the public address of
+// /api/downloads, and may download each purchase DownloadLimit times.
+type EbookConfig struct {
+	MaxFileBytes  int64         `env:"MAX_FILE_BYTES" envDefault:"104857600"`
+	DownloadLimit int           `env:"DOWNLOAD_LIMIT" envDefault:"5"`
+	LinkExpiry    time.Duration `env:"LINK_EXPIRY" envDefault:"5m"`
+	DownloadURL   string        `env:"DOWNLOAD_URL" envDefault:"/api/downloads"`
+}
+
+// APIConfig dates the retirement of API v1. Its responses say it is
+// deprecated since V1DeprecatedAt and is removed at V1SunsetAt, and point
+// clients to /api/v2.
+type APIConfig struct {
+	V1DeprecatedAt time.Time `env:"V1_DEPRECATED_AT" envDefault:"2026-11-01T00:00:00Z"`
+	V1SunsetAt     time.Time `env:"V1_SUNSET_AT" envDefault:"2027-11-01T00:00:00Z"`
+}
+
+// GRPCConfig sets the port of the gRPC server the other services of the
+// store call. It has no authentication of its own, so the port must only be
+// reachable from inside the network.
+type GRPCConfig struct {
+	Port string `env:"PORT" envDefault:"9090"`
+}
+
 func NewConfig(envPath string) (*Config, error) {
 	err := godotenv.Load(envPath)
 	if err != nil {
@@ -32,8 +171,8 @@ func NewConfig(envPath string) (*Config, error) {
 
 	err = env.Parse(cfg)
 	if err != nil {
-		return nil, errors.New("failed to parse config file")
+		return nil, errors.New("failed to parse config file: " + err.Error())
 	}
 
 	return cfg, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS coupon_categories;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS coupon_categories (
+    coupon_id INT NOT NULL,
+    category_id INT NOT NULL,
+    PRIMARY KEY (coupon_id, category_id),
+    FOREIGN KEY (coupon_id) REFERENCES coupons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
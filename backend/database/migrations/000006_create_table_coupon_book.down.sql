@@ -0,0 +1 @@
+DROP TABLE IF EXISTS coupon_books;
\ No newline at end of file
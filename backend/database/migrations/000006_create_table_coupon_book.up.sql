@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS coupon_books (
+    coupon_id INT NOT NULL,
+    book_id INT NOT NULL,
+    PRIMARY KEY (coupon_id, book_id),
+    FOREIGN KEY (coupon_id) REFERENCES coupons(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS order_items (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    order_id INT NOT NULL,
+    book_id INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    unit_price INT NOT NULL,
+    quantity INT NOT NULL,
+    subtotal INT NOT NULL,
+    discount INT NOT NULL DEFAULT 0,
+    total INT NOT NULL,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON UPDATE CASCADE
+);
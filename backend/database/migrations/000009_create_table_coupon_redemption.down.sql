@@ -0,0 +1 @@
+DROP TABLE IF EXISTS coupon_redemptions;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE categories
+    DROP FOREIGN KEY fk_categories_tax_rate,
+    DROP COLUMN tax_rate_id;
@@ -0,0 +1,3 @@
+ALTER TABLE categories
+    ADD COLUMN tax_rate_id INT NULL AFTER name,
+    ADD CONSTRAINT fk_categories_tax_rate FOREIGN KEY (tax_rate_id) REFERENCES tax_rates(id) ON DELETE SET NULL ON UPDATE CASCADE;
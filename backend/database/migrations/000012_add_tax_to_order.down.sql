@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP COLUMN tax_total,
+    DROP COLUMN prices_include_tax;
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN tax_total INT NOT NULL DEFAULT 0 AFTER discount_total,
+    ADD COLUMN prices_include_tax BOOLEAN NOT NULL DEFAULT FALSE AFTER tax_total;
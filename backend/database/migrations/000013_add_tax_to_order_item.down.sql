@@ -0,0 +1,3 @@
+ALTER TABLE order_items
+    DROP COLUMN tax_rate,
+    DROP COLUMN tax;
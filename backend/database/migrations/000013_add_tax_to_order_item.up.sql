@@ -0,0 +1,3 @@
+ALTER TABLE order_items
+    ADD COLUMN tax_rate INT NOT NULL DEFAULT 0 AFTER discount,
+    ADD COLUMN tax INT NOT NULL DEFAULT 0 AFTER tax_rate;
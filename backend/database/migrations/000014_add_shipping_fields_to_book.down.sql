@@ -0,0 +1,5 @@
+ALTER TABLE books
+    DROP COLUMN weight_grams,
+    DROP COLUMN length_mm,
+    DROP COLUMN width_mm,
+    DROP COLUMN height_mm;
@@ -0,0 +1,5 @@
+ALTER TABLE books
+    ADD COLUMN weight_grams INT NOT NULL DEFAULT 0 AFTER description,
+    ADD COLUMN length_mm INT NOT NULL DEFAULT 0 AFTER weight_grams,
+    ADD COLUMN width_mm INT NOT NULL DEFAULT 0 AFTER length_mm,
+    ADD COLUMN height_mm INT NOT NULL DEFAULT 0 AFTER width_mm;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shipping_zones;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shipping_zone_regions;
\ No newline at end of file
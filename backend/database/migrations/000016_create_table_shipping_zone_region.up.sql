@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS shipping_zone_regions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    shipping_zone_id INT NOT NULL,
+    province VARCHAR(255),
+    postal_code_prefix VARCHAR(10),
+    FOREIGN KEY (shipping_zone_id) REFERENCES shipping_zones(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
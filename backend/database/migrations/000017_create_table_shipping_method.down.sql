@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shipping_methods;
\ No newline at end of file
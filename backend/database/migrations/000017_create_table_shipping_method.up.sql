@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS shipping_methods (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    shipping_zone_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (shipping_zone_id) REFERENCES shipping_zones(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
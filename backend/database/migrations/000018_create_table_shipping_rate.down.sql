@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shipping_rates;
\ No newline at end of file
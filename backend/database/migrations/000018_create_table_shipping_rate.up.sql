@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS shipping_rates (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    shipping_method_id INT NOT NULL,
+    min_weight_grams INT NOT NULL DEFAULT 0,
+    max_weight_grams INT NOT NULL DEFAULT 0,
+    price INT NOT NULL,
+    FOREIGN KEY (shipping_method_id) REFERENCES shipping_methods(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
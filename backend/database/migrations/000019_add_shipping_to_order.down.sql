@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    DROP COLUMN shipping_total,
+    DROP COLUMN shipping_method_id,
+    DROP COLUMN shipping_method_name,
+    DROP COLUMN shipping_recipient,
+    DROP COLUMN shipping_phone,
+    DROP COLUMN shipping_address,
+    DROP COLUMN shipping_province,
+    DROP COLUMN shipping_postal_code;
@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    ADD COLUMN shipping_total INT NOT NULL DEFAULT 0 AFTER tax_total,
+    ADD COLUMN shipping_method_id INT NULL AFTER prices_include_tax,
+    ADD COLUMN shipping_method_name VARCHAR(255) AFTER shipping_method_id,
+    ADD COLUMN shipping_recipient VARCHAR(255) AFTER shipping_method_name,
+    ADD COLUMN shipping_phone VARCHAR(32) AFTER shipping_recipient,
+    ADD COLUMN shipping_address TEXT AFTER shipping_phone,
+    ADD COLUMN shipping_province VARCHAR(255) AFTER shipping_address,
+    ADD COLUMN shipping_postal_code VARCHAR(10) AFTER shipping_province;
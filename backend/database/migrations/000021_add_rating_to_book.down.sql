@@ -0,0 +1,4 @@
+ALTER TABLE books
+    DROP INDEX idx_books_rating,
+    DROP COLUMN rating_average,
+    DROP COLUMN rating_count;
@@ -0,0 +1,4 @@
+ALTER TABLE books
+    ADD COLUMN rating_average DECIMAL(3,2) NOT NULL DEFAULT 0 AFTER height_mm,
+    ADD COLUMN rating_count INT NOT NULL DEFAULT 0 AFTER rating_average,
+    ADD INDEX idx_books_rating (rating_average, rating_count);
@@ -0,0 +1,2 @@
+ALTER TABLE books
+    ADD COLUMN stock INT NULL DEFAULT NULL AFTER price;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS book_recommendations;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS book_recommendations (
+    book_id INT NOT NULL,
+    recommended_book_id INT NOT NULL,
+    score INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (book_id, recommended_book_id),
+    INDEX idx_book_recommendations_score (book_id, score),
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (recommended_book_id) REFERENCES books(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
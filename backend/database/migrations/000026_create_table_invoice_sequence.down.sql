@@ -0,0 +1 @@
+DROP TABLE IF EXISTS invoice_sequences;
\ No newline at end of file
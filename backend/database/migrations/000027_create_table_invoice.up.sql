@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS invoices (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    order_id INT NOT NULL,
+    number VARCHAR(32) NOT NULL,
+    year INT NOT NULL,
+    sequence INT NOT NULL,
+    file_path VARCHAR(255) NOT NULL,
+    issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_invoices_order (order_id),
+    UNIQUE KEY uq_invoices_number (number),
+    UNIQUE KEY uq_invoices_year_sequence (year, sequence),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON UPDATE CASCADE
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS returns (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    order_id INT NOT NULL,
+    user_id INT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    reason TEXT NOT NULL,
+    refund_amount INT NOT NULL DEFAULT 0,
+    refund_reference VARCHAR(255),
+    refunded_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_returns_user (user_id),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON UPDATE CASCADE
+);
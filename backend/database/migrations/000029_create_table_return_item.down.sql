@@ -0,0 +1 @@
+DROP TABLE IF EXISTS return_items;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS return_items (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    return_id INT NOT NULL,
+    order_item_id INT NOT NULL,
+    book_id INT NOT NULL,
+    quantity INT NOT NULL,
+    amount INT NOT NULL,
+    FOREIGN KEY (return_id) REFERENCES returns(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON UPDATE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS return_status_histories;
\ No newline at end of file
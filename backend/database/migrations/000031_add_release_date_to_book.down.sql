@@ -0,0 +1,4 @@
+ALTER TABLE books
+    DROP INDEX idx_books_availability_release,
+    DROP COLUMN release_date,
+    DROP COLUMN availability;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE books
+    ADD COLUMN availability VARCHAR(32) NOT NULL DEFAULT 'available' AFTER stock,
+    ADD COLUMN release_date DATE NULL DEFAULT NULL AFTER availability,
+    ADD INDEX idx_books_availability_release (availability, release_date);
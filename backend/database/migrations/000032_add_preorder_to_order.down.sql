@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP COLUMN payment_reference,
+    DROP COLUMN is_preorder;
\ No newline at end of file
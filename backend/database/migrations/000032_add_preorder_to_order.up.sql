@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN is_preorder BOOLEAN NOT NULL DEFAULT FALSE AFTER status,
+    ADD COLUMN payment_reference VARCHAR(255) AFTER is_preorder;
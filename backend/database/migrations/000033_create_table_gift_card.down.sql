@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gift_cards;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS gift_cards (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(32) NOT NULL,
+    initial_balance INT NOT NULL,
+    balance INT NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    purchased_by_user_id INT NULL DEFAULT NULL,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_gift_cards_code (code)
+);
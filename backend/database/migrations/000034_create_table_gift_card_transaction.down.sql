@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gift_card_transactions;
\ No newline at end of file
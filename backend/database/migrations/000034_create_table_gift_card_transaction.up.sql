@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS gift_card_transactions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    gift_card_id INT NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    amount INT NOT NULL,
+    balance_after INT NOT NULL,
+    order_id INT NULL DEFAULT NULL,
+    note VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_gift_card_transactions_order (order_id),
+    FOREIGN KEY (gift_card_id) REFERENCES gift_cards(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON UPDATE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP COLUMN amount_due,
+    DROP COLUMN gift_card_total;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN gift_card_total INT NOT NULL DEFAULT 0 AFTER total,
+    ADD COLUMN amount_due INT NOT NULL DEFAULT 0 AFTER gift_card_total;
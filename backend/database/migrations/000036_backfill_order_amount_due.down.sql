@@ -0,0 +1 @@
+UPDATE orders SET amount_due = 0;
\ No newline at end of file
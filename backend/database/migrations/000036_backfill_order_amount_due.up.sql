@@ -0,0 +1 @@
+UPDATE orders SET amount_due = total - gift_card_total;
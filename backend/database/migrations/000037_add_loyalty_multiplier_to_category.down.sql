@@ -0,0 +1,2 @@
+ALTER TABLE categories
+    DROP COLUMN loyalty_multiplier;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE categories
+    ADD COLUMN loyalty_multiplier INT NOT NULL DEFAULT 100 AFTER tax_rate_id;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS loyalty_entries;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS loyalty_entries (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    points INT NOT NULL,
+    remaining INT NOT NULL DEFAULT 0,
+    order_id INT NULL DEFAULT NULL,
+    note VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_loyalty_entries_user (user_id, remaining),
+    INDEX idx_loyalty_entries_order (order_id),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON UPDATE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    DROP COLUMN points_earned,
+    DROP COLUMN points_discount,
+    DROP COLUMN points_redeemed;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    ADD COLUMN points_redeemed INT NOT NULL DEFAULT 0 AFTER total,
+    ADD COLUMN points_discount INT NOT NULL DEFAULT 0 AFTER points_redeemed,
+    ADD COLUMN points_earned INT NOT NULL DEFAULT 0 AFTER points_discount;
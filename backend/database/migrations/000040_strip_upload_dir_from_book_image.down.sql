@@ -0,0 +1 @@
+UPDATE books SET image_path = CONCAT('uploads/', image_path) WHERE image_path <> '';
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE books SET image_path = SUBSTRING(image_path, 9) WHERE image_path LIKE 'uploads/%';
@@ -0,0 +1 @@
+UPDATE invoices SET file_path = CONCAT('uploads/', file_path);
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE invoices SET file_path = SUBSTRING(file_path, 9) WHERE file_path LIKE 'uploads/%';
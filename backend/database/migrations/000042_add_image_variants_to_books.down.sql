@@ -0,0 +1,2 @@
+ALTER TABLE books
+    DROP COLUMN image_variants;
\ No newline at end of file
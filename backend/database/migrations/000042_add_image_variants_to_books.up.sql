@@ -0,0 +1,2 @@
+ALTER TABLE books
+    ADD COLUMN image_variants TEXT NULL AFTER image_path;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS book_images;
\ No newline at end of file
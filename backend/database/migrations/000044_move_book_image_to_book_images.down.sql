@@ -0,0 +1,3 @@
+UPDATE books
+    JOIN book_images ON book_images.book_id = books.id AND book_images.is_primary = TRUE
+    SET books.image_path = book_images.path, books.image_variants = book_images.variants;
\ No newline at end of file
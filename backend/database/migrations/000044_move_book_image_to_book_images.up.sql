@@ -0,0 +1,2 @@
+INSERT INTO book_images (book_id, path, variants, alt, position, is_primary)
+SELECT id, image_path, image_variants, title, 0, TRUE FROM books WHERE image_path <> '';
@@ -0,0 +1,3 @@
+ALTER TABLE books
+    ADD COLUMN image_path VARCHAR(255) NOT NULL DEFAULT '' AFTER release_date,
+    ADD COLUMN image_variants TEXT NULL AFTER image_path;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE books
+    DROP COLUMN image_variants,
+    DROP COLUMN image_path;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS uploads (
+    id CHAR(36) PRIMARY KEY,
+    object_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(64) NOT NULL,
+    size BIGINT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_uploads_expires (expires_at)
+);
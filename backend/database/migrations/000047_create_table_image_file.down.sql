@@ -0,0 +1 @@
+DROP TABLE IF EXISTS image_files;
\ No newline at end of file
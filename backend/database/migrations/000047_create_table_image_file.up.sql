@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS image_files (
+    hash CHAR(64) PRIMARY KEY,
+    path VARCHAR(255) NOT NULL,
+    variants TEXT NULL,
+    ref_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_image_files_path (path)
+);
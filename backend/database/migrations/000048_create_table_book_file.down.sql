@@ -0,0 +1 @@
+DROP TABLE IF EXISTS book_files;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS ebook_downloads (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    order_item_id INT NOT NULL,
+    book_file_id INT NOT NULL,
+    user_id INT NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_ebook_downloads_item (order_item_id),
+    FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE returns
+    DROP COLUMN points_refunded,
+    DROP COLUMN gift_card_refund_amount;
\ No newline at end of file
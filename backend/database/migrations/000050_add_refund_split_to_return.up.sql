@@ -0,0 +1,3 @@
+ALTER TABLE returns
+    ADD COLUMN gift_card_refund_amount INT NOT NULL DEFAULT 0 AFTER refund_amount,
+    ADD COLUMN points_refunded INT NOT NULL DEFAULT 0 AFTER gift_card_refund_amount;
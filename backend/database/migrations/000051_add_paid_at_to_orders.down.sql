@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN paid_at;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE orders ADD COLUMN paid_at TIMESTAMP NULL DEFAULT NULL AFTER payment_reference;
+
+-- Orders paid before this column existed count as paid when they were placed
+UPDATE orders SET paid_at = created_at WHERE status IN ('paid', 'shipped', 'delivered');
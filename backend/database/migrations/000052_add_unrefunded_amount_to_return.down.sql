@@ -0,0 +1 @@
+ALTER TABLE returns DROP COLUMN unrefunded_amount;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE returns ADD COLUMN unrefunded_amount INT NOT NULL DEFAULT 0 AFTER points_refunded;
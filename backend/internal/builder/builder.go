@@ -1,27 +1,187 @@
 package builder
 
 import (
+	"github.com/aws-cakap-intern/book-store/config"
+	v2 "github.com/aws-cakap-intern/book-store/internal/dto/v2"
+	"github.com/aws-cakap-intern/book-store/internal/graphql"
 	"github.com/aws-cakap-intern/book-store/internal/http/handler"
 	"github.com/aws-cakap-intern/book-store/internal/http/router"
+	"github.com/aws-cakap-intern/book-store/internal/job"
 	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/internal/rpc"
 	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/openapi"
+	"github.com/aws-cakap-intern/book-store/pkg/payment"
+	pb "github.com/aws-cakap-intern/book-store/pkg/pb/bookstore/v1"
 	"github.com/aws-cakap-intern/book-store/pkg/route"
+	"github.com/aws-cakap-intern/book-store/pkg/scheduler"
+	"github.com/aws-cakap-intern/book-store/pkg/server"
+	"github.com/aws-cakap-intern/book-store/pkg/signer"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
 	"gorm.io/gorm"
 )
 
-func BuildAppPublicRoutes(db *gorm.DB, ) []*route.Route {
-	
+// BuildApp serves the store over HTTP, as route groups, and over gRPC. Both
+// share one set of services, so state such as the similarity index is the
+// same whichever way a book is changed.
+func BuildApp(db *gorm.DB, fileStorage storage.Storage, privateStorage storage.Storage, cfg *config.Config) ([]*route.Group, *server.GRPCServer) {
+	appHandler, grpcServer := buildApp(db, fileStorage, privateStorage, cfg)
 
+	return appRouteGroups(appHandler, &cfg.API), grpcServer
+}
+
+func BuildAppJobs(db *gorm.DB, fileStorage storage.Storage, cfg *config.Config) []*scheduler.Job {
+	bookRepository := repository.NewBookRepository(db)
+	orderRepository := repository.NewOrderRepository(db)
+	recommendationRepository := repository.NewRecommendationRepository(db)
+	loyaltyRepository := repository.NewLoyaltyRepository(db)
+	bookImageRepository := repository.NewBookImageRepository(db)
+	imageFileRepository := repository.NewImageFileRepository(db)
+	uploadRepository := repository.NewUploadRepository(db)
+
+	paymentProvider := payment.NewManualProvider()
+	imageValidator := upload.NewImageValidator(&cfg.Upload)
+	urlSigner := signer.NewSigner(cfg.URLSigningKey)
+
+	recommendationService := service.NewRecommendationService(recommendationRepository, bookRepository, fileStorage, cfg)
+	preorderService := service.NewPreorderService(bookRepository, orderRepository, paymentProvider)
+	loyaltyService := service.NewLoyaltyService(loyaltyRepository, cfg)
+	storageCheckService := service.NewStorageCheckService(bookImageRepository, imageFileRepository, fileStorage, cfg)
+	uploadService := service.NewUploadService(uploadRepository, fileStorage, imageValidator, urlSigner, cfg)
+
+	return job.AppJobs(recommendationService, preorderService, loyaltyService, storageCheckService, uploadService, cfg)
+}
+
+func BuildStorageCheckService(db *gorm.DB, fileStorage storage.Storage, cfg *config.Config) service.StorageCheckService {
+	bookImageRepository := repository.NewBookImageRepository(db)
+	imageFileRepository := repository.NewImageFileRepository(db)
+
+	return service.NewStorageCheckService(bookImageRepository, imageFileRepository, fileStorage, cfg)
+}
+
+func BuildInvoiceService(db *gorm.DB, fileStorage storage.Storage, privateStorage storage.Storage, cfg *config.Config) service.InvoiceService {
+	invoiceRepository := repository.NewInvoiceRepository(db)
+	orderRepository := repository.NewOrderRepository(db)
+
+	return service.NewInvoiceService(invoiceRepository, orderRepository, privateStorage, fileStorage, cfg)
+}
+
+// BuildOpenAPI documents the routes of every version. The handlers are never
+// called, so none of their dependencies are built.
+func BuildOpenAPI() *openapi.Document {
+	document := openapi.NewDocument("Book Store API", "1.0.0", "/api")
+	for _, group := range appRouteGroups(handler.AppHandler{}, &config.APIConfig{}) {
+		document.AddGroup(group)
+	}
+	return document
+}
+
+// appRouteGroups lists the versions of the API. v1 keeps the shapes the
+// frontend was built on and is deprecated in favour of v2.
+func appRouteGroups(appHandler handler.AppHandler, cfg *config.APIConfig) []*route.Group {
+	return []*route.Group{
+		{
+			Prefix:       "/api",
+			PublicRoutes: router.AppDocsRoutes(appHandler),
+		},
+		{
+			Prefix:       "/api",
+			PublicRoutes: router.AppGraphQLRoutes(appHandler),
+			AdminRoutes:  router.AppGraphQLAdminRoutes(appHandler),
+		},
+		{
+			Prefix:        "/api",
+			PublicRoutes:  router.AppPublicRoutes(appHandler),
+			PrivateRoutes: router.AppPrivateRoutes(appHandler),
+			AdminRoutes:   router.AppAdminRoutes(appHandler),
+			Deprecation: &route.Deprecation{
+				Since:     cfg.V1DeprecatedAt,
+				Sunset:    cfg.V1SunsetAt,
+				Successor: "/api/v2",
+			},
+		},
+		{
+			Prefix:        "/api/v2",
+			PublicRoutes:  router.AppV2PublicRoutes(appHandler),
+			PrivateRoutes: router.AppV2PrivateRoutes(appHandler),
+			Mapper:        v2.Map,
+		},
+	}
+}
+
+func buildApp(db *gorm.DB, fileStorage storage.Storage, privateStorage storage.Storage, cfg *config.Config) (handler.AppHandler, *server.GRPCServer) {
 	categoryRepository := repository.NewCategoryRepository(db)
 	bookRepository := repository.NewBookRepository(db)
+	bookImageRepository := repository.NewBookImageRepository(db)
+	imageFileRepository := repository.NewImageFileRepository(db)
+	couponRepository := repository.NewCouponRepository(db)
+	orderRepository := repository.NewOrderRepository(db)
+	taxRateRepository := repository.NewTaxRateRepository(db)
+	shippingRepository := repository.NewShippingRepository(db)
+	reviewRepository := repository.NewReviewRepository(db)
+	wishlistRepository := repository.NewWishlistRepository(db)
+	recommendationRepository := repository.NewRecommendationRepository(db)
+	invoiceRepository := repository.NewInvoiceRepository(db)
+	returnRepository := repository.NewReturnRepository(db)
+	giftCardRepository := repository.NewGiftCardRepository(db)
+	loyaltyRepository := repository.NewLoyaltyRepository(db)
+	uploadRepository := repository.NewUploadRepository(db)
+	bookFileRepository := repository.NewBookFileRepository(db)
+	ebookDownloadRepository := repository.NewEbookDownloadRepository(db)
+	bookCategoryRepository := repository.NewBookCategoryRepository(db)
+
+	paymentProvider := payment.NewManualProvider()
+	imageValidator := upload.NewImageValidator(&cfg.Upload)
+	ebookValidator := upload.NewEbookValidator(&cfg.Ebook)
+	urlSigner := signer.NewSigner(cfg.URLSigningKey)
+
+	categoryService := service.NewCategoryService(categoryRepository, taxRateRepository)
+	similarityService := service.NewSimilarityService(bookRepository, fileStorage, cfg)
+	variantGenerator := upload.NewVariantGenerator(&cfg.Upload)
+	bookService := service.NewBookService(bookRepository, bookImageRepository, imageFileRepository, bookFileRepository, categoryRepository, similarityService, fileStorage, privateStorage, variantGenerator)
+	bookImageService := service.NewBookImageService(bookRepository, bookImageRepository, imageFileRepository, fileStorage, variantGenerator)
+	couponService := service.NewCouponService(couponRepository)
+	cartService := service.NewCartService(bookRepository, couponRepository, shippingRepository, giftCardRepository, loyaltyRepository, cfg)
+	orderService := service.NewOrderService(orderRepository, bookRepository, couponRepository, shippingRepository, giftCardRepository, loyaltyRepository, paymentProvider, cfg)
+	taxRateService := service.NewTaxRateService(taxRateRepository)
+	shippingService := service.NewShippingService(shippingRepository)
+	reviewService := service.NewReviewService(reviewRepository, bookRepository, orderRepository)
+	wishlistService := service.NewWishlistService(wishlistRepository, bookRepository, fileStorage)
+	recommendationService := service.NewRecommendationService(recommendationRepository, bookRepository, fileStorage, cfg)
+	invoiceService := service.NewInvoiceService(invoiceRepository, orderRepository, privateStorage, fileStorage, cfg)
+	returnService := service.NewReturnService(returnRepository, orderRepository, paymentProvider)
+	giftCardService := service.NewGiftCardService(giftCardRepository, paymentProvider)
+	loyaltyService := service.NewLoyaltyService(loyaltyRepository, cfg)
+	uploadService := service.NewUploadService(uploadRepository, fileStorage, imageValidator, urlSigner, cfg)
+	ebookService := service.NewEbookService(bookRepository, bookFileRepository, ebookDownloadRepository, orderRepository, privateStorage, uploadService, imageValidator, urlSigner, cfg)
+	catalogService := service.NewCatalogService(bookRepository, categoryRepository, bookCategoryRepository, fileStorage)
 
-	categoryService := service.NewCategoryService(categoryRepository)
-	bookService := service.NewBookService(bookRepository, categoryRepository)
-	
 	categoryHandler := handler.NewCategoryHandler(categoryService)
-	bookHandler := handler.NewBookHandler(bookService)
+	bookHandler := handler.NewBookHandler(bookService, uploadService, imageValidator)
+	bookImageHandler := handler.NewBookImageHandler(bookImageService, uploadService, imageValidator)
+	couponHandler := handler.NewCouponHandler(couponService)
+	cartHandler := handler.NewCartHandler(cartService)
+	orderHandler := handler.NewOrderHandler(orderService)
+	taxRateHandler := handler.NewTaxRateHandler(taxRateService)
+	shippingHandler := handler.NewShippingHandler(shippingService)
+	reviewHandler := handler.NewReviewHandler(reviewService)
+	wishlistHandler := handler.NewWishlistHandler(wishlistService)
+	recommendationHandler := handler.NewRecommendationHandler(recommendationService, similarityService)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceService)
+	returnHandler := handler.NewReturnHandler(returnService)
+	giftCardHandler := handler.NewGiftCardHandler(giftCardService)
+	meHandler := handler.NewMeHandler(loyaltyService)
+	uploadHandler := handler.NewUploadHandler(uploadService)
+	ebookHandler := handler.NewEbookHandler(ebookService, ebookValidator)
+	docsHandler := handler.NewDocsHandler(BuildOpenAPI())
+	graphQLHandler := handler.NewGraphQLHandler(graphql.NewSchema(bookService, categoryService, catalogService, uploadService))
 
-	appHandler := handler.NewAppHandler(categoryHandler, bookHandler)
+	// A message may carry a cover of the largest allowed size, with room to
+	// spare for the other fields
+	grpcServer := server.NewGRPCServer(int(cfg.Upload.MaxImageBytes) + 1<<20)
+	pb.RegisterBookServiceServer(grpcServer, rpc.NewBookServer(bookService, uploadService, imageValidator))
+	pb.RegisterCategoryServiceServer(grpcServer, rpc.NewCategoryServer(categoryService))
 
-	return router.AppPublicRoutes(appHandler)
+	return handler.NewAppHandler(categoryHandler, bookHandler, couponHandler, cartHandler, orderHandler, taxRateHandler, shippingHandler, reviewHandler, wishlistHandler, recommendationHandler, invoiceHandler, returnHandler, giftCardHandler, meHandler, bookImageHandler, uploadHandler, ebookHandler, docsHandler, graphQLHandler), grpcServer
 }
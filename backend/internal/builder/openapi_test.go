@@ -0,0 +1,29 @@
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// TestOpenAPIDocumentIsUpToDate fails when a route, binder or DTO changed
+// without regenerating docs/openapi.json.
+func TestOpenAPIDocumentIsUpToDate(t *testing.T) {
+	var document bytes.Buffer
+	encoder := json.NewEncoder(&document)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(BuildOpenAPI()); err != nil {
+		t.Fatal(err)
+	}
+
+	committed, err := os.ReadFile("../../docs/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(committed, document.Bytes()) {
+		t.Fatal("docs/openapi.json is out of date, run: go run ./cmd/openapi")
+	}
+}
@@ -1,12 +1,36 @@
 package dto
 
+import "time"
+
 type BookResponse struct {
 	ID          uint   `json:"id"`
 	Title       string `json:"title"`
 	Price       int    `json:"price"`
-	ImagePath   string `json:"imagePath"`
+	Stock       *int   `json:"stock"`
+	Availability string `json:"availability"`
+	ReleaseDate *string `json:"release_date"`
+	Cover       map[string]string `json:"cover"`
+	Images      []BookImageResponse `json:"images"`
 	Description string `json:"description"`
+	WeightGrams int    `json:"weight_grams"`
+	LengthMm    int    `json:"length_mm"`
+	WidthMm     int    `json:"width_mm"`
+	HeightMm    int    `json:"height_mm"`
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int     `json:"rating_count"`
 	Categories  []CategoryResponse `json:"categories"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
+	// CreatedTime and UpdatedTime are the times behind CreatedAt and
+	// UpdatedAt, kept for the v2 shapes.
+	CreatedTime time.Time `json:"-"`
+	UpdatedTime time.Time `json:"-"`
+}
+
+type BookImageResponse struct {
+	ID        uint              `json:"id"`
+	Alt       string            `json:"alt"`
+	Position  int               `json:"position"`
+	IsPrimary bool              `json:"is_primary"`
+	URLs      map[string]string `json:"urls"`
 }
\ No newline at end of file
@@ -0,0 +1,45 @@
+package dto
+
+type CartResponse struct {
+	Items             []CartItemResponse         `json:"items"`
+	AppliedCoupons    []AppliedCouponResponse    `json:"applied_coupons"`
+	RejectedCoupons   []RejectedCouponResponse   `json:"rejected_coupons"`
+	Subtotal          int                        `json:"subtotal"`
+	DiscountTotal     int                        `json:"discount_total"`
+	TaxTotal          int                        `json:"tax_total"`
+	ShippingTotal     int                        `json:"shipping_total"`
+	PricesIncludeTax  bool                       `json:"prices_include_tax"`
+	Total             int                        `json:"total"`
+	PointsRedeemed    int                        `json:"points_redeemed"`
+	PointsDiscount    int                        `json:"points_discount"`
+	PointsEarned      int                        `json:"points_earned"`
+	ShippingMethods   []ShippingOptionResponse   `json:"shipping_methods"`
+	ShippingMethodID  *uint                      `json:"shipping_method_id"`
+	AppliedGiftCards  []AppliedGiftCardResponse  `json:"applied_gift_cards"`
+	RejectedGiftCards []RejectedGiftCardResponse `json:"rejected_gift_cards"`
+	GiftCardTotal     int                        `json:"gift_card_total"`
+	AmountDue         int                        `json:"amount_due"`
+}
+
+type CartItemResponse struct {
+	BookID    uint   `json:"book_id"`
+	Title     string `json:"title"`
+	UnitPrice int    `json:"unit_price"`
+	Quantity  int    `json:"quantity"`
+	Subtotal  int    `json:"subtotal"`
+	Discount  int    `json:"discount"`
+	TaxRate   int    `json:"tax_rate"`
+	Tax       int    `json:"tax"`
+	Total     int    `json:"total"`
+}
+
+type AppliedGiftCardResponse struct {
+	Code             string `json:"code"`
+	Amount           int    `json:"amount"`
+	RemainingBalance int    `json:"remaining_balance"`
+}
+
+type RejectedGiftCardResponse struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
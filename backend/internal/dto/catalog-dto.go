@@ -0,0 +1,14 @@
+package dto
+
+// BookPageResponse is a page of books and how many books match in all.
+type BookPageResponse struct {
+	Books []*BookResponse `json:"books"`
+	Total int64           `json:"total"`
+}
+
+// CategoryPageResponse is a page of categories and how many categories match
+// in all.
+type CategoryPageResponse struct {
+	Categories []*CategoryResponse `json:"categories"`
+	Total      int64               `json:"total"`
+}
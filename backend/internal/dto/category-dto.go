@@ -1,8 +1,16 @@
 package dto
 
+import "time"
+
 type CategoryResponse struct {
-	ID        uint   `json:"id"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID                uint   `json:"id"`
+	Name              string `json:"name"`
+	TaxRateID         *uint  `json:"tax_rate_id"`
+	LoyaltyMultiplier int    `json:"loyalty_multiplier"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+	// CreatedTime and UpdatedTime are the times behind CreatedAt and
+	// UpdatedAt, kept for the v2 shapes.
+	CreatedTime time.Time `json:"-"`
+	UpdatedTime time.Time `json:"-"`
 }
\ No newline at end of file
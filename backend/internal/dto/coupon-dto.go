@@ -0,0 +1,33 @@
+package dto
+
+type CouponResponse struct {
+	ID           uint   `json:"id"`
+	Code         string `json:"code"`
+	Description  string `json:"description"`
+	Type         string `json:"type"`
+	Value        int    `json:"value"`
+	MinSubtotal  int    `json:"min_subtotal"`
+	BuyQuantity  int    `json:"buy_quantity"`
+	GetQuantity  int    `json:"get_quantity"`
+	UsageLimit   int    `json:"usage_limit"`
+	PerUserLimit int    `json:"per_user_limit"`
+	StartsAt     string `json:"starts_at"`
+	EndsAt       string `json:"ends_at"`
+	IsActive     bool   `json:"is_active"`
+	CategoryIDs  []uint `json:"category_ids"`
+	BookIDs      []uint `json:"book_ids"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type AppliedCouponResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Amount      int    `json:"amount"`
+}
+
+type RejectedCouponResponse struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"io"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+)
+
+type BookFileResponse struct {
+	ID          uint   `json:"id"`
+	Format      string `json:"format"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// EbookDownloadResponse lists the download links of a purchase and how many
+// downloads it has left.
+type EbookDownloadResponse struct {
+	OrderItemID   uint                `json:"order_item_id"`
+	DownloadLimit int                 `json:"download_limit"`
+	DownloadsUsed int                 `json:"downloads_used"`
+	DownloadsLeft int                 `json:"downloads_left"`
+	Links         []EbookLinkResponse `json:"links"`
+}
+
+type EbookLinkResponse struct {
+	Format    string `json:"format"`
+	FileName  string `json:"file_name"`
+	Size      int64  `json:"size"`
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// FileStreamResponse is a stored file streamed to the client. The caller
+// closes Content.
+type FileStreamResponse struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
+
+// BookExtractResponse is a book drafted from an ebook file. Book can be sent
+// to create the book once an editor has checked it and filled in the rest,
+// and Metadata holds everything that was found, including the fields books
+// do not have.
+type BookExtractResponse struct {
+	Book     binder.CreateBook      `json:"book"`
+	Metadata BookMetadataResponse   `json:"metadata"`
+	Cover    *UploadPreviewResponse `json:"cover"`
+	Warnings []string               `json:"warnings"`
+}
+
+type BookMetadataResponse struct {
+	Format      string   `json:"format"`
+	Title       string   `json:"title"`
+	Authors     []string `json:"authors"`
+	Description string   `json:"description"`
+	Language    string   `json:"language"`
+	ISBN        string   `json:"isbn"`
+	Publisher   string   `json:"publisher"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+type GiftCardResponse struct {
+	ID                uint                          `json:"id"`
+	Code              string                        `json:"code"`
+	InitialBalance    int                           `json:"initial_balance"`
+	Balance           int                           `json:"balance"`
+	Status            string                        `json:"status"`
+	PurchasedByUserID *uint                         `json:"purchased_by_user_id"`
+	ExpiresAt         *string                       `json:"expires_at"`
+	Transactions      []GiftCardTransactionResponse `json:"transactions"`
+	CreatedAt         string                        `json:"created_at"`
+	UpdatedAt         string                        `json:"updated_at"`
+}
+
+type GiftCardTransactionResponse struct {
+	ID           uint   `json:"id"`
+	Type         string `json:"type"`
+	Amount       int    `json:"amount"`
+	BalanceAfter int    `json:"balance_after"`
+	OrderID      *uint  `json:"order_id"`
+	Note         string `json:"note"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type GiftCardBalanceResponse struct {
+	Code      string  `json:"code"`
+	Balance   int     `json:"balance"`
+	Status    string  `json:"status"`
+	ExpiresAt *string `json:"expires_at"`
+}
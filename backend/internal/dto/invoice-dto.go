@@ -0,0 +1,8 @@
+package dto
+
+// FileResponse is a stored document sent back as-is rather than as JSON.
+type FileResponse struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
@@ -0,0 +1,23 @@
+package dto
+
+type MeResponse struct {
+	UserID  uint            `json:"user_id"`
+	Loyalty LoyaltyResponse `json:"loyalty"`
+}
+
+type LoyaltyResponse struct {
+	Balance    int                    `json:"balance"`
+	PointValue int                    `json:"point_value"`
+	Entries    []LoyaltyEntryResponse `json:"entries"`
+}
+
+type LoyaltyEntryResponse struct {
+	ID        uint    `json:"id"`
+	Type      string  `json:"type"`
+	Points    int     `json:"points"`
+	Remaining int     `json:"remaining"`
+	OrderID   *uint   `json:"order_id"`
+	Note      string  `json:"note"`
+	ExpiresAt *string `json:"expires_at"`
+	CreatedAt string  `json:"created_at"`
+}
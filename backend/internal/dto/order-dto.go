@@ -0,0 +1,47 @@
+package dto
+
+type OrderResponse struct {
+	ID                 uint                    `json:"id"`
+	UserID             uint                    `json:"user_id"`
+	Status             string                  `json:"status"`
+	IsPreorder         bool                    `json:"is_preorder"`
+	Items              []OrderItemResponse     `json:"items"`
+	AppliedCoupons     []AppliedCouponResponse `json:"applied_coupons"`
+	Subtotal           int                     `json:"subtotal"`
+	DiscountTotal      int                     `json:"discount_total"`
+	TaxTotal           int                     `json:"tax_total"`
+	ShippingTotal      int                     `json:"shipping_total"`
+	PricesIncludeTax   bool                    `json:"prices_include_tax"`
+	Total              int                     `json:"total"`
+	PointsRedeemed     int                     `json:"points_redeemed"`
+	PointsDiscount     int                     `json:"points_discount"`
+	PointsEarned       int                     `json:"points_earned"`
+	GiftCardTotal      int                     `json:"gift_card_total"`
+	AmountDue          int                     `json:"amount_due"`
+	ShippingMethodID   *uint                   `json:"shipping_method_id"`
+	ShippingMethodName string                  `json:"shipping_method_name"`
+	ShippingAddress    ShippingAddressResponse `json:"shipping_address"`
+	CreatedAt          string                  `json:"created_at"`
+	UpdatedAt          string                  `json:"updated_at"`
+}
+
+type OrderItemResponse struct {
+	ID        uint   `json:"id"`
+	BookID    uint   `json:"book_id"`
+	Title     string `json:"title"`
+	UnitPrice int    `json:"unit_price"`
+	Quantity  int    `json:"quantity"`
+	Subtotal  int    `json:"subtotal"`
+	Discount  int    `json:"discount"`
+	TaxRate   int    `json:"tax_rate"`
+	Tax       int    `json:"tax"`
+	Total     int    `json:"total"`
+}
+
+type ShippingAddressResponse struct {
+	Recipient  string `json:"recipient"`
+	Phone      string `json:"phone"`
+	Address    string `json:"address"`
+	Province   string `json:"province"`
+	PostalCode string `json:"postal_code"`
+}
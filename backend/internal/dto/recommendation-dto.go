@@ -0,0 +1,12 @@
+package dto
+
+type RecommendationResponse struct {
+	Book   BookResponse `json:"book"`
+	Score  int          `json:"score"`
+	Source string       `json:"source"`
+}
+
+type SimilarBookResponse struct {
+	Book  BookResponse `json:"book"`
+	Score float64      `json:"score"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+type ReturnResponse struct {
+	ID                   uint                          `json:"id"`
+	OrderID              uint                          `json:"order_id"`
+	UserID               uint                          `json:"user_id"`
+	Status               string                        `json:"status"`
+	Reason               string                        `json:"reason"`
+	Items                []ReturnItemResponse          `json:"items"`
+	RefundableTotal      int                           `json:"refundable_total"`
+	RefundAmount         int                           `json:"refund_amount"`
+	GiftCardRefundAmount int                           `json:"gift_card_refund_amount"`
+	PointsRefunded       int                           `json:"points_refunded"`
+	UnrefundedAmount     int                           `json:"unrefunded_amount"`
+	RefundReference      string                        `json:"refund_reference"`
+	RefundedAt           *string                       `json:"refunded_at"`
+	History              []ReturnStatusHistoryResponse `json:"history"`
+	CreatedAt            string                        `json:"created_at"`
+	UpdatedAt            string                        `json:"updated_at"`
+}
+
+type ReturnItemResponse struct {
+	ID          uint `json:"id"`
+	OrderItemID uint `json:"order_item_id"`
+	BookID      uint `json:"book_id"`
+	Quantity    int  `json:"quantity"`
+	Amount      int  `json:"amount"`
+}
+
+type ReturnStatusHistoryResponse struct {
+	Status    string `json:"status"`
+	Note      string `json:"note"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type ReviewResponse struct {
+	ID               uint   `json:"id"`
+	BookID           uint   `json:"book_id"`
+	UserID           uint   `json:"user_id"`
+	Rating           int    `json:"rating"`
+	Body             string `json:"body"`
+	VerifiedPurchase bool   `json:"verified_purchase"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+	// CreatedTime and UpdatedTime are the times behind CreatedAt and
+	// UpdatedAt, kept for the v2 shapes.
+	CreatedTime time.Time `json:"-"`
+	UpdatedTime time.Time `json:"-"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+type ShippingZoneResponse struct {
+	ID        uint                         `json:"id"`
+	Name      string                       `json:"name"`
+	Regions   []ShippingZoneRegionResponse `json:"regions"`
+	Methods   []ShippingMethodResponse     `json:"methods"`
+	CreatedAt string                       `json:"created_at"`
+	UpdatedAt string                       `json:"updated_at"`
+}
+
+type ShippingZoneRegionResponse struct {
+	Province         string `json:"province"`
+	PostalCodePrefix string `json:"postal_code_prefix"`
+}
+
+type ShippingMethodResponse struct {
+	ID             uint                   `json:"id"`
+	ShippingZoneID uint                   `json:"shipping_zone_id"`
+	Name           string                 `json:"name"`
+	Rates          []ShippingRateResponse `json:"rates"`
+	CreatedAt      string                 `json:"created_at"`
+	UpdatedAt      string                 `json:"updated_at"`
+}
+
+type ShippingRateResponse struct {
+	MinWeightGrams int `json:"min_weight_grams"`
+	MaxWeightGrams int `json:"max_weight_grams"`
+	Price          int `json:"price"`
+}
+
+// ShippingOptionResponse is a shipping method offered at checkout with its
+// price for the current cart.
+type ShippingOptionResponse struct {
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	Price        int    `json:"price"`
+	FreeShipping bool   `json:"free_shipping"`
+}
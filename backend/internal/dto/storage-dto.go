@@ -0,0 +1,22 @@
+package dto
+
+type StorageReport struct {
+	CheckedAt      string               `json:"checked_at"`
+	Files          int                  `json:"files"`
+	Orphans        []StorageOrphan      `json:"orphans"`
+	PendingOrphans int                  `json:"pending_orphans"`
+	Missing        []StorageMissingFile `json:"missing"`
+}
+
+type StorageOrphan struct {
+	Key        string `json:"key"`
+	Size       int64  `json:"size"`
+	ModifiedAt string `json:"modified_at"`
+	Deleted    bool   `json:"deleted"`
+}
+
+type StorageMissingFile struct {
+	BookID  uint   `json:"book_id"`
+	ImageID uint   `json:"image_id"`
+	Key     string `json:"key"`
+}
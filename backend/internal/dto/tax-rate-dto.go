@@ -0,0 +1,9 @@
+package dto
+
+type TaxRateResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Rate      int    `json:"rate"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
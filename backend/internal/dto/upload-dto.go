@@ -0,0 +1,21 @@
+package dto
+
+type UploadResponse struct {
+	UploadID  string            `json:"upload_id"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt string            `json:"expires_at"`
+}
+
+// UploadPreviewResponse is an image the server uploaded on the client's
+// behalf, such as a cover found in an ebook. It is used like a direct upload,
+// by sending UploadID with the book.
+type UploadPreviewResponse struct {
+	UploadID    string `json:"upload_id"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	URL         string `json:"url"`
+	ExpiresAt   string `json:"expires_at"`
+}
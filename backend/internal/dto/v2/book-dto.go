@@ -0,0 +1,68 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+)
+
+type BookResponse struct {
+	ID            uint                    `json:"id"`
+	Title         string                  `json:"title"`
+	Price         int                     `json:"price"`
+	Stock         *int                    `json:"stock"`
+	Availability  string                  `json:"availability"`
+	ReleaseDate   *string                 `json:"release_date"`
+	Cover         map[string]string       `json:"cover"`
+	Images        []dto.BookImageResponse `json:"images"`
+	Description   string                  `json:"description"`
+	WeightGrams   int                     `json:"weight_grams"`
+	LengthMm      int                     `json:"length_mm"`
+	WidthMm       int                     `json:"width_mm"`
+	HeightMm      int                     `json:"height_mm"`
+	RatingAverage float64                 `json:"rating_average"`
+	RatingCount   int                     `json:"rating_count"`
+	Categories    []BookCategoryResponse  `json:"categories"`
+	CreatedAt     time.Time               `json:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at"`
+}
+
+// BookCategoryResponse is a category as listed on a book, without the fields
+// v1 leaves empty there.
+type BookCategoryResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func NewBookResponse(book *dto.BookResponse) *BookResponse {
+	if book == nil {
+		return nil
+	}
+
+	response := &BookResponse{
+		ID:            book.ID,
+		Title:         book.Title,
+		Price:         book.Price,
+		Stock:         book.Stock,
+		Availability:  book.Availability,
+		ReleaseDate:   book.ReleaseDate,
+		Cover:         book.Cover,
+		Images:        book.Images,
+		Description:   book.Description,
+		WeightGrams:   book.WeightGrams,
+		LengthMm:      book.LengthMm,
+		WidthMm:       book.WidthMm,
+		HeightMm:      book.HeightMm,
+		RatingAverage: book.RatingAverage,
+		RatingCount:   book.RatingCount,
+		Categories:    []BookCategoryResponse{},
+		CreatedAt:     book.CreatedTime,
+		UpdatedAt:     book.UpdatedTime,
+	}
+
+	for _, category := range book.Categories {
+		response.Categories = append(response.Categories, BookCategoryResponse{ID: category.ID, Name: category.Name})
+	}
+
+	return response
+}
@@ -0,0 +1,31 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+)
+
+type CategoryResponse struct {
+	ID                uint      `json:"id"`
+	Name              string    `json:"name"`
+	TaxRateID         *uint     `json:"tax_rate_id"`
+	LoyaltyMultiplier int       `json:"loyalty_multiplier"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func NewCategoryResponse(category *dto.CategoryResponse) *CategoryResponse {
+	if category == nil {
+		return nil
+	}
+
+	return &CategoryResponse{
+		ID:                category.ID,
+		Name:              category.Name,
+		TaxRateID:         category.TaxRateID,
+		LoyaltyMultiplier: category.LoyaltyMultiplier,
+		CreatedAt:         category.CreatedTime,
+		UpdatedAt:         category.UpdatedTime,
+	}
+}
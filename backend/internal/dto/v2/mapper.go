@@ -0,0 +1,39 @@
+// Package v2 holds the response shapes of API v2 and maps the v1 responses
+// the services build into them. Timestamps are time.Time, written as RFC
+// 3339, instead of the time.Time.String output v1 keeps for its clients;
+// they are taken from the times the v1 responses carry next to that output.
+package v2
+
+import "github.com/aws-cakap-intern/book-store/internal/dto"
+
+// Map turns the data of a v1 response into its v2 shape. Data that has no
+// v2 shape is returned as it is.
+func Map(data interface{}) interface{} {
+	switch data := data.(type) {
+	case *dto.BookResponse:
+		return NewBookResponse(data)
+	case []*dto.BookResponse:
+		books := make([]*BookResponse, 0, len(data))
+		for _, book := range data {
+			books = append(books, NewBookResponse(book))
+		}
+		return books
+	case *dto.CategoryResponse:
+		return NewCategoryResponse(data)
+	case []*dto.CategoryResponse:
+		categories := make([]*CategoryResponse, 0, len(data))
+		for _, category := range data {
+			categories = append(categories, NewCategoryResponse(category))
+		}
+		return categories
+	case *dto.ReviewResponse:
+		return NewReviewResponse(data)
+	case []*dto.ReviewResponse:
+		reviews := make([]*ReviewResponse, 0, len(data))
+		for _, review := range data {
+			reviews = append(reviews, NewReviewResponse(review))
+		}
+		return reviews
+	}
+	return data
+}
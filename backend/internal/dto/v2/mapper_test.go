@@ -0,0 +1,57 @@
+package v2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+)
+
+// TestMapKeepsTimestamps checks the v2 timestamps are the times of the v1
+// responses, including ones read with a monotonic clock reading.
+func TestMapKeepsTimestamps(t *testing.T) {
+	created := time.Now()
+	updated := time.Date(2024, 5, 1, 10, 30, 0, 123456789, time.FixedZone("WIB", 7*60*60))
+
+	tests := []struct {
+		name string
+		data interface{}
+		get  func(interface{}) (time.Time, time.Time)
+	}{
+		{
+			name: "book",
+			data: &dto.BookResponse{CreatedTime: created, UpdatedTime: updated},
+			get: func(data interface{}) (time.Time, time.Time) {
+				book := data.(*BookResponse)
+				return book.CreatedAt, book.UpdatedAt
+			},
+		},
+		{
+			name: "category",
+			data: &dto.CategoryResponse{CreatedTime: created, UpdatedTime: updated},
+			get: func(data interface{}) (time.Time, time.Time) {
+				category := data.(*CategoryResponse)
+				return category.CreatedAt, category.UpdatedAt
+			},
+		},
+		{
+			name: "review",
+			data: &dto.ReviewResponse{CreatedTime: created, UpdatedTime: updated},
+			get: func(data interface{}) (time.Time, time.Time) {
+				review := data.(*ReviewResponse)
+				return review.CreatedAt, review.UpdatedAt
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCreated, gotUpdated := tt.get(Map(tt.data))
+			if !gotCreated.Equal(created) {
+				t.Errorf("created at %v, want %v", gotCreated, created)
+			}
+			if !gotUpdated.Equal(updated) {
+				t.Errorf("updated at %v, want %v", gotUpdated, updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+)
+
+type ReviewResponse struct {
+	ID               uint      `json:"id"`
+	BookID           uint      `json:"book_id"`
+	UserID           uint      `json:"user_id"`
+	Rating           int       `json:"rating"`
+	Body             string    `json:"body"`
+	VerifiedPurchase bool      `json:"verified_purchase"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func NewReviewResponse(review *dto.ReviewResponse) *ReviewResponse {
+	if review == nil {
+		return nil
+	}
+
+	return &ReviewResponse{
+		ID:               review.ID,
+		BookID:           review.BookID,
+		UserID:           review.UserID,
+		Rating:           review.Rating,
+		Body:             review.Body,
+		VerifiedPurchase: review.VerifiedPurchase,
+		CreatedAt:        review.CreatedTime,
+		UpdatedAt:        review.UpdatedTime,
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+type WishlistResponse struct {
+	ID         uint                   `json:"id"`
+	Name       string                 `json:"name"`
+	ShareToken string                 `json:"share_token,omitempty"`
+	Items      []WishlistItemResponse `json:"items"`
+	CreatedAt  string                 `json:"created_at"`
+	UpdatedAt  string                 `json:"updated_at"`
+}
+
+type WishlistItemResponse struct {
+	BookID         uint              `json:"book_id"`
+	Title          string            `json:"title"`
+	Cover          map[string]string `json:"cover"`
+	Price          int               `json:"price"`
+	PriceWhenAdded int               `json:"price_when_added"`
+	PriceDropped   bool              `json:"price_dropped"`
+	PriceDrop      int               `json:"price_drop"`
+	InStock        bool              `json:"in_stock"`
+	AddedAt        string            `json:"added_at"`
+}
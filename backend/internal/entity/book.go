@@ -4,13 +4,29 @@ import (
 	"time"
 )
 
+const (
+	BookAvailabilityAvailable = "available"
+	BookAvailabilityPreorder  = "preorder"
+)
+
+// Book is a title in the catalog. Stock is nil while the store does not track
+// how many copies it has, and such a book never sells out.
 type Book struct {
-	ID          uint       `gorm:"primaryKey;autoIncrement"`
-	Title       string     `gorm:"type:varchar(255);not null"`
-	Price       int        `gorm:"type:int;not null"`
-	ImagePath   string     `gorm:"type:varchar(255);not null"`
-	Description string     `gorm:"type:text;not null"`
-	Categories  []Category `gorm:"many2many:book_categories;"`
-	CreatedAt   time.Time  `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
+	ID            uint        `gorm:"primaryKey;autoIncrement"`
+	Title         string      `gorm:"type:varchar(255);not null"`
+	Price         int         `gorm:"type:int;not null"`
+	Stock         *int        `gorm:"type:int;default:null"`
+	Availability  string      `gorm:"type:varchar(32);not null;default:available"`
+	ReleaseDate   *time.Time  `gorm:"type:date;default:null"`
+	Images        []BookImage `gorm:"foreignKey:BookID"`
+	Description   string      `gorm:"type:text;not null"`
+	WeightGrams   int         `gorm:"type:int;not null"`
+	LengthMm      int         `gorm:"type:int;not null"`
+	WidthMm       int         `gorm:"type:int;not null"`
+	HeightMm      int         `gorm:"type:int;not null"`
+	RatingAverage float64     `gorm:"type:decimal(3,2);not null;->"`
+	RatingCount   int         `gorm:"type:int;not null;->"`
+	Categories    []Category  `gorm:"many2many:book_categories;"`
+	CreatedAt     time.Time   `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time   `gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+)
+
+// BookFile is an ebook edition of a book, such as a PDF or EPUB. It is kept
+// in private storage and only reaches buyers through signed download links.
+type BookFile struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	BookID      uint      `gorm:"not null"`
+	Format      string    `gorm:"type:varchar(16);not null"`
+	Path        string    `gorm:"type:varchar(255);not null"`
+	FileName    string    `gorm:"type:varchar(255);not null"`
+	ContentType string    `gorm:"type:varchar(64);not null"`
+	Size        int64     `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// EbookDownload logs one download of a book file by the buyer of an order
+// item. The downloads of an item count against its download limit.
+type EbookDownload struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	OrderItemID uint      `gorm:"not null"`
+	BookFileID  uint      `gorm:"not null"`
+	UserID      uint      `gorm:"not null"`
+	Format      string    `gorm:"type:varchar(16);not null"`
+	IPAddress   string    `gorm:"type:varchar(64);not null"`
+	UserAgent   string    `gorm:"type:varchar(255);not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+)
+
+// BookImage is one picture in a book's gallery. Position orders the gallery
+// and the primary image is the cover shown in listings. Variants maps the
+// names of the resized copies to their storage keys.
+type BookImage struct {
+	ID        uint              `gorm:"primaryKey;autoIncrement"`
+	BookID    uint              `gorm:"not null"`
+	Path      string            `gorm:"type:varchar(255);not null"`
+	Variants  map[string]string `gorm:"type:text;serializer:json"`
+	Alt       string            `gorm:"type:varchar(255);not null"`
+	Position  int               `gorm:"type:int;not null"`
+	IsPrimary bool              `gorm:"not null"`
+	CreatedAt time.Time         `gorm:"autoCreateTime"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+)
+
+// BookRecommendation is a precomputed "customers also bought" pair. Score is
+// the number of orders that contained both books.
+type BookRecommendation struct {
+	BookID            uint      `gorm:"primaryKey"`
+	RecommendedBookID uint      `gorm:"primaryKey"`
+	RecommendedBook   Book      `gorm:"foreignKey:RecommendedBookID"`
+	Score             int       `gorm:"type:int;not null"`
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+}
@@ -5,9 +5,12 @@ import (
 )
 
 type Category struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	Name      string    `gorm:"type:varchar(255);not null"`
-	Books     []Book    `gorm:"many2many:book_category;"`
-	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+	ID                uint      `gorm:"primaryKey;autoIncrement"`
+	Name              string    `gorm:"type:varchar(255);not null"`
+	TaxRateID         *uint     `gorm:"default:null"`
+	TaxRate           *TaxRate  `gorm:"foreignKey:TaxRateID"`
+	LoyaltyMultiplier int       `gorm:"type:int;not null;default:100"`
+	Books             []Book    `gorm:"many2many:book_category;"`
+	CreatedAt         time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time `gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
 }
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	CouponTypePercentage  = "percentage"
+	CouponTypeFixedAmount = "fixed_amount"
+	CouponTypeBuyXGetY    = "buy_x_get_y"
+)
+
+// Coupon is a discount rule that is applied to a cart when its code is entered.
+// A coupon without categories or books targets the whole cart.
+type Coupon struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement"`
+	Code         string     `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Description  string     `gorm:"type:varchar(255)"`
+	Type         string     `gorm:"type:varchar(32);not null"`
+	Value        int        `gorm:"type:int;not null"`
+	MinSubtotal  int        `gorm:"type:int;not null"`
+	BuyQuantity  int        `gorm:"type:int;not null"`
+	GetQuantity  int        `gorm:"type:int;not null"`
+	UsageLimit   int        `gorm:"type:int;not null"`
+	PerUserLimit int        `gorm:"type:int;not null"`
+	StartsAt     *time.Time `gorm:"type:timestamp"`
+	EndsAt       *time.Time `gorm:"type:timestamp"`
+	IsActive     bool       `gorm:"not null"`
+	Categories   []Category `gorm:"many2many:coupon_categories;"`
+	Books        []Book     `gorm:"many2many:coupon_books;"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+}
+
+type CouponRedemption struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	CouponID  uint      `gorm:"not null"`
+	Coupon    *Coupon   `gorm:"foreignKey:CouponID"`
+	UserID    uint      `gorm:"not null"`
+	OrderID   uint      `gorm:"not null"`
+	Amount    int       `gorm:"type:int;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
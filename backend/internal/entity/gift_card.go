@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	GiftCardStatusActive = "active"
+	GiftCardStatusVoid   = "void"
+)
+
+const (
+	GiftCardTransactionIssue  = "issue"
+	GiftCardTransactionRedeem = "redeem"
+	GiftCardTransactionRefund = "refund"
+	GiftCardTransactionAdjust = "adjust"
+	GiftCardTransactionVoid   = "void"
+)
+
+type GiftCard struct {
+	ID                uint                  `gorm:"primaryKey;autoIncrement"`
+	Code              string                `gorm:"type:varchar(32);uniqueIndex;not null"`
+	InitialBalance    int                   `gorm:"type:int;not null"`
+	Balance           int                   `gorm:"type:int;not null"`
+	Status            string                `gorm:"type:varchar(32);not null"`
+	PurchasedByUserID *uint                 `gorm:"default:null"`
+	ExpiresAt         *time.Time            `gorm:"default:null"`
+	Transactions      []GiftCardTransaction `gorm:"foreignKey:GiftCardID"`
+	CreatedAt         time.Time             `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time             `gorm:"autoUpdateTime"`
+}
+
+// GiftCardTransaction is one entry in a card's ledger. Amount is negative
+// when it takes money off the card.
+type GiftCardTransaction struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	GiftCardID   uint      `gorm:"not null"`
+	GiftCard     *GiftCard `gorm:"foreignKey:GiftCardID"`
+	Type         string    `gorm:"type:varchar(32);not null"`
+	Amount       int       `gorm:"type:int;not null"`
+	BalanceAfter int       `gorm:"type:int;not null"`
+	OrderID      *uint     `gorm:"default:null"`
+	Note         string    `gorm:"type:varchar(255)"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
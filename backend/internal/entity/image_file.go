@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+)
+
+// ImageFile is a stored image shared by every book image with the same
+// content. Hash is the SHA-256 of the original, and RefCount is the number of
+// book images using the files; they are deleted when it drops to zero.
+type ImageFile struct {
+	Hash      string            `gorm:"type:char(64);primaryKey"`
+	Path      string            `gorm:"type:varchar(255);not null"`
+	Variants  map[string]string `gorm:"type:text;serializer:json"`
+	RefCount  int               `gorm:"type:int;not null"`
+	CreatedAt time.Time         `gorm:"autoCreateTime"`
+	UpdatedAt time.Time         `gorm:"autoUpdateTime"`
+}
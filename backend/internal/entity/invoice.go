@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+)
+
+// Invoice is issued once per order. Numbers run from 1 each year without gaps.
+type Invoice struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	OrderID   uint      `gorm:"not null;uniqueIndex"`
+	Number    string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	Year      int       `gorm:"not null"`
+	Sequence  int       `gorm:"not null"`
+	FilePath  string    `gorm:"type:varchar(255);not null"`
+	IssuedAt  time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// InvoiceSequence holds the last invoice number handed out in a year.
+type InvoiceSequence struct {
+	Year       int       `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int       `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	LoyaltyEntryEarn    = "earn"
+	LoyaltyEntryRedeem  = "redeem"
+	LoyaltyEntryExpire  = "expire"
+	LoyaltyEntryReverse = "reverse"
+)
+
+// LoyaltyEntry is one line in a customer's points ledger. Points is negative
+// when points are taken away. Entries that add points also track how many of
+// them are still unspent in Remaining, so points can be spent and expired
+// oldest first.
+type LoyaltyEntry struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UserID    uint      `gorm:"not null"`
+	Type      string    `gorm:"type:varchar(32);not null"`
+	Points    int       `gorm:"type:int;not null"`
+	Remaining int       `gorm:"type:int;not null"`
+	OrderID   *uint     `gorm:"default:null"`
+	Note      string    `gorm:"type:varchar(255)"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	OrderStatusPending    = "pending"
+	OrderStatusPreordered = "preordered"
+	OrderStatusCapturing  = "capturing"
+	OrderStatusPaid       = "paid"
+	OrderStatusShipped    = "shipped"
+	OrderStatusDelivered  = "delivered"
+	OrderStatusCancelling = "cancelling"
+	OrderStatusCancelled  = "cancelled"
+)
+
+type Order struct {
+	ID                   uint                  `gorm:"primaryKey;autoIncrement"`
+	UserID               uint                  `gorm:"not null"`
+	Status               string                `gorm:"type:varchar(32);not null"`
+	IsPreorder           bool                  `gorm:"not null"`
+	PaymentReference     string                `gorm:"type:varchar(255)"`
+	PaidAt               *time.Time            `gorm:"default:null"`
+	Subtotal             int                   `gorm:"type:int;not null"`
+	DiscountTotal        int                   `gorm:"type:int;not null"`
+	TaxTotal             int                   `gorm:"type:int;not null"`
+	ShippingTotal        int                   `gorm:"type:int;not null"`
+	PricesIncludeTax     bool                  `gorm:"not null"`
+	ShippingMethodID     *uint                 `gorm:"default:null"`
+	ShippingMethodName   string                `gorm:"type:varchar(255)"`
+	ShippingRecipient    string                `gorm:"type:varchar(255)"`
+	ShippingPhone        string                `gorm:"type:varchar(32)"`
+	ShippingAddress      string                `gorm:"type:text"`
+	ShippingProvince     string                `gorm:"type:varchar(255)"`
+	ShippingPostalCode   string                `gorm:"type:varchar(10)"`
+	Total                int                   `gorm:"type:int;not null"`
+	PointsRedeemed       int                   `gorm:"type:int;not null"`
+	PointsDiscount       int                   `gorm:"type:int;not null"`
+	PointsEarned         int                   `gorm:"type:int;not null"`
+	GiftCardTotal        int                   `gorm:"type:int;not null"`
+	AmountDue            int                   `gorm:"type:int;not null"`
+	Items                []OrderItem           `gorm:"foreignKey:OrderID"`
+	CouponRedemptions    []CouponRedemption    `gorm:"foreignKey:OrderID"`
+	GiftCardTransactions []GiftCardTransaction `gorm:"foreignKey:OrderID"`
+	CreatedAt            time.Time             `gorm:"autoCreateTime"`
+	UpdatedAt            time.Time             `gorm:"autoUpdateTime"`
+}
+
+type OrderItem struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	OrderID   uint   `gorm:"not null"`
+	BookID    uint   `gorm:"not null"`
+	Title     string `gorm:"type:varchar(255);not null"`
+	UnitPrice int    `gorm:"type:int;not null"`
+	Quantity  int    `gorm:"type:int;not null"`
+	Subtotal  int    `gorm:"type:int;not null"`
+	Discount  int    `gorm:"type:int;not null"`
+	TaxRate   int    `gorm:"type:int;not null"`
+	Tax       int    `gorm:"type:int;not null"`
+	Total     int    `gorm:"type:int;not null"`
+}
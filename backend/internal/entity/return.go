@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	ReturnStatusRequested = "requested"
+	ReturnStatusApproved  = "approved"
+	ReturnStatusRejected  = "rejected"
+	ReturnStatusReceived  = "received"
+	ReturnStatusRefunding = "refunding"
+	ReturnStatusRefunded  = "refunded"
+)
+
+// Return is a customer's request to send back lines of a delivered order. The
+// refund is paid back the way the order was paid: RefundAmount through the
+// payment provider, GiftCardRefundAmount onto the gift cards and
+// PointsRefunded as loyalty points. UnrefundedAmount is the part of the refund
+// that was not paid back, because earlier refunds of the order had used up
+// what was paid that way.
+type Return struct {
+	ID                   uint                  `gorm:"primaryKey;autoIncrement"`
+	OrderID              uint                  `gorm:"not null"`
+	UserID               uint                  `gorm:"not null"`
+	Status               string                `gorm:"type:varchar(32);not null"`
+	Reason               string                `gorm:"type:text;not null"`
+	RefundAmount         int                   `gorm:"type:int;not null"`
+	GiftCardRefundAmount int                   `gorm:"type:int;not null"`
+	PointsRefunded       int                   `gorm:"type:int;not null"`
+	UnrefundedAmount     int                   `gorm:"type:int;not null"`
+	RefundReference      string                `gorm:"type:varchar(255)"`
+	RefundedAt           *time.Time            `gorm:"default:null"`
+	Items                []ReturnItem          `gorm:"foreignKey:ReturnID"`
+	Histories            []ReturnStatusHistory `gorm:"foreignKey:ReturnID"`
+	CreatedAt            time.Time             `gorm:"autoCreateTime"`
+	UpdatedAt            time.Time             `gorm:"autoUpdateTime"`
+}
+
+// ReturnItem is the quantity of one order line being returned. Amount is the
+// most that can be refunded for it.
+type ReturnItem struct {
+	ID          uint `gorm:"primaryKey;autoIncrement"`
+	ReturnID    uint `gorm:"not null"`
+	OrderItemID uint `gorm:"not null"`
+	BookID      uint `gorm:"not null"`
+	Quantity    int  `gorm:"type:int;not null"`
+	Amount      int  `gorm:"type:int;not null"`
+}
+
+type ReturnStatusHistory struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	ReturnID  uint      `gorm:"not null"`
+	Status    string    `gorm:"type:varchar(32);not null"`
+	Note      string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+)
+
+type Review struct {
+	ID               uint      `gorm:"primaryKey;autoIncrement"`
+	BookID           uint      `gorm:"not null"`
+	UserID           uint      `gorm:"not null"`
+	Rating           int       `gorm:"type:tinyint;not null"`
+	Body             string    `gorm:"type:text"`
+	VerifiedPurchase bool      `gorm:"not null"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
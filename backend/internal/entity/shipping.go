@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+)
+
+// ShippingZone groups the regions that share the same shipping methods. A
+// region matches on province, postal code prefix, or both.
+type ShippingZone struct {
+	ID        uint                 `gorm:"primaryKey;autoIncrement"`
+	Name      string               `gorm:"type:varchar(255);not null"`
+	Regions   []ShippingZoneRegion `gorm:"foreignKey:ShippingZoneID"`
+	Methods   []ShippingMethod     `gorm:"foreignKey:ShippingZoneID"`
+	CreatedAt time.Time            `gorm:"autoCreateTime"`
+	UpdatedAt time.Time            `gorm:"autoUpdateTime"`
+}
+
+type ShippingZoneRegion struct {
+	ID               uint   `gorm:"primaryKey;autoIncrement"`
+	ShippingZoneID   uint   `gorm:"not null"`
+	Province         string `gorm:"type:varchar(255)"`
+	PostalCodePrefix string `gorm:"type:varchar(10)"`
+}
+
+type ShippingMethod struct {
+	ID             uint           `gorm:"primaryKey;autoIncrement"`
+	ShippingZoneID uint           `gorm:"not null"`
+	Name           string         `gorm:"type:varchar(255);not null"`
+	Rates          []ShippingRate `gorm:"foreignKey:ShippingMethodID"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+}
+
+// ShippingRate is one weight bracket of a method's rate table. A MaxWeightGrams
+// of 0 leaves the bracket open-ended.
+type ShippingRate struct {
+	ID               uint `gorm:"primaryKey;autoIncrement"`
+	ShippingMethodID uint `gorm:"not null"`
+	MinWeightGrams   int  `gorm:"type:int;not null"`
+	MaxWeightGrams   int  `gorm:"type:int;not null"`
+	Price            int  `gorm:"type:int;not null"`
+}
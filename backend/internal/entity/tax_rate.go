@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"time"
+)
+
+// TaxRate is a named tax rate in basis points, so 1100 is 11%.
+type TaxRate struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	Rate      int       `gorm:"type:int;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+)
+
+// Upload is a direct upload waiting to be used by a book. The client sends
+// the file straight to storage under ObjectKey, and the upload is removed
+// once a book takes the image or it expires.
+type Upload struct {
+	ID          string    `gorm:"type:char(36);primaryKey"`
+	ObjectKey   string    `gorm:"type:varchar(255);not null"`
+	ContentType string    `gorm:"type:varchar(64);not null"`
+	Size        int64     `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+)
+
+// Wishlist is a named list of books saved by a user. Anyone holding the share
+// token can read it.
+type Wishlist struct {
+	ID         uint           `gorm:"primaryKey;autoIncrement"`
+	UserID     uint           `gorm:"not null"`
+	Name       string         `gorm:"type:varchar(255);not null"`
+	ShareToken string         `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Items      []WishlistItem `gorm:"foreignKey:WishlistID"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
+}
+
+// WishlistItem keeps the price at the time the book was saved so price drops
+// can be reported.
+type WishlistItem struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`
+	WishlistID     uint      `gorm:"not null"`
+	BookID         uint      `gorm:"not null"`
+	Book           Book      `gorm:"foreignKey:BookID"`
+	PriceWhenAdded int       `gorm:"type:int;not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	v2 "github.com/aws-cakap-intern/book-store/internal/dto/v2"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type bookResolver struct {
+	book *v2.BookResponse
+}
+
+func newBookResolver(book *dto.BookResponse) *bookResolver {
+	return &bookResolver{book: v2.NewBookResponse(book)}
+}
+
+func (r *bookResolver) ID() gql.ID {
+	return toID(r.book.ID)
+}
+
+func (r *bookResolver) Title() string {
+	return r.book.Title
+}
+
+func (r *bookResolver) Price() int32 {
+	return int32(r.book.Price)
+}
+
+func (r *bookResolver) Stock() *int32 {
+	if r.book.Stock == nil {
+		return nil
+	}
+	stock := int32(*r.book.Stock)
+	return &stock
+}
+
+func (r *bookResolver) Availability() string {
+	return strings.ToUpper(r.book.Availability)
+}
+
+func (r *bookResolver) ReleaseDate() *string {
+	return r.book.ReleaseDate
+}
+
+func (r *bookResolver) Description() string {
+	return r.book.Description
+}
+
+func (r *bookResolver) CoverURL(args struct{ Size string }) *string {
+	return sizeURL(r.book.Cover, args.Size)
+}
+
+func (r *bookResolver) Images() []*bookImageResolver {
+	resolvers := []*bookImageResolver{}
+	for i := range r.book.Images {
+		resolvers = append(resolvers, &bookImageResolver{image: &r.book.Images[i]})
+	}
+	return resolvers
+}
+
+func (r *bookResolver) WeightGrams() int32 {
+	return int32(r.book.WeightGrams)
+}
+
+func (r *bookResolver) LengthMm() int32 {
+	return int32(r.book.LengthMm)
+}
+
+func (r *bookResolver) WidthMm() int32 {
+	return int32(r.book.WidthMm)
+}
+
+func (r *bookResolver) HeightMm() int32 {
+	return int32(r.book.HeightMm)
+}
+
+func (r *bookResolver) RatingAverage() float64 {
+	return r.book.RatingAverage
+}
+
+func (r *bookResolver) RatingCount() int32 {
+	return int32(r.book.RatingCount)
+}
+
+// Categories are loaded through the request loader rather than taken from
+// the book, so a page of books costs one query for all their categories.
+func (r *bookResolver) Categories(ctx context.Context) ([]*categoryResolver, error) {
+	categories, err := loadersFrom(ctx).categoriesOfBook.Load(ctx, r.book.ID)()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := []*categoryResolver{}
+	for _, category := range categories {
+		resolvers = append(resolvers, newCategoryResolver(category))
+	}
+	return resolvers, nil
+}
+
+func (r *bookResolver) CreatedAt() gql.Time {
+	return gql.Time{Time: r.book.CreatedAt}
+}
+
+func (r *bookResolver) UpdatedAt() gql.Time {
+	return gql.Time{Time: r.book.UpdatedAt}
+}
+
+type bookImageResolver struct {
+	image *dto.BookImageResponse
+}
+
+func (r *bookImageResolver) ID() gql.ID {
+	return toID(r.image.ID)
+}
+
+func (r *bookImageResolver) Alt() string {
+	return r.image.Alt
+}
+
+func (r *bookImageResolver) Position() int32 {
+	return int32(r.image.Position)
+}
+
+func (r *bookImageResolver) IsPrimary() bool {
+	return r.image.IsPrimary
+}
+
+func (r *bookImageResolver) URL(args struct{ Size string }) *string {
+	return sizeURL(r.image.URLs, args.Size)
+}
+
+func sizeURL(urls map[string]string, size string) *string {
+	url, ok := urls[size]
+	if !ok {
+		return nil
+	}
+	return &url
+}
+
+func toID(id uint) gql.ID {
+	return gql.ID(strconv.FormatUint(uint64(id), 10))
+}
@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	v2 "github.com/aws-cakap-intern/book-store/internal/dto/v2"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type categoryResolver struct {
+	category *v2.CategoryResponse
+}
+
+func newCategoryResolver(category *dto.CategoryResponse) *categoryResolver {
+	return &categoryResolver{category: v2.NewCategoryResponse(category)}
+}
+
+func (r *categoryResolver) ID() gql.ID {
+	return toID(r.category.ID)
+}
+
+func (r *categoryResolver) Name() string {
+	return r.category.Name
+}
+
+func (r *categoryResolver) TaxRateID() *gql.ID {
+	if r.category.TaxRateID == nil {
+		return nil
+	}
+	id := toID(*r.category.TaxRateID)
+	return &id
+}
+
+func (r *categoryResolver) LoyaltyMultiplier() int32 {
+	return int32(r.category.LoyaltyMultiplier)
+}
+
+// Books loads the book IDs of every category in the query at once, then the
+// books themselves, which are shared with any other part of the query.
+func (r *categoryResolver) Books(ctx context.Context, args struct{ First int32 }) ([]*bookResolver, error) {
+	loaders := loadersFrom(ctx)
+
+	bookIDs, err := loaders.bookIDsOfCategory.Load(ctx, r.category.ID)()
+	if err != nil {
+		return nil, err
+	}
+	if first := pageSize(args.First); len(bookIDs) > first {
+		bookIDs = bookIDs[:first]
+	}
+
+	books, errs := loaders.book.LoadMany(ctx, bookIDs)()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resolvers := []*bookResolver{}
+	for _, book := range books {
+		if book != nil {
+			resolvers = append(resolvers, newBookResolver(book))
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *categoryResolver) BookCount(ctx context.Context) (int32, error) {
+	bookIDs, err := loadersFrom(ctx).bookIDsOfCategory.Load(ctx, r.category.ID)()
+	if err != nil {
+		return 0, err
+	}
+	return int32(len(bookIDs)), nil
+}
+
+func (r *categoryResolver) CreatedAt() gql.Time {
+	return gql.Time{Time: r.category.CreatedAt}
+}
+
+func (r *categoryResolver) UpdatedAt() gql.Time {
+	return gql.Time{Time: r.category.UpdatedAt}
+}
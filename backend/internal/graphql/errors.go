@@ -0,0 +1,35 @@
+package graphql
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+)
+
+// apiError reports a service failure with its HTTP status as the code
+// extension, so clients can tell a missing book from a server error.
+type apiError struct {
+	execption *execption.ApiExecption
+}
+
+func (e apiError) Error() string {
+	return e.execption.Message
+}
+
+func (e apiError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.execption.Status}
+}
+
+// inputError reports invalid mutation input, mapping each invalid field to
+// its problem as the REST API does in the data of a 400 response.
+type inputError struct {
+	fields map[string]string
+}
+
+func (e inputError) Error() string {
+	return "validasi input gagal"
+}
+
+func (e inputError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": http.StatusBadRequest, "fields": e.fields}
+}
@@ -0,0 +1,52 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// loaders batch the relations asked for by one query: the categories of all
+// the books on a page are read together, and so are the books of all the
+// categories. They cache for the length of the query only.
+type loaders struct {
+	book              *dataloader.Loader[uint, *dto.BookResponse]
+	categoriesOfBook  *dataloader.Loader[uint, []*dto.CategoryResponse]
+	bookIDsOfCategory *dataloader.Loader[uint, []uint]
+}
+
+type loadersKey struct{}
+
+func newLoaders(catalogService service.CatalogService) *loaders {
+	return &loaders{
+		book:              dataloader.NewBatchedLoader(batch(catalogService.GetBooksByIDs)),
+		categoriesOfBook:  dataloader.NewBatchedLoader(batch(catalogService.GetCategoriesOfBooks)),
+		bookIDsOfCategory: dataloader.NewBatchedLoader(batch(catalogService.GetBookIDsOfCategories)),
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// batch turns a service lookup by IDs into a batch function, which must
+// return one result per key in the order of the keys. Keys missing from the
+// lookup get the zero value.
+func batch[V any](lookup func(ids []uint) (map[uint]V, *execption.ApiExecption)) dataloader.BatchFunc[uint, V] {
+	return func(_ context.Context, keys []uint) []*dataloader.Result[V] {
+		values, execption := lookup(keys)
+
+		results := make([]*dataloader.Result[V], len(keys))
+		for i, key := range keys {
+			if execption != nil {
+				results[i] = &dataloader.Result[V]{Error: apiError{execption}}
+				continue
+			}
+			results[i] = &dataloader.Result[V]{Data: values[key]}
+		}
+		return results
+	}
+}
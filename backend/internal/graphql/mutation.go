@@ -0,0 +1,256 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+	"github.com/aws-cakap-intern/book-store/pkg/validator"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+type bookInput struct {
+	Title        string
+	Price        int32
+	Stock        *int32
+	Availability *string
+	ReleaseDate  *string
+	Description  string
+	WeightGrams  *int32
+	LengthMm     *int32
+	WidthMm      *int32
+	HeightMm     *int32
+	CategoryIDs  []gql.ID
+	UploadID     *string
+}
+
+type categoryInput struct {
+	Name              string
+	TaxRateID         *gql.ID
+	LoyaltyMultiplier *int32
+}
+
+// CreateBook takes its cover from a direct upload, since GraphQL requests
+// carry no files.
+func (r *Resolver) CreateBook(args struct{ Input bookInput }) (*bookResolver, error) {
+	in := args.Input
+	input := binder.CreateBook{
+		Title:        in.Title,
+		Price:        int(in.Price),
+		Stock:        toInt(in.Stock),
+		Availability: strings.ToLower(stringValue(in.Availability)),
+		ReleaseDate:  stringValue(in.ReleaseDate),
+		Description:  in.Description,
+		WeightGrams:  intValue(in.WeightGrams),
+		LengthMm:     intValue(in.LengthMm),
+		WidthMm:      intValue(in.WidthMm),
+		HeightMm:     intValue(in.HeightMm),
+		UploadID:     stringValue(in.UploadID),
+		Categories:   joinIDs(in.CategoryIDs),
+	}
+
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+	if input.UploadID == "" {
+		return nil, inputError{fields: map[string]string{"uploadId": "uploadId is required"}}
+	}
+
+	categoryIDs, err := parseIDs("categoryIds", in.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	image, execption := r.uploadService.OpenUpload(input.UploadID)
+	if execption != nil {
+		return nil, apiError{execption}
+	}
+	defer image.File.Close()
+
+	book, execption := r.bookService.CreateBook(input, categoryIDs, image)
+	if execption != nil {
+		return nil, apiError{execption}
+	}
+
+	r.uploadService.FinishUpload(input.UploadID)
+	return newBookResolver(book), nil
+}
+
+func (r *Resolver) UpdateBook(args struct {
+	ID    gql.ID
+	Input bookInput
+}) (*bookResolver, error) {
+	in := args.Input
+	input := binder.UpdateBook{
+		ID:           string(args.ID),
+		Title:        in.Title,
+		Price:        int(in.Price),
+		Stock:        toInt(in.Stock),
+		Availability: strings.ToLower(stringValue(in.Availability)),
+		ReleaseDate:  stringValue(in.ReleaseDate),
+		Description:  in.Description,
+		WeightGrams:  intValue(in.WeightGrams),
+		LengthMm:     intValue(in.LengthMm),
+		WidthMm:      intValue(in.WidthMm),
+		HeightMm:     intValue(in.HeightMm),
+		UploadID:     stringValue(in.UploadID),
+		Categories:   joinIDs(in.CategoryIDs),
+	}
+
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	categoryIDs, err := parseIDs("categoryIds", in.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var image *upload.Image
+	if input.UploadID != "" {
+		opened, execption := r.uploadService.OpenUpload(input.UploadID)
+		if execption != nil {
+			return nil, apiError{execption}
+		}
+		defer opened.File.Close()
+		image = opened
+	}
+
+	book, execption := r.bookService.UpdateBook(input, categoryIDs, image)
+	if execption != nil {
+		return nil, apiError{execption}
+	}
+
+	if input.UploadID != "" {
+		r.uploadService.FinishUpload(input.UploadID)
+	}
+	return newBookResolver(book), nil
+}
+
+func (r *Resolver) DeleteBook(args struct{ ID gql.ID }) (bool, error) {
+	if execption := r.bookService.DeleteBook(string(args.ID)); execption != nil {
+		return false, apiError{execption}
+	}
+	return true, nil
+}
+
+func (r *Resolver) CreateCategory(args struct{ Input categoryInput }) (*categoryResolver, error) {
+	taxRateID, err := parseOptionalID("taxRateId", args.Input.TaxRateID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := binder.CreateCategory{
+		Name:              args.Input.Name,
+		TaxRateID:         taxRateID,
+		LoyaltyMultiplier: toInt(args.Input.LoyaltyMultiplier),
+	}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	category, execption := r.categoryService.CreateCategory(input)
+	if execption != nil {
+		return nil, apiError{execption}
+	}
+	return newCategoryResolver(category), nil
+}
+
+func (r *Resolver) UpdateCategory(args struct {
+	ID    gql.ID
+	Input categoryInput
+}) (*categoryResolver, error) {
+	taxRateID, err := parseOptionalID("taxRateId", args.Input.TaxRateID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := binder.UpdateCategory{
+		ID:                string(args.ID),
+		Name:              args.Input.Name,
+		TaxRateID:         taxRateID,
+		LoyaltyMultiplier: toInt(args.Input.LoyaltyMultiplier),
+	}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	category, execption := r.categoryService.UpdateCategory(input)
+	if execption != nil {
+		return nil, apiError{execption}
+	}
+	return newCategoryResolver(category), nil
+}
+
+func (r *Resolver) DeleteCategory(args struct{ ID gql.ID }) (bool, error) {
+	if execption := r.categoryService.DeleteCategory(string(args.ID)); execption != nil {
+		return false, apiError{execption}
+	}
+	return true, nil
+}
+
+// validate checks a binder with the rules of the REST API and reports the
+// invalid fields under their GraphQL names.
+func validate(input interface{}) error {
+	validationErrors := validator.Validate(input)
+	if validationErrors == nil {
+		return nil
+	}
+
+	fields := map[string]string{}
+	for name, message := range validationErrors {
+		fields[fieldName(name)] = message
+	}
+	return inputError{fields: fields}
+}
+
+// fieldName turns a form field like release_date into the GraphQL name
+// releaseDate. The comma separated categories field is the categoryIds list.
+func fieldName(name string) string {
+	if name == "categories" {
+		return "categoryIds"
+	}
+
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] == "id" {
+			parts[i] = "Id"
+			continue
+		}
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+func parseOptionalID(field string, id *gql.ID) (*uint, error) {
+	if id == nil {
+		return nil, nil
+	}
+	parsed, err := parseIDs(field, []gql.ID{*id})
+	if err != nil {
+		return nil, err
+	}
+	return &parsed[0], nil
+}
+
+func joinIDs(ids []gql.ID) string {
+	var parts []string
+	for _, id := range ids {
+		parts = append(parts, string(id))
+	}
+	return strings.Join(parts, ",")
+}
+
+func intValue(n *int32) int {
+	if n == nil {
+		return 0
+	}
+	return int(*n)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
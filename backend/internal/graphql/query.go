@@ -0,0 +1,257 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+const (
+	maxPageSize  = 100
+	cursorPrefix = "offset:"
+)
+
+type bookFilter struct {
+	Title        *string
+	CategoryIDs  *[]gql.ID
+	Availability *string
+	MinPrice     *int32
+	MaxPrice     *int32
+}
+
+type categoryFilter struct {
+	Name *string
+}
+
+// Books primes the book loader with the page, so the books of a category
+// that are already on it are not read again.
+func (r *Resolver) Books(ctx context.Context, args struct {
+	First  int32
+	After  *string
+	Filter *bookFilter
+}) (*bookConnectionResolver, error) {
+	offset, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	search := repository.BookSearch{Limit: pageSize(args.First), Offset: offset}
+	if filter := args.Filter; filter != nil {
+		if filter.Title != nil {
+			search.Title = *filter.Title
+		}
+		if filter.CategoryIDs != nil {
+			search.CategoryIDs, err = parseIDs("categoryIds", *filter.CategoryIDs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if filter.Availability != nil {
+			search.Availability = strings.ToLower(*filter.Availability)
+		}
+		search.MinPrice = toInt(filter.MinPrice)
+		search.MaxPrice = toInt(filter.MaxPrice)
+	}
+
+	page, execption := r.catalogService.SearchBooks(search)
+	if execption != nil {
+		return nil, apiError{execption}
+	}
+
+	loaders := loadersFrom(ctx)
+	for _, book := range page.Books {
+		loaders.book.Prime(ctx, book.ID, book)
+	}
+	return &bookConnectionResolver{page: page, offset: offset}, nil
+}
+
+func (r *Resolver) Book(args struct{ ID gql.ID }) (*bookResolver, error) {
+	book, execption := r.bookService.GetBook(string(args.ID))
+	if execption != nil {
+		if execption.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, apiError{execption}
+	}
+	return newBookResolver(book), nil
+}
+
+func (r *Resolver) Categories(args struct {
+	First  int32
+	After  *string
+	Filter *categoryFilter
+}) (*categoryConnectionResolver, error) {
+	offset, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	search := repository.CategorySearch{Limit: pageSize(args.First), Offset: offset}
+	if args.Filter != nil && args.Filter.Name != nil {
+		search.Name = *args.Filter.Name
+	}
+
+	page, execption := r.catalogService.SearchCategories(search)
+	if execption != nil {
+		return nil, apiError{execption}
+	}
+	return &categoryConnectionResolver{page: page, offset: offset}, nil
+}
+
+func (r *Resolver) Category(args struct{ ID gql.ID }) (*categoryResolver, error) {
+	category, execption := r.categoryService.GetCategory(string(args.ID))
+	if execption != nil {
+		if execption.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, apiError{execption}
+	}
+	return newCategoryResolver(category), nil
+}
+
+type bookConnectionResolver struct {
+	page   *dto.BookPageResponse
+	offset int
+}
+
+func (r *bookConnectionResolver) Edges() []*bookEdgeResolver {
+	edges := []*bookEdgeResolver{}
+	for i, book := range r.page.Books {
+		edges = append(edges, &bookEdgeResolver{cursor: encodeCursor(r.offset + i), node: newBookResolver(book)})
+	}
+	return edges
+}
+
+func (r *bookConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{offset: r.offset, count: len(r.page.Books), total: r.page.Total}
+}
+
+func (r *bookConnectionResolver) TotalCount() int32 {
+	return int32(r.page.Total)
+}
+
+type bookEdgeResolver struct {
+	cursor string
+	node   *bookResolver
+}
+
+func (r *bookEdgeResolver) Cursor() string {
+	return r.cursor
+}
+
+func (r *bookEdgeResolver) Node() *bookResolver {
+	return r.node
+}
+
+type categoryConnectionResolver struct {
+	page   *dto.CategoryPageResponse
+	offset int
+}
+
+func (r *categoryConnectionResolver) Edges() []*categoryEdgeResolver {
+	edges := []*categoryEdgeResolver{}
+	for i, category := range r.page.Categories {
+		edges = append(edges, &categoryEdgeResolver{cursor: encodeCursor(r.offset + i), node: newCategoryResolver(category)})
+	}
+	return edges
+}
+
+func (r *categoryConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{offset: r.offset, count: len(r.page.Categories), total: r.page.Total}
+}
+
+func (r *categoryConnectionResolver) TotalCount() int32 {
+	return int32(r.page.Total)
+}
+
+type categoryEdgeResolver struct {
+	cursor string
+	node   *categoryResolver
+}
+
+func (r *categoryEdgeResolver) Cursor() string {
+	return r.cursor
+}
+
+func (r *categoryEdgeResolver) Node() *categoryResolver {
+	return r.node
+}
+
+type pageInfoResolver struct {
+	offset int
+	count  int
+	total  int64
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return int64(r.offset+r.count) < r.total
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	if r.count == 0 {
+		return nil
+	}
+	cursor := encodeCursor(r.offset + r.count - 1)
+	return &cursor
+}
+
+// Cursors are the opaque form of an offset into the result, so a page starts
+// right after the edge its after cursor names.
+func encodeCursor(offset int) string {
+	return base64.URLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor *string) (int, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+
+	invalid := inputError{fields: map[string]string{"after": "after is not a valid cursor"}}
+	decoded, err := base64.URLEncoding.DecodeString(*cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), cursorPrefix) {
+		return 0, invalid
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), cursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, invalid
+	}
+	return offset + 1, nil
+}
+
+// pageSize returns the number of items asked for, kept between 0 and
+// maxPageSize. The schema defaults it to 20.
+func pageSize(first int32) int {
+	switch {
+	case first < 0:
+		return 0
+	case first > maxPageSize:
+		return maxPageSize
+	}
+	return int(first)
+}
+
+func parseIDs(field string, ids []gql.ID) ([]uint, error) {
+	var parsed []uint
+	for _, id := range ids {
+		n, err := strconv.ParseUint(string(id), 10, 64)
+		if err != nil {
+			return nil, inputError{fields: map[string]string{field: "invalid ID: " + string(id)}}
+		}
+		parsed = append(parsed, uint(n))
+	}
+	return parsed, nil
+}
+
+func toInt(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+	value := int(*n)
+	return &value
+}
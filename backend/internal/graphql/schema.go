@@ -0,0 +1,66 @@
+// Package graphql serves books, categories and the relation between them
+// through GraphQL. Queries read pages through the CatalogService and load
+// relations in batches per request; mutations go through the same services
+// as the REST API and are only part of the schema served to staff.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSource string
+
+//go:embed mutation.graphql
+var mutationSource string
+
+// maxDepth keeps a query from nesting books and categories without end.
+const maxDepth = 8
+
+type Schema struct {
+	schema         *gql.Schema
+	staffSchema    *gql.Schema
+	catalogService service.CatalogService
+}
+
+// Resolver is the root of the schema, resolving both queries and mutations.
+type Resolver struct {
+	bookService     service.BookService
+	categoryService service.CategoryService
+	catalogService  service.CatalogService
+	uploadService   service.UploadService
+}
+
+// NewSchema parses the schema against the resolvers and panics when they do
+// not match, so a mismatch stops the server at start up.
+func NewSchema(bookService service.BookService, categoryService service.CategoryService, catalogService service.CatalogService, uploadService service.UploadService) *Schema {
+	resolver := &Resolver{
+		bookService:     bookService,
+		categoryService: categoryService,
+		catalogService:  catalogService,
+		uploadService:   uploadService,
+	}
+	return &Schema{
+		schema:         gql.MustParseSchema(schemaSource, resolver, gql.MaxDepth(maxDepth)),
+		staffSchema:    gql.MustParseSchema(schemaSource+mutationSource, resolver, gql.MaxDepth(maxDepth)),
+		catalogService: catalogService,
+	}
+}
+
+// Exec runs a query with its own loaders, so nothing is cached between
+// requests. The schema has no mutations.
+func (s *Schema) Exec(ctx context.Context, query, operationName string, variables map[string]interface{}) *gql.Response {
+	ctx = context.WithValue(ctx, loadersKey{}, newLoaders(s.catalogService))
+	return s.schema.Exec(ctx, query, operationName, variables)
+}
+
+// ExecAsStaff runs a query or mutation against the schema with mutations.
+// The caller checks that the user is staff.
+func (s *Schema) ExecAsStaff(ctx context.Context, query, operationName string, variables map[string]interface{}) *gql.Response {
+	ctx = context.WithValue(ctx, loadersKey{}, newLoaders(s.catalogService))
+	return s.staffSchema.Exec(ctx, query, operationName, variables)
+}
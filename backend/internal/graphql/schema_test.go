@@ -0,0 +1,31 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+// TestOnlyStaffSchemaHasMutations keeps the mutations out of the schema that
+// is served without a token.
+func TestOnlyStaffSchemaHasMutations(t *testing.T) {
+	s := NewSchema(nil, nil, nil, nil)
+
+	tests := []struct {
+		name   string
+		schema *gql.Schema
+		want   string
+	}{
+		{"public", s.schema, `{"__schema":{"mutationType":null}}`},
+		{"staff", s.staffSchema, `{"__schema":{"mutationType":{"name":"Mutation"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.schema.Exec(context.Background(), `{ __schema { mutationType { name } } }`, "", nil)
+			if got := string(result.Data); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
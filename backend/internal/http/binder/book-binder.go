@@ -2,25 +2,85 @@ package binder
 
 import "mime/multipart"
 
+type GetBooks struct {
+	Sort         string `query:"sort" validate:"omitempty,oneof=rating"`
+	Availability string `query:"availability" validate:"omitempty,oneof=available coming_soon"`
+}
+
 type GetBook struct {
 	ID string `param:"id" validate:"required"`
 }
 
 type CreateBook struct {
-	Title       string                `form:"title" validate:"required"`
-	Price       int                   `form:"price" validate:"required"`
-	Description string                `form:"description" validate:"required"`
-	Image       *multipart.FileHeader `form:"image" validate:"required"`
+	Title        string                `json:"title" form:"title" validate:"required"`
+	Price        int                   `json:"price" form:"price" validate:"required"`
+	Stock        *int                  `json:"stock" form:"stock" validate:"omitempty,min=0"`
+	Availability string                `json:"availability" form:"availability" validate:"omitempty,oneof=available preorder"`
+	ReleaseDate  string                `json:"release_date" form:"release_date" validate:"required_if=Availability preorder,omitempty,datetime=2006-01-02"`
+	Description  string                `json:"description" form:"description" validate:"required"`
+	WeightGrams  int                   `json:"weight_grams" form:"weight_grams" validate:"min=0"`
+	LengthMm     int                   `json:"length_mm" form:"length_mm" validate:"min=0"`
+	WidthMm      int                   `json:"width_mm" form:"width_mm" validate:"min=0"`
+	HeightMm     int                   `json:"height_mm" form:"height_mm" validate:"min=0"`
+	Image        *multipart.FileHeader `json:"-" form:"image"`
+	UploadID     string                `json:"upload_id" form:"upload_id" validate:"omitempty,uuid"`
+	Categories   string                `json:"categories" form:"categories" validate:"required"`
 }
 
 type UpdateBook struct {
-	ID          string                `param:"id" validate:"required"`
-	Title       string                `form:"title" validate:"required"`
-	Price       int                   `form:"price" validate:"required"`
-	Description string                `form:"description" validate:"required"`
-	Image       *multipart.FileHeader `form:"image"`
+	ID           string                `param:"id" validate:"required"`
+	Title        string                `form:"title" validate:"required"`
+	Price        int                   `form:"price" validate:"required"`
+	Stock        *int                  `form:"stock" validate:"omitempty,min=0"`
+	Availability string                `form:"availability" validate:"omitempty,oneof=available preorder"`
+	ReleaseDate  string                `form:"release_date" validate:"required_if=Availability preorder,omitempty,datetime=2006-01-02"`
+	Description  string                `form:"description" validate:"required"`
+	WeightGrams  int                   `form:"weight_grams" validate:"min=0"`
+	LengthMm     int                   `form:"length_mm" validate:"min=0"`
+	WidthMm      int                   `form:"width_mm" validate:"min=0"`
+	HeightMm     int                   `form:"height_mm" validate:"min=0"`
+	Image        *multipart.FileHeader `form:"image"`
+	UploadID     string                `form:"upload_id" validate:"omitempty,uuid"`
+	Categories   string                `form:"categories" validate:"required"`
 }
 
 type DeleteBook struct {
 	ID string `param:"id" validate:"required"`
 }
+
+type GetBookRecommendations struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type GetSimilarBooks struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type GetBookImages struct {
+	BookID string `param:"id" validate:"required"`
+}
+
+type CreateBookImage struct {
+	BookID    string                `param:"id" validate:"required"`
+	Alt       string                `form:"alt" validate:"max=255"`
+	IsPrimary bool                  `form:"is_primary"`
+	Image     *multipart.FileHeader `form:"image"`
+	UploadID  string                `form:"upload_id" validate:"omitempty,uuid"`
+}
+
+type UpdateBookImage struct {
+	BookID    string  `param:"id" validate:"required"`
+	ID        string  `param:"imageId" validate:"required"`
+	Alt       *string `json:"alt" validate:"omitempty,max=255"`
+	IsPrimary *bool   `json:"is_primary"`
+}
+
+type ReorderBookImages struct {
+	BookID   string `param:"id" validate:"required"`
+	ImageIDs []uint `json:"image_ids" validate:"required,min=1"`
+}
+
+type DeleteBookImage struct {
+	BookID string `param:"id" validate:"required"`
+	ID     string `param:"imageId" validate:"required"`
+}
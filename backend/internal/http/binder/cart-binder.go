@@ -0,0 +1,15 @@
+package binder
+
+type CartItem struct {
+	BookID   uint `json:"book_id" validate:"required"`
+	Quantity int  `json:"quantity" validate:"required,min=1"`
+}
+
+type QuoteCart struct {
+	Items            []CartItem       `json:"items" validate:"required,min=1,dive"`
+	CouponCodes      []string         `json:"coupon_codes"`
+	GiftCardCodes    []string         `json:"gift_card_codes"`
+	RedeemPoints     int              `json:"redeem_points" validate:"min=0"`
+	ShippingAddress  *ShippingAddress `json:"shipping_address"`
+	ShippingMethodID uint             `json:"shipping_method_id"`
+}
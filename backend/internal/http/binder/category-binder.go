@@ -5,12 +5,16 @@ type GetCategory struct {
 }
 
 type CreateCategory struct {
-	Name string `json:"name" validate:"required"`
+	Name              string `json:"name" validate:"required"`
+	TaxRateID         *uint  `json:"tax_rate_id"`
+	LoyaltyMultiplier *int   `json:"loyalty_multiplier" validate:"omitempty,min=0,max=1000"`
 }
 
 type UpdateCategory struct {
-	ID   string `param:"id" validate:"required"`
-	Name string `json:"name" validate:"required"`
+	ID                string `param:"id" validate:"required"`
+	Name              string `json:"name" validate:"required"`
+	TaxRateID         *uint  `json:"tax_rate_id"`
+	LoyaltyMultiplier *int   `json:"loyalty_multiplier" validate:"omitempty,min=0,max=1000"`
 }
 
 type DeleteCategory struct {
@@ -0,0 +1,46 @@
+package binder
+
+import "time"
+
+type GetCoupon struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CreateCoupon struct {
+	Code         string     `json:"code" validate:"required"`
+	Description  string     `json:"description"`
+	Type         string     `json:"type" validate:"required,oneof=percentage fixed_amount buy_x_get_y"`
+	Value        int        `json:"value" validate:"min=0"`
+	MinSubtotal  int        `json:"min_subtotal" validate:"min=0"`
+	BuyQuantity  int        `json:"buy_quantity" validate:"min=0"`
+	GetQuantity  int        `json:"get_quantity" validate:"min=0"`
+	UsageLimit   int        `json:"usage_limit" validate:"min=0"`
+	PerUserLimit int        `json:"per_user_limit" validate:"min=0"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	IsActive     *bool      `json:"is_active"`
+	CategoryIDs  []uint     `json:"category_ids"`
+	BookIDs      []uint     `json:"book_ids"`
+}
+
+type UpdateCoupon struct {
+	ID           string     `param:"id" validate:"required"`
+	Code         string     `json:"code" validate:"required"`
+	Description  string     `json:"description"`
+	Type         string     `json:"type" validate:"required,oneof=percentage fixed_amount buy_x_get_y"`
+	Value        int        `json:"value" validate:"min=0"`
+	MinSubtotal  int        `json:"min_subtotal" validate:"min=0"`
+	BuyQuantity  int        `json:"buy_quantity" validate:"min=0"`
+	GetQuantity  int        `json:"get_quantity" validate:"min=0"`
+	UsageLimit   int        `json:"usage_limit" validate:"min=0"`
+	PerUserLimit int        `json:"per_user_limit" validate:"min=0"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	IsActive     *bool      `json:"is_active"`
+	CategoryIDs  []uint     `json:"category_ids"`
+	BookIDs      []uint     `json:"book_ids"`
+}
+
+type DeleteCoupon struct {
+	ID string `param:"id" validate:"required"`
+}
@@ -0,0 +1,34 @@
+package binder
+
+import "mime/multipart"
+
+type GetBookFiles struct {
+	BookID string `param:"id" validate:"required"`
+}
+
+type CreateBookFile struct {
+	BookID string                `param:"id" validate:"required"`
+	File   *multipart.FileHeader `form:"file"`
+}
+
+type ExtractBook struct {
+	File *multipart.FileHeader `form:"file"`
+}
+
+type DeleteBookFile struct {
+	BookID string `param:"id" validate:"required"`
+	ID     string `param:"fileId" validate:"required"`
+}
+
+type CreateEbookDownload struct {
+	OrderID string `param:"id" validate:"required"`
+	ItemID  string `param:"itemId" validate:"required"`
+}
+
+type DownloadEbook struct {
+	FileID    string `param:"id" validate:"required"`
+	OrderID   string `query:"order" validate:"required"`
+	ItemID    string `query:"item" validate:"required"`
+	Expires   string `query:"expires" validate:"required"`
+	Signature string `query:"signature" validate:"required"`
+}
@@ -0,0 +1,30 @@
+package binder
+
+type GetGiftCard struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CheckGiftCardBalance struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type IssueGiftCard struct {
+	InitialBalance int    `json:"initial_balance" validate:"required,min=1"`
+	ExpiresAt      string `json:"expires_at" validate:"omitempty,datetime=2006-01-02"`
+	Note           string `json:"note" validate:"max=255"`
+}
+
+type PurchaseGiftCard struct {
+	Amount int `json:"amount" validate:"required,min=10000,max=10000000"`
+}
+
+type AdjustGiftCard struct {
+	ID     string `param:"id" validate:"required"`
+	Amount int    `json:"amount" validate:"required"`
+	Note   string `json:"note" validate:"required,max=255"`
+}
+
+type VoidGiftCard struct {
+	ID   string `param:"id" validate:"required"`
+	Note string `json:"note" validate:"max=255"`
+}
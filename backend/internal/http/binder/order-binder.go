@@ -0,0 +1,23 @@
+package binder
+
+type GetOrder struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CreateOrder struct {
+	Items            []CartItem      `json:"items" validate:"required,min=1,dive"`
+	CouponCodes      []string        `json:"coupon_codes"`
+	GiftCardCodes    []string        `json:"gift_card_codes"`
+	RedeemPoints     int             `json:"redeem_points" validate:"min=0"`
+	ShippingAddress  ShippingAddress `json:"shipping_address" validate:"required"`
+	ShippingMethodID uint            `json:"shipping_method_id" validate:"required"`
+}
+
+type UpdateOrderStatus struct {
+	ID     string `param:"id" validate:"required"`
+	Status string `json:"status" validate:"required,oneof=pending paid shipped delivered cancelled"`
+}
+
+type GetOrderInvoice struct {
+	ID string `param:"id" validate:"required"`
+}
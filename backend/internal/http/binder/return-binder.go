@@ -0,0 +1,23 @@
+package binder
+
+type ReturnItem struct {
+	OrderItemID uint `json:"order_item_id" validate:"required"`
+	Quantity    int  `json:"quantity" validate:"required,min=1"`
+}
+
+type GetReturn struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CreateReturn struct {
+	OrderID string       `param:"id" validate:"required"`
+	Reason  string       `json:"reason" validate:"required"`
+	Items   []ReturnItem `json:"items" validate:"required,min=1,dive"`
+}
+
+type UpdateReturnStatus struct {
+	ID           string `param:"id" validate:"required"`
+	Status       string `json:"status" validate:"required,oneof=approved rejected received"`
+	Note         string `json:"note"`
+	RefundAmount *int   `json:"refund_amount" validate:"omitempty,min=0"`
+}
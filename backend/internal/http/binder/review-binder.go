@@ -0,0 +1,21 @@
+package binder
+
+type GetBookReviews struct {
+	BookID string `param:"id" validate:"required"`
+}
+
+type CreateReview struct {
+	BookID string `param:"id" validate:"required"`
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Body   string `json:"body"`
+}
+
+type UpdateReview struct {
+	ID     string `param:"id" validate:"required"`
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Body   string `json:"body"`
+}
+
+type DeleteReview struct {
+	ID string `param:"id" validate:"required"`
+}
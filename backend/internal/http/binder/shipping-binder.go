@@ -0,0 +1,55 @@
+package binder
+
+type ShippingZoneRegion struct {
+	Province         string `json:"province"`
+	PostalCodePrefix string `json:"postal_code_prefix"`
+}
+
+type ShippingRate struct {
+	MinWeightGrams int `json:"min_weight_grams" validate:"min=0"`
+	MaxWeightGrams int `json:"max_weight_grams" validate:"min=0"`
+	Price          int `json:"price" validate:"min=0"`
+}
+
+type GetShippingZone struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CreateShippingZone struct {
+	Name    string               `json:"name" validate:"required"`
+	Regions []ShippingZoneRegion `json:"regions" validate:"required,min=1,dive"`
+}
+
+type UpdateShippingZone struct {
+	ID      string               `param:"id" validate:"required"`
+	Name    string               `json:"name" validate:"required"`
+	Regions []ShippingZoneRegion `json:"regions" validate:"required,min=1,dive"`
+}
+
+type DeleteShippingZone struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CreateShippingMethod struct {
+	ShippingZoneID uint           `json:"shipping_zone_id" validate:"required"`
+	Name           string         `json:"name" validate:"required"`
+	Rates          []ShippingRate `json:"rates" validate:"required,min=1,dive"`
+}
+
+type UpdateShippingMethod struct {
+	ID    string         `param:"id" validate:"required"`
+	Name  string         `json:"name" validate:"required"`
+	Rates []ShippingRate `json:"rates" validate:"required,min=1,dive"`
+}
+
+type DeleteShippingMethod struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type ShippingAddress struct {
+	Recipient  string `json:"recipient" validate:"required"`
+	Phone      string `json:"phone" validate:"required"`
+	Address    string `json:"address" validate:"required"`
+	Province   string `json:"province" validate:"required"`
+	PostalCode string `json:"postal_code" validate:"required"`
+}
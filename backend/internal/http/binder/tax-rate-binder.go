@@ -0,0 +1,20 @@
+package binder
+
+type GetTaxRate struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type CreateTaxRate struct {
+	Name string `json:"name" validate:"required"`
+	Rate int    `json:"rate" validate:"min=0,max=10000"`
+}
+
+type UpdateTaxRate struct {
+	ID   string `param:"id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+	Rate int    `json:"rate" validate:"min=0,max=10000"`
+}
+
+type DeleteTaxRate struct {
+	ID string `param:"id" validate:"required"`
+}
@@ -0,0 +1,12 @@
+package binder
+
+type CreateUpload struct {
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
+
+type PutUpload struct {
+	ID        string `param:"id" validate:"required"`
+	Expires   string `query:"expires" validate:"required"`
+	Signature string `query:"signature" validate:"required"`
+}
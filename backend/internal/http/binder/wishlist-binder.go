@@ -0,0 +1,36 @@
+package binder
+
+type GetWishlist struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type GetSharedWishlist struct {
+	Token string `param:"token" validate:"required"`
+}
+
+type CreateWishlist struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type UpdateWishlist struct {
+	ID   string `param:"id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+}
+
+type DeleteWishlist struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type ResetWishlistShareToken struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type AddWishlistItem struct {
+	ID     string `param:"id" validate:"required"`
+	BookID uint   `json:"book_id" validate:"required"`
+}
+
+type RemoveWishlistItem struct {
+	ID     string `param:"id" validate:"required"`
+	BookID string `param:"bookId" validate:"required"`
+}
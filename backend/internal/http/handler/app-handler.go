@@ -1,14 +1,62 @@
 package handler
 
-import "github.com/aws-cakap-intern/book-store/pkg/validator"
+import (
+	"mime/multipart"
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/aws-cakap-intern/book-store/pkg/token"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+	"github.com/aws-cakap-intern/book-store/pkg/validator"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
 
 type AppHandler struct {
-	CategoryHandler *CategotyHandler
-	BookHandler *BookHandler
+	CategoryHandler       *CategotyHandler
+	BookHandler           *BookHandler
+	CouponHandler         *CouponHandler
+	CartHandler           *CartHandler
+	OrderHandler          *OrderHandler
+	TaxRateHandler        *TaxRateHandler
+	ShippingHandler       *ShippingHandler
+	ReviewHandler         *ReviewHandler
+	WishlistHandler       *WishlistHandler
+	RecommendationHandler *RecommendationHandler
+	InvoiceHandler        *InvoiceHandler
+	ReturnHandler         *ReturnHandler
+	GiftCardHandler       *GiftCardHandler
+	MeHandler             *MeHandler
+	BookImageHandler      *BookImageHandler
+	UploadHandler         *UploadHandler
+	EbookHandler          *EbookHandler
+	DocsHandler           *DocsHandler
+	GraphQLHandler        *GraphQLHandler
 }
 
-func NewAppHandler(categoryHandler *CategotyHandler, bookHandler *BookHandler) AppHandler {
-	return AppHandler{CategoryHandler: categoryHandler, BookHandler: bookHandler}
+func NewAppHandler(categoryHandler *CategotyHandler, bookHandler *BookHandler, couponHandler *CouponHandler, cartHandler *CartHandler, orderHandler *OrderHandler, taxRateHandler *TaxRateHandler, shippingHandler *ShippingHandler, reviewHandler *ReviewHandler, wishlistHandler *WishlistHandler, recommendationHandler *RecommendationHandler, invoiceHandler *InvoiceHandler, returnHandler *ReturnHandler, giftCardHandler *GiftCardHandler, meHandler *MeHandler, bookImageHandler *BookImageHandler, uploadHandler *UploadHandler, ebookHandler *EbookHandler, docsHandler *DocsHandler, graphQLHandler *GraphQLHandler) AppHandler {
+	return AppHandler{
+		CategoryHandler:       categoryHandler,
+		BookHandler:           bookHandler,
+		CouponHandler:         couponHandler,
+		CartHandler:           cartHandler,
+		OrderHandler:          orderHandler,
+		TaxRateHandler:        taxRateHandler,
+		ShippingHandler:       shippingHandler,
+		ReviewHandler:         reviewHandler,
+		WishlistHandler:       wishlistHandler,
+		RecommendationHandler: recommendationHandler,
+		InvoiceHandler:        invoiceHandler,
+		ReturnHandler:         returnHandler,
+		GiftCardHandler:       giftCardHandler,
+		MeHandler:             meHandler,
+		BookImageHandler:      bookImageHandler,
+		UploadHandler:         uploadHandler,
+		EbookHandler:          ebookHandler,
+		DocsHandler:           docsHandler,
+		GraphQLHandler:        graphQLHandler,
+	}
 }
 
 func checkValidation(input interface{}) (errorMessage string, data interface{}) {
@@ -20,4 +68,58 @@ func checkValidation(input interface{}) (errorMessage string, data interface{})
 		return "validasi input gagal", validationErrors
 	}
 	return "", nil
-}
\ No newline at end of file
+}
+
+// checkImage validates an uploaded image and reports any problem on the image
+// field, in the same shape checkValidation uses for the rest of the input.
+func checkImage(imageValidator *upload.ImageValidator, file multipart.File) (image *upload.Image, errorMessage string, data interface{}) {
+	image, err := imageValidator.Validate(file)
+	if err != nil {
+		return nil, "validasi input gagal", map[string]string{"image": err.Error()}
+	}
+	return image, "", nil
+}
+
+// readImage returns the image sent with a form, either as the "image" file
+// or as the upload_id of a direct upload, or nil when there is neither. When
+// the image cannot be used the error response has already been written and
+// ok is false. The caller closes the image file.
+func readImage(ctx echo.Context, imageValidator *upload.ImageValidator, uploadService service.UploadService, uploadID string) (image *upload.Image, ok bool, err error) {
+	if uploadID != "" {
+		image, execption := uploadService.OpenUpload(uploadID)
+		if execption != nil {
+			return nil, false, ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+		}
+		return image, true, nil
+	}
+
+	file, _, err := ctx.Request().FormFile("image")
+	if err == http.ErrMissingFile {
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Failed to get file"))
+	}
+
+	image, errorMessage, data := checkImage(imageValidator, file)
+	if errorMessage != "" {
+		file.Close()
+		return nil, false, ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+	return image, true, nil
+}
+
+// currentUserID returns the ID of the user in the JWT set by server.JWTProtection.
+func currentUserID(ctx echo.Context) uint {
+	user, ok := ctx.Get("user").(*jwt.Token)
+	if !ok {
+		return 0
+	}
+
+	claims, ok := user.Claims.(*token.JwtCustomClaims)
+	if !ok {
+		return 0
+	}
+
+	return claims.ID
+}
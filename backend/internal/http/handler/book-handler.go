@@ -2,7 +2,6 @@ package handler
 
 import (
 	"fmt"
-	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,19 +9,32 @@ import (
 	"github.com/aws-cakap-intern/book-store/internal/http/binder"
 	"github.com/aws-cakap-intern/book-store/internal/service"
 	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
 	"github.com/labstack/echo/v4"
 )
 
 type BookHandler struct {
-	bookService service.BookService
+	bookService    service.BookService
+	uploadService  service.UploadService
+	imageValidator *upload.ImageValidator
 }
 
-func NewBookHandler(bookService service.BookService) *BookHandler {
-	return &BookHandler{bookService: bookService}
+func NewBookHandler(bookService service.BookService, uploadService service.UploadService, imageValidator *upload.ImageValidator) *BookHandler {
+	return &BookHandler{bookService: bookService, uploadService: uploadService, imageValidator: imageValidator}
 }
 
 func (c *BookHandler) GetBooks(ctx echo.Context) error {
-	responsData, execption := c.bookService.GetBooks()
+	var input binder.GetBooks
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.bookService.GetBooks(input)
 
 	if execption != nil {
 		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
@@ -62,30 +74,31 @@ func (c *BookHandler) CreateBook(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
 	}
 
-	categories := ctx.FormValue("categories")
-
-	if categories == "" {
-		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Categories field is required"))
-	}
-
-	parsedCategories, err := c.parseCategories(categories)
+	parsedCategories, err := c.parseCategories(input.Categories)
 
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
 	}
 
-	file, fileHeader, err := ctx.Request().FormFile("image")
-	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Failed to get file"))
+	image, ok, err := readImage(ctx, c.imageValidator, c.uploadService, input.UploadID)
+	if !ok {
+		return err
 	}
-	defer file.Close()
+	if image == nil {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, "validasi input gagal", map[string]string{"image": "image is required"}))
+	}
+	defer image.File.Close()
 
-	responsData, execption := c.bookService.CreateBook(input, parsedCategories, file, fileHeader)
+	responsData, execption := c.bookService.CreateBook(input, parsedCategories, image)
 
 	if execption != nil {
 		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
 	}
 
+	if input.UploadID != "" {
+		c.uploadService.FinishUpload(input.UploadID)
+	}
+
 	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Create Book", responsData))
 }
 
@@ -100,39 +113,30 @@ func (c *BookHandler) UpdateBook(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
 	}
 
-	categories := ctx.FormValue("categories")
-
-	if categories == "" {
-		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Categories field is required"))
-	}
-
-	parsedCategories, err := c.parseCategories(categories)
+	parsedCategories, err := c.parseCategories(input.Categories)
 
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
 	}
 
-	var file multipart.File
-	var fileHeader *multipart.FileHeader
-
-	file, fileHeader, err = ctx.Request().FormFile("image")
-
-	if err != nil {
-		if err != http.ErrMissingFile {
-			return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Failed to get file"))
-		}
-		file = nil
-		fileHeader = nil
-	} else {
-		defer file.Close()
+	image, ok, err := readImage(ctx, c.imageValidator, c.uploadService, input.UploadID)
+	if !ok {
+		return err
+	}
+	if image != nil {
+		defer image.File.Close()
 	}
 
-	responsData, execption := c.bookService.UpdateBook(input, parsedCategories, file, fileHeader)
+	responsData, execption := c.bookService.UpdateBook(input, parsedCategories, image)
 
 	if execption != nil {
 		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
 	}
 
+	if input.UploadID != "" {
+		c.uploadService.FinishUpload(input.UploadID)
+	}
+
 	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Update Book", responsData))
 }
 
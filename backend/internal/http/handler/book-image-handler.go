@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+	"github.com/labstack/echo/v4"
+)
+
+type BookImageHandler struct {
+	bookImageService service.BookImageService
+	uploadService    service.UploadService
+	imageValidator   *upload.ImageValidator
+}
+
+func NewBookImageHandler(bookImageService service.BookImageService, uploadService service.UploadService, imageValidator *upload.ImageValidator) *BookImageHandler {
+	return &BookImageHandler{bookImageService: bookImageService, uploadService: uploadService, imageValidator: imageValidator}
+}
+
+func (c *BookImageHandler) GetBookImages(ctx echo.Context) error {
+	var input binder.GetBookImages
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.bookImageService.GetBookImages(input.BookID)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Book Images", responsData))
+}
+
+func (c *BookImageHandler) CreateBookImage(ctx echo.Context) error {
+	var input binder.CreateBookImage
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	image, ok, err := readImage(ctx, c.imageValidator, c.uploadService, input.UploadID)
+	if !ok {
+		return err
+	}
+	if image == nil {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, "validasi input gagal", map[string]string{"image": "image is required"}))
+	}
+	defer image.File.Close()
+
+	responsData, execption := c.bookImageService.CreateBookImage(input, image)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	if input.UploadID != "" {
+		c.uploadService.FinishUpload(input.UploadID)
+	}
+
+	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Create Book Image", responsData))
+}
+
+func (c *BookImageHandler) UpdateBookImage(ctx echo.Context) error {
+	var input binder.UpdateBookImage
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.bookImageService.UpdateBookImage(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Update Book Image", responsData))
+}
+
+func (c *BookImageHandler) ReorderBookImages(ctx echo.Context) error {
+	var input binder.ReorderBookImages
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.bookImageService.ReorderBookImages(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Reorder Book Images", responsData))
+}
+
+func (c *BookImageHandler) DeleteBookImage(ctx echo.Context) error {
+	var input binder.DeleteBookImage
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	execption := c.bookImageService.DeleteBookImage(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Delete Book Image", nil))
+}
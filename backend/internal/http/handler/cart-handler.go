@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type CartHandler struct {
+	cartService service.CartService
+}
+
+func NewCartHandler(cartService service.CartService) *CartHandler {
+	return &CartHandler{cartService: cartService}
+}
+
+func (c *CartHandler) QuoteCart(ctx echo.Context) error {
+	var input binder.QuoteCart
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.cartService.QuoteCart(currentUserID(ctx), input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Quote Cart", responsData))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type CouponHandler struct {
+	couponService service.CouponService
+}
+
+func NewCouponHandler(couponService service.CouponService) *CouponHandler {
+	return &CouponHandler{couponService: couponService}
+}
+
+func (c *CouponHandler) GetCoupons(ctx echo.Context) error {
+	responsData, execption := c.couponService.GetCoupons()
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Coupons", responsData))
+}
+
+func (c *CouponHandler) GetCoupon(ctx echo.Context) error {
+	var input binder.GetCoupon
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.couponService.GetCoupon(input.ID)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Coupon", responsData))
+}
+
+func (c *CouponHandler) CreateCoupon(ctx echo.Context) error {
+	var input binder.CreateCoupon
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.couponService.CreateCoupon(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Create Coupon", responsData))
+}
+
+func (c *CouponHandler) UpdateCoupon(ctx echo.Context) error {
+	var input binder.UpdateCoupon
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.couponService.UpdateCoupon(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Update Coupon", responsData))
+}
+
+func (c *CouponHandler) DeleteCoupon(ctx echo.Context) error {
+	var input binder.DeleteCoupon
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	execption := c.couponService.DeleteCoupon(input.ID)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Delete Coupon", nil))
+}
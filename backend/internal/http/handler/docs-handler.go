@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/pkg/openapi"
+	"github.com/labstack/echo/v4"
+)
+
+type DocsHandler struct {
+	document *openapi.Document
+}
+
+func NewDocsHandler(document *openapi.Document) *DocsHandler {
+	return &DocsHandler{document: document}
+}
+
+// GetSpec answers with the bare document, outside the response envelope,
+// so OpenAPI tools can read it.
+func (c *DocsHandler) GetSpec(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, c.document)
+}
+
+func (c *DocsHandler) GetDocs(ctx echo.Context) error {
+	return ctx.HTMLBlob(http.StatusOK, openapi.DocsPage)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+	"github.com/labstack/echo/v4"
+)
+
+type EbookHandler struct {
+	ebookService   service.EbookService
+	ebookValidator *upload.EbookValidator
+}
+
+func NewEbookHandler(ebookService service.EbookService, ebookValidator *upload.EbookValidator) *EbookHandler {
+	return &EbookHandler{ebookService: ebookService, ebookValidator: ebookValidator}
+}
+
+func (c *EbookHandler) GetBookFiles(ctx echo.Context) error {
+	var input binder.GetBookFiles
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.ebookService.GetBookFiles(input.BookID)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Book Files", responsData))
+}
+
+func (c *EbookHandler) CreateBookFile(ctx echo.Context) error {
+	var input binder.CreateBookFile
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	ebook, ok, err := c.readEbook(ctx)
+	if !ok {
+		return err
+	}
+	defer ebook.File.Close()
+
+	responsData, execption := c.ebookService.CreateBookFile(input, ebook)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Create Book File", responsData))
+}
+
+func (c *EbookHandler) DeleteBookFile(ctx echo.Context) error {
+	var input binder.DeleteBookFile
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	execption := c.ebookService.DeleteBookFile(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Delete Book File", nil))
+}
+
+func (c *EbookHandler) CreateDownload(ctx echo.Context) error {
+	var input binder.CreateEbookDownload
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.ebookService.CreateDownload(currentUserID(ctx), input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Create Download", responsData))
+}
+
+// Download streams the file of a signed download link, so large ebooks are
+// never held in memory.
+func (c *EbookHandler) Download(ctx echo.Context) error {
+	var input binder.DownloadEbook
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.ebookService.Download(input, ctx.RealIP(), ctx.Request().UserAgent())
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+	defer responsData.Content.Close()
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", responsData.Name))
+	ctx.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(responsData.Size, 10))
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "private, no-store")
+	return ctx.Stream(http.StatusOK, responsData.ContentType, responsData.Content)
+}
+
+func (c *EbookHandler) ExtractBook(ctx echo.Context) error {
+	var input binder.ExtractBook
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	ebook, ok, err := c.readEbook(ctx)
+	if !ok {
+		return err
+	}
+	defer ebook.File.Close()
+
+	responsData, execption := c.ebookService.ExtractBook(ebook)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Extract Book", responsData))
+}
+
+// readEbook returns the validated ebook sent as the "file" form field. When
+// there is none or it is not a valid ebook, the error response has already
+// been written and ok is false. The caller closes the ebook file.
+func (c *EbookHandler) readEbook(ctx echo.Context) (ebook *upload.Ebook, ok bool, err error) {
+	file, _, err := ctx.Request().FormFile("file")
+	if err == http.ErrMissingFile {
+		return nil, false, ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, "validasi input gagal", map[string]string{"file": "file is required"}))
+	}
+	if err != nil {
+		return nil, false, ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, "Failed to get file"))
+	}
+
+	ebook, err = c.ebookValidator.Validate(file)
+	if err != nil {
+		file.Close()
+		return nil, false, ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, "validasi input gagal", map[string]string{"file": err.Error()}))
+	}
+	return ebook, true, nil
+}
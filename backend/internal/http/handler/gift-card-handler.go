@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type GiftCardHandler struct {
+	giftCardService service.GiftCardService
+}
+
+func NewGiftCardHandler(giftCardService service.GiftCardService) *GiftCardHandler {
+	return &GiftCardHandler{giftCardService: giftCardService}
+}
+
+func (c *GiftCardHandler) GetGiftCards(ctx echo.Context) error {
+	responsData, execption := c.giftCardService.GetGiftCards()
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Gift Cards", responsData))
+}
+
+func (c *GiftCardHandler) GetGiftCard(ctx echo.Context) error {
+	var input binder.GetGiftCard
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.giftCardService.GetGiftCard(input.ID)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Gift Card", responsData))
+}
+
+func (c *GiftCardHandler) CheckBalance(ctx echo.Context) error {
+	var input binder.CheckGiftCardBalance
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.giftCardService.CheckBalance(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Gift Card Balance", responsData))
+}
+
+func (c *GiftCardHandler) IssueGiftCard(ctx echo.Context) error {
+	var input binder.IssueGiftCard
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.giftCardService.IssueGiftCard(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Issue Gift Card", responsData))
+}
+
+func (c *GiftCardHandler) PurchaseGiftCard(ctx echo.Context) error {
+	var input binder.PurchaseGiftCard
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.giftCardService.PurchaseGiftCard(currentUserID(ctx), input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Purchase Gift Card", responsData))
+}
+
+func (c *GiftCardHandler) AdjustGiftCard(ctx echo.Context) error {
+	var input binder.AdjustGiftCard
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.giftCardService.AdjustGiftCard(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Adjust Gift Card", responsData))
+}
+
+func (c *GiftCardHandler) VoidGiftCard(ctx echo.Context) error {
+	var input binder.VoidGiftCard
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.giftCardService.VoidGiftCard(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Void Gift Card", responsData))
+}
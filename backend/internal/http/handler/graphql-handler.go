@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/graphql"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type GraphQLHandler struct {
+	schema *graphql.Schema
+}
+
+func NewGraphQLHandler(schema *graphql.Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+// Query answers with the GraphQL result, outside the response envelope, so
+// GraphQL clients can read it. Errors of the query are in the result, next
+// to the data that could still be resolved.
+func (c *GraphQLHandler) Query(ctx echo.Context) error {
+	var input binder.GraphQLQuery
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	result := c.schema.Exec(ctx.Request().Context(), input.Query, input.OperationName, input.Variables)
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// StaffQuery answers like Query from the schema with mutations.
+func (c *GraphQLHandler) StaffQuery(ctx echo.Context) error {
+	var input binder.GraphQLQuery
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	result := c.schema.ExecAsStaff(ctx.Request().Context(), input.Query, input.OperationName, input.Variables)
+
+	return ctx.JSON(http.StatusOK, result)
+}
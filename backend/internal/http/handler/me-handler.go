@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type MeHandler struct {
+	loyaltyService service.LoyaltyService
+}
+
+func NewMeHandler(loyaltyService service.LoyaltyService) *MeHandler {
+	return &MeHandler{loyaltyService: loyaltyService}
+}
+
+func (c *MeHandler) GetMe(ctx echo.Context) error {
+	responsData, execption := c.loyaltyService.GetMe(currentUserID(ctx))
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Get Me", responsData))
+}
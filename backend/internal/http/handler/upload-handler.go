@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+type UploadHandler struct {
+	uploadService service.UploadService
+}
+
+func NewUploadHandler(uploadService service.UploadService) *UploadHandler {
+	return &UploadHandler{uploadService: uploadService}
+}
+
+func (c *UploadHandler) CreateUpload(ctx echo.Context) error {
+	var input binder.CreateUpload
+
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	responsData, execption := c.uploadService.CreateUpload(input)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusCreated, response.SuccessResponse(http.StatusCreated, "Success Create Upload", responsData))
+}
+
+// PutUpload takes the raw image as the request body, so only the path and
+// query are bound.
+func (c *UploadHandler) PutUpload(ctx echo.Context) error {
+	var input binder.PutUpload
+
+	paramsBinder := &echo.DefaultBinder{}
+	if err := paramsBinder.BindPathParams(ctx, &input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+	if err := paramsBinder.BindQueryParams(ctx, &input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse(http.StatusBadRequest, err.Error()))
+	}
+
+	if errorMessage, data := checkValidation(input); errorMessage != "" {
+		return ctx.JSON(http.StatusBadRequest, response.SuccessResponse(http.StatusBadRequest, errorMessage, data))
+	}
+
+	execption := c.uploadService.PutUpload(input, ctx.Request().Body)
+
+	if execption != nil {
+		return ctx.JSON(execption.Status, response.ErrorResponse(execption.Status, execption.Message))
+	}
+
+	return ctx.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Success Upload Image", nil))
+}
@@ -3,6 +3,8 @@ package router
 import (
 	"net/http"
 
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
 	"github.com/aws-cakap-intern/book-store/internal/http/handler"
 	"github.com/aws-cakap-intern/book-store/pkg/route"
 )
@@ -10,57 +12,612 @@ import (
 func AppPublicRoutes(appHandler handler.AppHandler) []*route.Route {
 	categoryHandler := appHandler.CategoryHandler
 	bookHandler := appHandler.BookHandler
+	bookImageHandler := appHandler.BookImageHandler
+	uploadHandler := appHandler.UploadHandler
+	ebookHandler := appHandler.EbookHandler
+	taxRateHandler := appHandler.TaxRateHandler
+	shippingHandler := appHandler.ShippingHandler
+	reviewHandler := appHandler.ReviewHandler
+	wishlistHandler := appHandler.WishlistHandler
+	recommendationHandler := appHandler.RecommendationHandler
+	giftCardHandler := appHandler.GiftCardHandler
 
 	return []*route.Route{
 		{
 			Method:  http.MethodGet,
 			Path:    "/categories",
 			Handler: categoryHandler.GetCategories,
+			Output:  []dto.CategoryResponse{},
 		},
 		{
 			Method:  http.MethodGet,
 			Path:    "/categories/:id",
 			Handler: categoryHandler.GetCategory,
+			Input:   binder.GetCategory{},
+			Output:  dto.CategoryResponse{},
 		},
 		{
 			Method:  http.MethodPost,
 			Path:    "/categories",
 			Handler: categoryHandler.CreateCategory,
+			Input:   binder.CreateCategory{},
+			Output:  dto.CategoryResponse{},
 		},
 		{
 			Method:  http.MethodPut,
 			Path:    "/categories/:id",
 			Handler: categoryHandler.UpdateCategory,
+			Input:   binder.UpdateCategory{},
+			Output:  dto.CategoryResponse{},
 		},
 		{
 			Method:  http.MethodDelete,
 			Path:    "/categories/:id",
 			Handler: categoryHandler.DeleteCategory,
+			Input:   binder.DeleteCategory{},
 		},
 		{
 			Method:  http.MethodGet,
 			Path:    "/books",
 			Handler: bookHandler.GetBooks,
+			Input:   binder.GetBooks{},
+			Output:  []dto.BookResponse{},
 		},
 		{
 			Method:  http.MethodGet,
 			Path:    "/books/:id",
 			Handler: bookHandler.GetBook,
+			Input:   binder.GetBook{},
+			Output:  dto.BookResponse{},
 		},
 		{
 			Method:  http.MethodPost,
 			Path:    "/books",
 			Handler: bookHandler.CreateBook,
+			Input:   binder.CreateBook{},
+			Output:  dto.BookResponse{},
+			Status:  http.StatusCreated,
 		},
 		{
 			Method:  http.MethodPut,
 			Path:    "/books/:id",
 			Handler: bookHandler.UpdateBook,
+			Input:   binder.UpdateBook{},
+			Output:  dto.BookResponse{},
 		},
 		{
 			Method:  http.MethodDelete,
 			Path:    "/books/:id",
 			Handler: bookHandler.DeleteBook,
+			Input:   binder.DeleteBook{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books/:id/images",
+			Handler: bookImageHandler.GetBookImages,
+			Input:   binder.GetBookImages{},
+			Output:  []dto.BookImageResponse{},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/uploads/:id",
+			Handler: uploadHandler.PutUpload,
+			Input:   binder.PutUpload{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books/:id/files",
+			Handler: ebookHandler.GetBookFiles,
+			Input:   binder.GetBookFiles{},
+			Output:  []dto.BookFileResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/downloads/:id",
+			Handler: ebookHandler.Download,
+			Input:   binder.DownloadEbook{},
+			Output:  route.File{ContentTypes: []string{"application/epub+zip", "application/pdf"}},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books/:id/reviews",
+			Handler: reviewHandler.GetBookReviews,
+			Input:   binder.GetBookReviews{},
+			Output:  []dto.ReviewResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books/:id/recommendations",
+			Handler: recommendationHandler.GetRecommendations,
+			Input:   binder.GetBookRecommendations{},
+			Output:  []dto.RecommendationResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books/:id/similar",
+			Handler: recommendationHandler.GetSimilarBooks,
+			Input:   binder.GetSimilarBooks{},
+			Output:  []dto.SimilarBookResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/wishlists/shared/:token",
+			Handler: wishlistHandler.GetSharedWishlist,
+			Input:   binder.GetSharedWishlist{},
+			Output:  dto.WishlistResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/gift-cards/balance",
+			Handler: giftCardHandler.CheckBalance,
+			Input:   binder.CheckGiftCardBalance{},
+			Output:  dto.GiftCardBalanceResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/tax-rates",
+			Handler: taxRateHandler.GetTaxRates,
+			Output:  []dto.TaxRateResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/tax-rates/:id",
+			Handler: taxRateHandler.GetTaxRate,
+			Input:   binder.GetTaxRate{},
+			Output:  dto.TaxRateResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/shipping-zones",
+			Handler: shippingHandler.GetShippingZones,
+			Output:  []dto.ShippingZoneResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/shipping-zones/:id",
+			Handler: shippingHandler.GetShippingZone,
+			Input:   binder.GetShippingZone{},
+			Output:  dto.ShippingZoneResponse{},
+		},
+	}
+}
+
+func AppPrivateRoutes(appHandler handler.AppHandler) []*route.Route {
+	cartHandler := appHandler.CartHandler
+	orderHandler := appHandler.OrderHandler
+	reviewHandler := appHandler.ReviewHandler
+	wishlistHandler := appHandler.WishlistHandler
+	invoiceHandler := appHandler.InvoiceHandler
+	returnHandler := appHandler.ReturnHandler
+	giftCardHandler := appHandler.GiftCardHandler
+	meHandler := appHandler.MeHandler
+	ebookHandler := appHandler.EbookHandler
+
+	return []*route.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/me",
+			Handler: meHandler.GetMe,
+			Output:  dto.MeResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/cart/quote",
+			Handler: cartHandler.QuoteCart,
+			Input:   binder.QuoteCart{},
+			Output:  dto.CartResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/orders",
+			Handler: orderHandler.GetOrders,
+			Output:  []dto.OrderResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/orders/:id",
+			Handler: orderHandler.GetOrder,
+			Input:   binder.GetOrder{},
+			Output:  dto.OrderResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/orders/:id/invoice.pdf",
+			Handler: invoiceHandler.GetInvoicePDF,
+			Input:   binder.GetOrderInvoice{},
+			Output:  route.File{ContentTypes: []string{"application/pdf"}},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/orders/:id/items/:itemId/downloads",
+			Handler: ebookHandler.CreateDownload,
+			Input:   binder.CreateEbookDownload{},
+			Output:  dto.EbookDownloadResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/orders/:id/returns",
+			Handler: returnHandler.CreateReturn,
+			Input:   binder.CreateReturn{},
+			Output:  dto.ReturnResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/returns",
+			Handler: returnHandler.GetReturns,
+			Output:  []dto.ReturnResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/returns/:id",
+			Handler: returnHandler.GetReturn,
+			Input:   binder.GetReturn{},
+			Output:  dto.ReturnResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/gift-cards/purchase",
+			Handler: giftCardHandler.PurchaseGiftCard,
+			Input:   binder.PurchaseGiftCard{},
+			Output:  dto.GiftCardResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/orders",
+			Handler: orderHandler.CreateOrder,
+			Input:   binder.CreateOrder{},
+			Output:  dto.OrderResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/books/:id/reviews",
+			Handler: reviewHandler.CreateReview,
+			Input:   binder.CreateReview{},
+			Output:  dto.ReviewResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/reviews/:id",
+			Handler: reviewHandler.UpdateReview,
+			Input:   binder.UpdateReview{},
+			Output:  dto.ReviewResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/reviews/:id",
+			Handler: reviewHandler.DeleteReview,
+			Input:   binder.DeleteReview{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/wishlists",
+			Handler: wishlistHandler.GetWishlists,
+			Output:  []dto.WishlistResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/wishlists/:id",
+			Handler: wishlistHandler.GetWishlist,
+			Input:   binder.GetWishlist{},
+			Output:  dto.WishlistResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/wishlists",
+			Handler: wishlistHandler.CreateWishlist,
+			Input:   binder.CreateWishlist{},
+			Output:  dto.WishlistResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/wishlists/:id",
+			Handler: wishlistHandler.UpdateWishlist,
+			Input:   binder.UpdateWishlist{},
+			Output:  dto.WishlistResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/wishlists/:id",
+			Handler: wishlistHandler.DeleteWishlist,
+			Input:   binder.DeleteWishlist{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/wishlists/:id/share-token",
+			Handler: wishlistHandler.ResetShareToken,
+			Input:   binder.ResetWishlistShareToken{},
+			Output:  dto.WishlistResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/wishlists/:id/items",
+			Handler: wishlistHandler.AddItem,
+			Input:   binder.AddWishlistItem{},
+			Output:  dto.WishlistResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/wishlists/:id/items/:bookId",
+			Handler: wishlistHandler.RemoveItem,
+			Input:   binder.RemoveWishlistItem{},
+			Output:  dto.WishlistResponse{},
+		},
+	}
+}
+
+// AppAdminRoutes are the routes staff run the store with. They need a token
+// with the admin or staff role.
+func AppAdminRoutes(appHandler handler.AppHandler) []*route.Route {
+	couponHandler := appHandler.CouponHandler
+	orderHandler := appHandler.OrderHandler
+	taxRateHandler := appHandler.TaxRateHandler
+	shippingHandler := appHandler.ShippingHandler
+	returnHandler := appHandler.ReturnHandler
+	giftCardHandler := appHandler.GiftCardHandler
+	ebookHandler := appHandler.EbookHandler
+	uploadHandler := appHandler.UploadHandler
+	bookImageHandler := appHandler.BookImageHandler
+
+	return []*route.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/coupons",
+			Handler: couponHandler.GetCoupons,
+			Output:  []dto.CouponResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/coupons/:id",
+			Handler: couponHandler.GetCoupon,
+			Input:   binder.GetCoupon{},
+			Output:  dto.CouponResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/coupons",
+			Handler: couponHandler.CreateCoupon,
+			Input:   binder.CreateCoupon{},
+			Output:  dto.CouponResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/coupons/:id",
+			Handler: couponHandler.UpdateCoupon,
+			Input:   binder.UpdateCoupon{},
+			Output:  dto.CouponResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/coupons/:id",
+			Handler: couponHandler.DeleteCoupon,
+			Input:   binder.DeleteCoupon{},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/orders/:id/status",
+			Handler: orderHandler.UpdateOrderStatus,
+			Input:   binder.UpdateOrderStatus{},
+			Output:  dto.OrderResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/tax-rates",
+			Handler: taxRateHandler.CreateTaxRate,
+			Input:   binder.CreateTaxRate{},
+			Output:  dto.TaxRateResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/tax-rates/:id",
+			Handler: taxRateHandler.UpdateTaxRate,
+			Input:   binder.UpdateTaxRate{},
+			Output:  dto.TaxRateResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/tax-rates/:id",
+			Handler: taxRateHandler.DeleteTaxRate,
+			Input:   binder.DeleteTaxRate{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/shipping-zones",
+			Handler: shippingHandler.CreateShippingZone,
+			Input:   binder.CreateShippingZone{},
+			Output:  dto.ShippingZoneResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/shipping-zones/:id",
+			Handler: shippingHandler.UpdateShippingZone,
+			Input:   binder.UpdateShippingZone{},
+			Output:  dto.ShippingZoneResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/shipping-zones/:id",
+			Handler: shippingHandler.DeleteShippingZone,
+			Input:   binder.DeleteShippingZone{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/shipping-methods",
+			Handler: shippingHandler.CreateShippingMethod,
+			Input:   binder.CreateShippingMethod{},
+			Output:  dto.ShippingMethodResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/shipping-methods/:id",
+			Handler: shippingHandler.UpdateShippingMethod,
+			Input:   binder.UpdateShippingMethod{},
+			Output:  dto.ShippingMethodResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/shipping-methods/:id",
+			Handler: shippingHandler.DeleteShippingMethod,
+			Input:   binder.DeleteShippingMethod{},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/returns/:id/status",
+			Handler: returnHandler.UpdateReturnStatus,
+			Input:   binder.UpdateReturnStatus{},
+			Output:  dto.ReturnResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/gift-cards",
+			Handler: giftCardHandler.GetGiftCards,
+			Output:  []dto.GiftCardResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/gift-cards/:id",
+			Handler: giftCardHandler.GetGiftCard,
+			Input:   binder.GetGiftCard{},
+			Output:  dto.GiftCardResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/gift-cards",
+			Handler: giftCardHandler.IssueGiftCard,
+			Input:   binder.IssueGiftCard{},
+			Output:  dto.GiftCardResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/gift-cards/:id/adjust",
+			Handler: giftCardHandler.AdjustGiftCard,
+			Input:   binder.AdjustGiftCard{},
+			Output:  dto.GiftCardResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/gift-cards/:id/void",
+			Handler: giftCardHandler.VoidGiftCard,
+			Input:   binder.VoidGiftCard{},
+			Output:  dto.GiftCardResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/books/:id/files",
+			Handler: ebookHandler.CreateBookFile,
+			Input:   binder.CreateBookFile{},
+			Output:  []dto.BookFileResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/books/:id/files/:fileId",
+			Handler: ebookHandler.DeleteBookFile,
+			Input:   binder.DeleteBookFile{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/uploads",
+			Handler: uploadHandler.CreateUpload,
+			Input:   binder.CreateUpload{},
+			Output:  dto.UploadResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/books/:id/images",
+			Handler: bookImageHandler.CreateBookImage,
+			Input:   binder.CreateBookImage{},
+			Output:  []dto.BookImageResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/books/:id/images/order",
+			Handler: bookImageHandler.ReorderBookImages,
+			Input:   binder.ReorderBookImages{},
+			Output:  []dto.BookImageResponse{},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/books/:id/images/:imageId",
+			Handler: bookImageHandler.UpdateBookImage,
+			Input:   binder.UpdateBookImage{},
+			Output:  []dto.BookImageResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/books/:id/images/:imageId",
+			Handler: bookImageHandler.DeleteBookImage,
+			Input:   binder.DeleteBookImage{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/books/extract",
+			Handler: ebookHandler.ExtractBook,
+			Input:   binder.ExtractBook{},
+			Output:  dto.BookExtractResponse{},
+		},
+	}
+}
+
+// AppDocsRoutes serves the API documentation, which covers every version.
+func AppDocsRoutes(appHandler handler.AppHandler) []*route.Route {
+	docsHandler := appHandler.DocsHandler
+
+	return []*route.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/openapi.json",
+			Handler: docsHandler.GetSpec,
+			Output:  route.File{ContentTypes: []string{"application/json"}},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/docs",
+			Handler: docsHandler.GetDocs,
+			Output:  route.File{ContentTypes: []string{"text/html"}},
+		},
+	}
+}
+
+// AppGraphQLRoutes serves the GraphQL schema of books and categories, which
+// is not versioned with the REST API.
+func AppGraphQLRoutes(appHandler handler.AppHandler) []*route.Route {
+	graphQLHandler := appHandler.GraphQLHandler
+
+	return []*route.Route{
+		{
+			Method:  http.MethodPost,
+			Path:    "/graphql",
+			Handler: graphQLHandler.Query,
+			Input:   binder.GraphQLQuery{},
+			Output:  route.File{ContentTypes: []string{"application/json"}},
+		},
+	}
+}
+
+// AppGraphQLAdminRoutes serve the GraphQL schema with its mutations to staff.
+func AppGraphQLAdminRoutes(appHandler handler.AppHandler) []*route.Route {
+	graphQLHandler := appHandler.GraphQLHandler
+
+	return []*route.Route{
+		{
+			Method:  http.MethodPost,
+			Path:    "/admin/graphql",
+			Handler: graphQLHandler.StaffQuery,
+			Input:   binder.GraphQLQuery{},
+			Output:  route.File{ContentTypes: []string{"application/json"}},
 		},
 	}
 }
@@ -0,0 +1,124 @@
+package router
+
+import (
+	"net/http"
+
+	v2 "github.com/aws-cakap-intern/book-store/internal/dto/v2"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/http/handler"
+	"github.com/aws-cakap-intern/book-store/pkg/route"
+)
+
+// AppV2PublicRoutes are the public routes of API v2. They share the handlers
+// of v1; v2.Map gives their responses the v2 shapes.
+func AppV2PublicRoutes(appHandler handler.AppHandler) []*route.Route {
+	categoryHandler := appHandler.CategoryHandler
+	bookHandler := appHandler.BookHandler
+	reviewHandler := appHandler.ReviewHandler
+
+	return []*route.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/categories",
+			Handler: categoryHandler.GetCategories,
+			Output:  []v2.CategoryResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/categories/:id",
+			Handler: categoryHandler.GetCategory,
+			Input:   binder.GetCategory{},
+			Output:  v2.CategoryResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/categories",
+			Handler: categoryHandler.CreateCategory,
+			Input:   binder.CreateCategory{},
+			Output:  v2.CategoryResponse{},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/categories/:id",
+			Handler: categoryHandler.UpdateCategory,
+			Input:   binder.UpdateCategory{},
+			Output:  v2.CategoryResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/categories/:id",
+			Handler: categoryHandler.DeleteCategory,
+			Input:   binder.DeleteCategory{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books",
+			Handler: bookHandler.GetBooks,
+			Input:   binder.GetBooks{},
+			Output:  []v2.BookResponse{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books/:id",
+			Handler: bookHandler.GetBook,
+			Input:   binder.GetBook{},
+			Output:  v2.BookResponse{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/books",
+			Handler: bookHandler.CreateBook,
+			Input:   binder.CreateBook{},
+			Output:  v2.BookResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/books/:id",
+			Handler: bookHandler.UpdateBook,
+			Input:   binder.UpdateBook{},
+			Output:  v2.BookResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/books/:id",
+			Handler: bookHandler.DeleteBook,
+			Input:   binder.DeleteBook{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/books/:id/reviews",
+			Handler: reviewHandler.GetBookReviews,
+			Input:   binder.GetBookReviews{},
+			Output:  []v2.ReviewResponse{},
+		},
+	}
+}
+
+func AppV2PrivateRoutes(appHandler handler.AppHandler) []*route.Route {
+	reviewHandler := appHandler.ReviewHandler
+
+	return []*route.Route{
+		{
+			Method:  http.MethodPost,
+			Path:    "/books/:id/reviews",
+			Handler: reviewHandler.CreateReview,
+			Input:   binder.CreateReview{},
+			Output:  v2.ReviewResponse{},
+			Status:  http.StatusCreated,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/reviews/:id",
+			Handler: reviewHandler.UpdateReview,
+			Input:   binder.UpdateReview{},
+			Output:  v2.ReviewResponse{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/reviews/:id",
+			Handler: reviewHandler.DeleteReview,
+			Input:   binder.DeleteReview{},
+		},
+	}
+}
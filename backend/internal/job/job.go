@@ -0,0 +1,37 @@
+package job
+
+import (
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	"github.com/aws-cakap-intern/book-store/pkg/scheduler"
+)
+
+func AppJobs(recommendationService service.RecommendationService, preorderService service.PreorderService, loyaltyService service.LoyaltyService, storageCheckService service.StorageCheckService, uploadService service.UploadService, cfg *config.Config) []*scheduler.Job {
+	return []*scheduler.Job{
+		{
+			Name:     "refresh-recommendations",
+			Interval: cfg.Recommendation.RefreshInterval,
+			Run:      recommendationService.RefreshRecommendations,
+		},
+		{
+			Name:     "release-preorders",
+			Interval: cfg.Preorder.ReleaseInterval,
+			Run:      preorderService.ReleasePreorders,
+		},
+		{
+			Name:     "expire-loyalty-points",
+			Interval: cfg.Loyalty.ExpireInterval,
+			Run:      loyaltyService.ExpirePoints,
+		},
+		{
+			Name:     "check-storage",
+			Interval: cfg.Storage.CheckInterval,
+			Run:      storageCheckService.RunStorageCheck,
+		},
+		{
+			Name:     "expire-uploads",
+			Interval: cfg.Upload.ExpireInterval,
+			Run:      uploadService.ExpireUploads,
+		},
+	}
+}
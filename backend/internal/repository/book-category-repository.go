@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+// BookCategoryRepository reads the links between books and categories, so
+// the two sides can be loaded in batches.
+type BookCategoryRepository interface {
+	FindByBookIDs(bookIDs []uint) ([]entity.BookCategory, error)
+	FindByCategoryIDs(categoryIDs []uint) ([]entity.BookCategory, error)
+}
+
+type bookCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewBookCategoryRepository(db *gorm.DB) BookCategoryRepository {
+	return &bookCategoryRepository{db}
+}
+
+// FindByBookIDs implements BookCategoryRepository.
+func (r *bookCategoryRepository) FindByBookIDs(bookIDs []uint) ([]entity.BookCategory, error) {
+	var links []entity.BookCategory
+	if err := r.db.Where("book_id IN ?", bookIDs).Order("category_id").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// FindByCategoryIDs implements BookCategoryRepository. The newest books of a
+// category come first.
+func (r *bookCategoryRepository) FindByCategoryIDs(categoryIDs []uint) ([]entity.BookCategory, error) {
+	var links []entity.BookCategory
+	if err := r.db.Where("category_id IN ?", categoryIDs).Order("book_id DESC").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
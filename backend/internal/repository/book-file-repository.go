@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBookFileNotFound      = errors.New("book file not found")
+	ErrBookFileAlreadyExists = errors.New("book already has a file in this format")
+)
+
+type BookFileRepository interface {
+	Create(file *entity.BookFile) (*entity.BookFile, error)
+	Delete(file *entity.BookFile) error
+	GetById(bookID uint, id uint) (*entity.BookFile, error)
+	GetByBook(bookID uint) ([]entity.BookFile, error)
+}
+
+type bookFileRepository struct {
+	db *gorm.DB
+}
+
+func NewBookFileRepository(db *gorm.DB) BookFileRepository {
+	return &bookFileRepository{db}
+}
+
+// Create adds a file to a book, which may have one file in each format.
+func (r *bookFileRepository) Create(file *entity.BookFile) (*entity.BookFile, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBook(tx, file.BookID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.BookFile{}).Where("book_id = ? AND format = ?", file.BookID, file.Format).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrBookFileAlreadyExists
+		}
+
+		return tx.Create(file).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (r *bookFileRepository) Delete(file *entity.BookFile) error {
+	return r.db.Delete(&entity.BookFile{}, file.ID).Error
+}
+
+func (r *bookFileRepository) GetById(bookID uint, id uint) (*entity.BookFile, error) {
+	var file entity.BookFile
+	if err := r.db.Where("book_id = ?", bookID).First(&file, id).Error; err != nil {
+		return nil, ErrBookFileNotFound
+	}
+	return &file, nil
+}
+
+func (r *bookFileRepository) GetByBook(bookID uint) ([]entity.BookFile, error) {
+	var files []entity.BookFile
+	if err := r.db.Where("book_id = ?", bookID).Order("format").Find(&files).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
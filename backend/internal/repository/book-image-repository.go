@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrBookImageNotFound      = errors.New("book image not found")
+	ErrBookImageOrderMismatch = errors.New("the new order must list every image of the book exactly once")
+)
+
+type BookImageRepository interface {
+	Create(image *entity.BookImage) (*entity.BookImage, error)
+	Update(image *entity.BookImage) (*entity.BookImage, error)
+	Delete(image *entity.BookImage) error
+	Reorder(bookID uint, imageIDs []uint) error
+	GetById(bookID uint, id uint) (*entity.BookImage, error)
+	GetByBook(bookID uint) ([]entity.BookImage, error)
+	GetAll() ([]entity.BookImage, error)
+}
+
+type bookImageRepository struct {
+	db *gorm.DB
+}
+
+func NewBookImageRepository(db *gorm.DB) BookImageRepository {
+	return &bookImageRepository{db}
+}
+
+// Create adds an image to the end of a book's gallery. The first image of a
+// book is always its primary image.
+func (r *bookImageRepository) Create(image *entity.BookImage) (*entity.BookImage, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		images, err := lockBookImages(tx, image.BookID)
+		if err != nil {
+			return err
+		}
+
+		image.Position = len(images)
+		if len(images) == 0 {
+			image.IsPrimary = true
+		}
+
+		if image.IsPrimary {
+			if err := clearPrimaryImage(tx, image.BookID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(image).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// Update saves the file, alt text and primary flag of an image. Making an
+// image primary takes the flag from the book's other images.
+func (r *bookImageRepository) Update(image *entity.BookImage) (*entity.BookImage, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockBookImages(tx, image.BookID); err != nil {
+			return err
+		}
+
+		if image.IsPrimary {
+			if err := clearPrimaryImage(tx, image.BookID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(image).Select("Path", "Variants", "Alt", "IsPrimary").Updates(image).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetById(image.BookID, image.ID)
+}
+
+// Delete removes an image and closes the gap it leaves in the gallery. When
+// the primary image goes, the next image in the gallery takes its place.
+func (r *bookImageRepository) Delete(image *entity.BookImage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockBookImages(tx, image.BookID); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.BookImage{}, image.ID).Error; err != nil {
+			return err
+		}
+
+		var images []entity.BookImage
+		if err := orderBookImages(tx).Where("book_id = ?", image.BookID).Find(&images).Error; err != nil {
+			return err
+		}
+
+		for i := range images {
+			updates := map[string]interface{}{"position": i}
+			if i == 0 && image.IsPrimary {
+				updates["is_primary"] = true
+			}
+			if err := tx.Model(&images[i]).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Reorder puts a book's images in the order of imageIDs, which must hold
+// every image of the book.
+func (r *bookImageRepository) Reorder(bookID uint, imageIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		images, err := lockBookImages(tx, bookID)
+		if err != nil {
+			return err
+		}
+
+		if len(imageIDs) != len(images) {
+			return ErrBookImageOrderMismatch
+		}
+
+		positions := map[uint]int{}
+		for i, id := range imageIDs {
+			if _, seen := positions[id]; seen {
+				return ErrBookImageOrderMismatch
+			}
+			positions[id] = i
+		}
+
+		for i := range images {
+			position, ok := positions[images[i].ID]
+			if !ok {
+				return ErrBookImageOrderMismatch
+			}
+			if err := tx.Model(&images[i]).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *bookImageRepository) GetById(bookID uint, id uint) (*entity.BookImage, error) {
+	var image entity.BookImage
+	if err := r.db.Where("book_id = ?", bookID).First(&image, id).Error; err != nil {
+		return nil, ErrBookImageNotFound
+	}
+	return &image, nil
+}
+
+// GetByBook returns a book's gallery in order.
+func (r *bookImageRepository) GetByBook(bookID uint) ([]entity.BookImage, error) {
+	var images []entity.BookImage
+	if err := orderBookImages(r.db).Where("book_id = ?", bookID).Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// GetAll returns the images of every book.
+func (r *bookImageRepository) GetAll() ([]entity.BookImage, error) {
+	var images []entity.BookImage
+	if err := r.db.Order("book_id").Order("position").Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// lockBookImages locks the book and returns its images, so concurrent
+// changes to one gallery happen one after another.
+func lockBookImages(tx *gorm.DB, bookID uint) ([]entity.BookImage, error) {
+	if err := lockBook(tx, bookID); err != nil {
+		return nil, err
+	}
+
+	var images []entity.BookImage
+	if err := orderBookImages(tx).Where("book_id = ?", bookID).Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// lockBook locks a book row until the transaction ends.
+func lockBook(tx *gorm.DB, bookID uint) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&entity.Book{}, bookID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBookNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func clearPrimaryImage(tx *gorm.DB, bookID uint) error {
+	return tx.Model(&entity.BookImage{}).Where("book_id = ? AND is_primary = ?", bookID, true).Update("is_primary", false).Error
+}
+
+// orderBookImages sorts images in gallery order. It doubles as the
+// condition for preloading Book.Images.
+func orderBookImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position").Order("id")
+}
@@ -2,19 +2,52 @@ package repository
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/aws-cakap-intern/book-store/internal/entity"
 	"gorm.io/gorm"
 )
 
-var ErrBookNotFound = errors.New("book not found")
+var (
+	ErrBookNotFound = errors.New("book not found")
+	ErrBookOrdered  = errors.New("book has been ordered and cannot be deleted")
+)
+
+const (
+	BookSortRating = "rating"
+
+	BookFilterAvailable  = "available"
+	BookFilterComingSoon = "coming_soon"
+)
+
+// BookQuery holds the listing options for GetAll.
+type BookQuery struct {
+	Sort         string
+	Availability string
+}
+
+// BookSearch holds the filters and page of Search. Books match every filter
+// that is set; Title matches any part of the title.
+type BookSearch struct {
+	Title        string
+	CategoryIDs  []uint
+	Availability string
+	MinPrice     *int
+	MaxPrice     *int
+	Limit        int
+	Offset       int
+}
 
 type BookRepository interface {
 	Create(book *entity.Book, categoryIDs []uint) (*entity.Book, error)
 	Update(book *entity.Book, categoryIDs []uint) (*entity.Book, error)
 	Delete(id uint) error
-	GetAll() ([]entity.Book, error)
+	GetAll(query BookQuery) ([]entity.Book, error)
+	Search(search BookSearch) ([]entity.Book, int64, error)
 	GetById(id uint) (*entity.Book, error)
+	FindByIDs(ids []uint, books *[]*entity.Book) error
+	ReleaseDue(now time.Time) (int64, error)
 }
 
 type bookRepository struct {
@@ -43,8 +76,17 @@ func (b *bookRepository) Create(book *entity.Book, categoryIDs []uint) (*entity.
 	return book, nil
 }
 
-// Delete implements BookRepository.
+// Delete implements BookRepository. Books that have been ordered are kept,
+// since the order lines still point to them.
 func (b *bookRepository) Delete(id uint) error {
+	var ordered int64
+	if err := b.db.Model(&entity.OrderItem{}).Where("book_id = ?", id).Count(&ordered).Error; err != nil {
+		return err
+	}
+	if ordered > 0 {
+		return ErrBookOrdered
+	}
+
 	if err := b.db.Delete(&entity.Book{}, id).Error; err != nil {
 		return err
 	}
@@ -52,18 +94,75 @@ func (b *bookRepository) Delete(id uint) error {
 }
 
 // GetAll implements BookRepository.
-func (b *bookRepository) GetAll() ([]entity.Book, error) {
+func (b *bookRepository) GetAll(query BookQuery) ([]entity.Book, error) {
 	var books []entity.Book
-	if err := b.db.Preload("Categories").Find(&books).Error; err != nil {
+
+	db := b.db.Preload("Categories").Preload("Images", orderBookImages)
+
+	switch query.Availability {
+	case BookFilterAvailable:
+		db = db.Where("availability = ?", entity.BookAvailabilityAvailable)
+	case BookFilterComingSoon:
+		db = db.Where("availability = ?", entity.BookAvailabilityPreorder)
+	}
+
+	if query.Sort == BookSortRating {
+		db = db.Order("rating_average DESC").Order("rating_count DESC").Order("id")
+	} else if query.Availability == BookFilterComingSoon {
+		db = db.Order("release_date").Order("id")
+	}
+
+	if err := db.Find(&books).Error; err != nil {
 		return nil, err
 	}
 	return books, nil
 }
 
+// Search implements BookRepository. It returns a page of the matching books,
+// newest first, and how many books match in total.
+func (b *bookRepository) Search(search BookSearch) ([]entity.Book, int64, error) {
+	db := b.db.Model(&entity.Book{})
+
+	if search.Title != "" {
+		db = db.Where("title LIKE ?", "%"+escapeLike(search.Title)+"%")
+	}
+	if len(search.CategoryIDs) > 0 {
+		db = db.Where("id IN (?)", b.db.Model(&entity.BookCategory{}).Select("book_id").Where("category_id IN ?", search.CategoryIDs))
+	}
+	if search.Availability != "" {
+		db = db.Where("availability = ?", search.Availability)
+	}
+	if search.MinPrice != nil {
+		db = db.Where("price >= ?", *search.MinPrice)
+	}
+	if search.MaxPrice != nil {
+		db = db.Where("price <= ?", *search.MaxPrice)
+	}
+
+	// The filters are shared by the count and the page
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var books []entity.Book
+	if err := db.Preload("Images", orderBookImages).Order("id DESC").Limit(search.Limit).Offset(search.Offset).Find(&books).Error; err != nil {
+		return nil, 0, err
+	}
+	return books, total, nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 // GetById implements BookRepository.
 func (b *bookRepository) GetById(id uint) (*entity.Book, error) {
 	var book entity.Book
-	if err := b.db.Preload("Categories").First(&book, id).Error; err != nil {
+	if err := b.db.Preload("Categories").Preload("Images", orderBookImages).First(&book, id).Error; err != nil {
 		return nil, ErrBookNotFound
 	}
 	return &book, nil
@@ -76,11 +175,17 @@ func (b *bookRepository) Update(book *entity.Book, categoryIDs []uint) (*entity.
 		return nil, ErrBookNotFound
 	}
 
-	// Update book details
+	// Update book details. Stock is only written when it was given.
 	if err := b.db.Model(&existingBook).Updates(book).Error; err != nil {
 		return nil, err
 	}
 
+	// Updates skips zero values, so these are written on their own to allow
+	// clearing the release date
+	if err := b.db.Model(&existingBook).Select("availability", "release_date").Updates(book).Error; err != nil {
+		return nil, err
+	}
+
 	// Update category relationships
 	if len(categoryIDs) > 0 {
 		var categories []entity.Category
@@ -92,3 +197,22 @@ func (b *bookRepository) Update(book *entity.Book, categoryIDs []uint) (*entity.
 
 	return &existingBook, nil
 }
+
+func (b *bookRepository) FindByIDs(ids []uint, books *[]*entity.Book) error {
+	if err := b.db.Preload("Categories.TaxRate").Preload("Images", orderBookImages).Where("id IN (?)", ids).Find(books).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReleaseDue makes pre-order books available once their release date has
+// come and returns how many were released.
+func (b *bookRepository) ReleaseDue(now time.Time) (int64, error) {
+	result := b.db.Model(&entity.Book{}).
+		Where("availability = ? AND release_date <= ?", entity.BookAvailabilityPreorder, now.Format("2006-01-02")).
+		Update("availability", entity.BookAvailabilityAvailable)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
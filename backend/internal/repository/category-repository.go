@@ -16,6 +16,7 @@ type CategoryRepository interface {
 	GetAll() ([]entity.Category, error)
 	GetById(id uint) (*entity.Category, error)
 	FindByIDs(ids []uint, categories *[]*entity.Category) error
+	Search(search CategorySearch) ([]entity.Category, int64, error)
 }
 
 type categoryRepository struct {
@@ -39,7 +40,8 @@ func (r *categoryRepository) Update(category *entity.Category) (*entity.Category
 		return nil, ErrCategoryNotFound
 	}
 
-	if err := r.db.Model(&existingCategory).Updates(category).Error; err != nil {
+	// Select the columns explicitly so tax_rate_id can be cleared
+	if err := r.db.Model(&existingCategory).Select("Name", "TaxRateID", "LoyaltyMultiplier").Updates(category).Error; err != nil {
 		return nil, err
 	}
 	return category, nil
@@ -74,3 +76,34 @@ func (c *categoryRepository) FindByIDs(ids []uint, categories *[]*entity.Categor
 	}
 	return nil
 }
+
+// CategorySearch holds the filter and page of Search. Name matches any part
+// of the name.
+type CategorySearch struct {
+	Name   string
+	Limit  int
+	Offset int
+}
+
+// Search returns a page of the matching categories by name and how many
+// categories match in total.
+func (c *categoryRepository) Search(search CategorySearch) ([]entity.Category, int64, error) {
+	db := c.db.Model(&entity.Category{})
+
+	if search.Name != "" {
+		db = db.Where("name LIKE ?", "%"+escapeLike(search.Name)+"%")
+	}
+
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var categories []entity.Category
+	if err := db.Order("name").Order("id").Limit(search.Limit).Offset(search.Offset).Find(&categories).Error; err != nil {
+		return nil, 0, err
+	}
+	return categories, total, nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCouponNotFound          = errors.New("coupon not found")
+	ErrCouponUsageLimitReached = errors.New("coupon usage limit reached")
+)
+
+type CouponRepository interface {
+	Create(coupon *entity.Coupon, categoryIDs []uint, bookIDs []uint) (*entity.Coupon, error)
+	Update(coupon *entity.Coupon, categoryIDs []uint, bookIDs []uint) (*entity.Coupon, error)
+	Delete(id uint) error
+	GetAll() ([]entity.Coupon, error)
+	GetById(id uint) (*entity.Coupon, error)
+	GetByCode(code string) (*entity.Coupon, error)
+	CountRedemptions(couponID uint) (int64, error)
+	CountUserRedemptions(couponID uint, userID uint) (int64, error)
+}
+
+type couponRepository struct {
+	db *gorm.DB
+}
+
+func NewCouponRepository(db *gorm.DB) CouponRepository {
+	return &couponRepository{db}
+}
+
+func (r *couponRepository) Create(coupon *entity.Coupon, categoryIDs []uint, bookIDs []uint) (*entity.Coupon, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories", "Books").Create(coupon).Error; err != nil {
+			return err
+		}
+		return replaceCouponTargets(tx, coupon, categoryIDs, bookIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(coupon.ID)
+}
+
+func (r *couponRepository) Update(coupon *entity.Coupon, categoryIDs []uint, bookIDs []uint) (*entity.Coupon, error) {
+	var existingCoupon entity.Coupon
+	if err := r.db.First(&existingCoupon, coupon.ID).Error; err != nil {
+		return nil, ErrCouponNotFound
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Select("*") so that zero values such as is_active=false and cleared limits are written
+		if err := tx.Model(&existingCoupon).Select("*").Omit("ID", "CreatedAt", "Categories", "Books").Updates(coupon).Error; err != nil {
+			return err
+		}
+		return replaceCouponTargets(tx, &existingCoupon, categoryIDs, bookIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(coupon.ID)
+}
+
+func (r *couponRepository) Delete(id uint) error {
+	if err := r.db.Delete(&entity.Coupon{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *couponRepository) GetAll() ([]entity.Coupon, error) {
+	var coupons []entity.Coupon
+	if err := r.db.Preload("Categories").Preload("Books").Find(&coupons).Error; err != nil {
+		return nil, err
+	}
+	return coupons, nil
+}
+
+func (r *couponRepository) GetById(id uint) (*entity.Coupon, error) {
+	var coupon entity.Coupon
+	if err := r.db.Preload("Categories").Preload("Books").First(&coupon, id).Error; err != nil {
+		return nil, ErrCouponNotFound
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) GetByCode(code string) (*entity.Coupon, error) {
+	var coupon entity.Coupon
+	if err := r.db.Preload("Categories").Preload("Books").Where("code = ?", code).First(&coupon).Error; err != nil {
+		return nil, ErrCouponNotFound
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) CountRedemptions(couponID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.CouponRedemption{}).Where("coupon_id = ?", couponID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *couponRepository) CountUserRedemptions(couponID uint, userID uint) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.CouponRedemption{}).Where("coupon_id = ? AND user_id = ?", couponID, userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func replaceCouponTargets(tx *gorm.DB, coupon *entity.Coupon, categoryIDs []uint, bookIDs []uint) error {
+	categories := []entity.Category{}
+	if len(categoryIDs) > 0 {
+		if err := tx.Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(coupon).Association("Categories").Replace(categories); err != nil {
+		return err
+	}
+
+	books := []entity.Book{}
+	if len(bookIDs) > 0 {
+		if err := tx.Where("id IN ?", bookIDs).Find(&books).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(coupon).Association("Books").Replace(books)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrDownloadLimitReached = errors.New("download limit reached for this purchase")
+
+type EbookDownloadRepository interface {
+	Record(download *entity.EbookDownload, limit int) (*entity.EbookDownload, error)
+	CountByOrderItem(orderItemID uint) (int, error)
+}
+
+type ebookDownloadRepository struct {
+	db *gorm.DB
+}
+
+func NewEbookDownloadRepository(db *gorm.DB) EbookDownloadRepository {
+	return &ebookDownloadRepository{db}
+}
+
+// Record logs a download unless the order item has used up its limit. The
+// order item is locked, so concurrent downloads cannot go over the limit.
+func (r *ebookDownloadRepository) Record(download *entity.EbookDownload, limit int) (*entity.EbookDownload, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&entity.OrderItem{}, download.OrderItemID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entity.EbookDownload{}).Where("order_item_id = ?", download.OrderItemID).Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count) >= limit {
+			return ErrDownloadLimitReached
+		}
+
+		return tx.Create(download).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return download, nil
+}
+
+func (r *ebookDownloadRepository) CountByOrderItem(orderItemID uint) (int, error) {
+	var count int64
+	if err := r.db.Model(&entity.EbookDownload{}).Where("order_item_id = ?", orderItemID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrGiftCardNotFound            = errors.New("gift card not found")
+	ErrGiftCardNotActive           = errors.New("gift card is not active")
+	ErrGiftCardInsufficientBalance = errors.New("gift card balance is not enough")
+)
+
+type GiftCardRepository interface {
+	Create(giftCard *entity.GiftCard, note string) (*entity.GiftCard, error)
+	GetAll() ([]entity.GiftCard, error)
+	GetById(id uint) (*entity.GiftCard, error)
+	GetByCode(code string) (*entity.GiftCard, error)
+	Adjust(id uint, amount int, note string) (*entity.GiftCard, error)
+	Void(id uint, note string) (*entity.GiftCard, error)
+}
+
+type giftCardRepository struct {
+	db *gorm.DB
+}
+
+func NewGiftCardRepository(db *gorm.DB) GiftCardRepository {
+	return &giftCardRepository{db}
+}
+
+// Create stores the card and opens its ledger with the initial balance.
+func (g *giftCardRepository) Create(giftCard *entity.GiftCard, note string) (*entity.GiftCard, error) {
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(giftCard).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&entity.GiftCardTransaction{
+			GiftCardID:   giftCard.ID,
+			Type:         entity.GiftCardTransactionIssue,
+			Amount:       giftCard.InitialBalance,
+			BalanceAfter: giftCard.Balance,
+			Note:         note,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GetById(giftCard.ID)
+}
+
+// GetAll implements GiftCardRepository.
+func (g *giftCardRepository) GetAll() ([]entity.GiftCard, error) {
+	var giftCards []entity.GiftCard
+	if err := g.db.Order("id DESC").Find(&giftCards).Error; err != nil {
+		return nil, err
+	}
+	return giftCards, nil
+}
+
+// GetById implements GiftCardRepository.
+func (g *giftCardRepository) GetById(id uint) (*entity.GiftCard, error) {
+	var giftCard entity.GiftCard
+	if err := g.preload().First(&giftCard, id).Error; err != nil {
+		return nil, ErrGiftCardNotFound
+	}
+	return &giftCard, nil
+}
+
+// GetByCode implements GiftCardRepository.
+func (g *giftCardRepository) GetByCode(code string) (*entity.GiftCard, error) {
+	var giftCard entity.GiftCard
+	if err := g.preload().Where("code = ?", code).First(&giftCard).Error; err != nil {
+		return nil, ErrGiftCardNotFound
+	}
+	return &giftCard, nil
+}
+
+// Adjust adds a positive or negative amount to an active card.
+func (g *giftCardRepository) Adjust(id uint, amount int, note string) (*entity.GiftCard, error) {
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		giftCard, err := lockGiftCard(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if giftCard.Status != entity.GiftCardStatusActive {
+			return ErrGiftCardNotActive
+		}
+
+		return changeGiftCardBalance(tx, giftCard, entity.GiftCardTransactionAdjust, amount, nil, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GetById(id)
+}
+
+// Void cancels a card and writes off what was left on it.
+func (g *giftCardRepository) Void(id uint, note string) (*entity.GiftCard, error) {
+	err := g.db.Transaction(func(tx *gorm.DB) error {
+		giftCard, err := lockGiftCard(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if giftCard.Status != entity.GiftCardStatusActive {
+			return ErrGiftCardNotActive
+		}
+
+		if err := tx.Model(giftCard).Update("status", entity.GiftCardStatusVoid).Error; err != nil {
+			return err
+		}
+
+		return changeGiftCardBalance(tx, giftCard, entity.GiftCardTransactionVoid, -giftCard.Balance, nil, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GetById(id)
+}
+
+func (g *giftCardRepository) preload() *gorm.DB {
+	return g.db.Preload("Transactions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
+
+func lockGiftCard(tx *gorm.DB, id uint) (*entity.GiftCard, error) {
+	var giftCard entity.GiftCard
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&giftCard, id).Error; err != nil {
+		return nil, ErrGiftCardNotFound
+	}
+	return &giftCard, nil
+}
+
+// changeGiftCardBalance applies amount to a locked card and writes the ledger
+// entry. The balance can never go below zero.
+func changeGiftCardBalance(tx *gorm.DB, giftCard *entity.GiftCard, transactionType string, amount int, orderID *uint, note string) error {
+	balance := giftCard.Balance + amount
+	if balance < 0 {
+		return ErrGiftCardInsufficientBalance
+	}
+
+	if err := tx.Model(giftCard).Update("balance", balance).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.GiftCardTransaction{
+		GiftCardID:   giftCard.ID,
+		Type:         transactionType,
+		Amount:       amount,
+		BalanceAfter: balance,
+		OrderID:      orderID,
+		Note:         note,
+	}).Error
+}
+
+// redeemGiftCards takes the planned amounts off each card for an order. The
+// cards are locked and re-checked, so a balance spent elsewhere since the
+// cart was priced fails the checkout.
+func redeemGiftCards(tx *gorm.DB, order *entity.Order, redemptions []entity.GiftCardTransaction, now time.Time) error {
+	for _, redemption := range redemptions {
+		giftCard, err := lockGiftCard(tx, redemption.GiftCardID)
+		if err != nil {
+			return err
+		}
+
+		if giftCard.Status != entity.GiftCardStatusActive || (giftCard.ExpiresAt != nil && !giftCard.ExpiresAt.After(now)) {
+			return ErrGiftCardNotActive
+		}
+
+		if err := changeGiftCardBalance(tx, giftCard, entity.GiftCardTransactionRedeem, -redemption.Amount, &order.ID, redemption.Note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refundGiftCards puts up to amount of what an order took off gift cards back
+// on them, in the order the cards were used.
+func refundGiftCards(tx *gorm.DB, orderID uint, amount int, note string) error {
+	var transactions []entity.GiftCardTransaction
+	if err := tx.Where("order_id = ?", orderID).Order("id").Find(&transactions).Error; err != nil {
+		return err
+	}
+
+	for _, credit := range giftCardCredits(transactions, amount) {
+		giftCard, err := lockGiftCard(tx, credit.GiftCardID)
+		if err != nil {
+			return err
+		}
+
+		if err := changeGiftCardBalance(tx, giftCard, entity.GiftCardTransactionRefund, credit.Amount, &orderID, note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// giftCardCredit is an amount to put back on a card.
+type giftCardCredit struct {
+	GiftCardID uint
+	Amount     int
+}
+
+// giftCardCredits divides up to amount between the cards an order's
+// transactions used, giving each card at most what it is still owed after
+// earlier refunds, the first card first.
+func giftCardCredits(transactions []entity.GiftCardTransaction, amount int) []giftCardCredit {
+	owed := map[uint]int{}
+	var giftCardIDs []uint
+	for _, transaction := range transactions {
+		if _, seen := owed[transaction.GiftCardID]; !seen {
+			giftCardIDs = append(giftCardIDs, transaction.GiftCardID)
+		}
+		owed[transaction.GiftCardID] -= transaction.Amount
+	}
+
+	var credits []giftCardCredit
+	for _, giftCardID := range giftCardIDs {
+		credit := owed[giftCardID]
+		if credit > amount {
+			credit = amount
+		}
+		if credit <= 0 {
+			continue
+		}
+
+		credits = append(credits, giftCardCredit{GiftCardID: giftCardID, Amount: credit})
+		amount -= credit
+	}
+	return credits
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+func TestGiftCardCredits(t *testing.T) {
+	// The order took 30000 off card 1 and 20000 off card 2
+	redeemed := []entity.GiftCardTransaction{
+		{GiftCardID: 1, Type: entity.GiftCardTransactionRedeem, Amount: -30000},
+		{GiftCardID: 2, Type: entity.GiftCardTransactionRedeem, Amount: -20000},
+	}
+	partlyRefunded := append(redeemed[:2:2], entity.GiftCardTransaction{GiftCardID: 1, Type: entity.GiftCardTransactionRefund, Amount: 25000})
+
+	tests := []struct {
+		name         string
+		transactions []entity.GiftCardTransaction
+		amount       int
+		want         []giftCardCredit
+	}{
+		{"everything", redeemed, 50000, []giftCardCredit{{GiftCardID: 1, Amount: 30000}, {GiftCardID: 2, Amount: 20000}}},
+		{"first card first", redeemed, 40000, []giftCardCredit{{GiftCardID: 1, Amount: 30000}, {GiftCardID: 2, Amount: 10000}}},
+		{"less than the first card", redeemed, 10000, []giftCardCredit{{GiftCardID: 1, Amount: 10000}}},
+		{"no more than was taken", redeemed, 90000, []giftCardCredit{{GiftCardID: 1, Amount: 30000}, {GiftCardID: 2, Amount: 20000}}},
+		{"after an earlier refund", partlyRefunded, 40000, []giftCardCredit{{GiftCardID: 1, Amount: 5000}, {GiftCardID: 2, Amount: 20000}}},
+		{"nothing to refund", redeemed, 0, nil},
+		{"no gift cards", nil, 10000, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := giftCardCredits(tt.transactions, tt.amount); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrImageFileNotFound = errors.New("image file not found")
+
+type ImageFileRepository interface {
+	Acquire(hash string) (*entity.ImageFile, error)
+	Add(file *entity.ImageFile) error
+	Release(path string) (*entity.ImageFile, error)
+	Forget(path string) error
+}
+
+type imageFileRepository struct {
+	db *gorm.DB
+}
+
+func NewImageFileRepository(db *gorm.DB) ImageFileRepository {
+	return &imageFileRepository{db}
+}
+
+// Acquire takes a reference to the stored image with the given hash, or
+// returns ErrImageFileNotFound when it has not been stored yet.
+func (r *imageFileRepository) Acquire(hash string) (*entity.ImageFile, error) {
+	result := r.db.Model(&entity.ImageFile{}).Where("hash = ?", hash).Update("ref_count", gorm.Expr("ref_count + 1"))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrImageFileNotFound
+	}
+
+	var file entity.ImageFile
+	if err := r.db.First(&file, "hash = ?", hash).Error; err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// Add records a newly stored image with one reference. When the same image
+// was stored at the same time by someone else, the reference is added to
+// theirs.
+func (r *imageFileRepository) Add(file *entity.ImageFile) error {
+	file.RefCount = 1
+	return r.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"ref_count": gorm.Expr("ref_count + 1")}),
+	}).Create(file).Error
+}
+
+// Release drops a reference to the image stored at path. When it was the last
+// one the record is deleted and returned, so the caller can delete the files.
+func (r *imageFileRepository) Release(path string) (*entity.ImageFile, error) {
+	var released *entity.ImageFile
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var file entity.ImageFile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&file, "path = ?", path).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrImageFileNotFound
+			}
+			return err
+		}
+
+		if file.RefCount > 1 {
+			return tx.Model(&file).Update("ref_count", gorm.Expr("ref_count - 1")).Error
+		}
+
+		if err := tx.Delete(&file).Error; err != nil {
+			return err
+		}
+		released = &file
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// Forget deletes the record of the image stored at path regardless of its
+// references, for files that are about to be removed as unused.
+func (r *imageFileRepository) Forget(path string) error {
+	return r.db.Delete(&entity.ImageFile{}, "path = ?", path).Error
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
+type InvoiceRepository interface {
+	GetByOrder(orderID uint) (*entity.Invoice, error)
+	Issue(orderID uint, year int, issuedAt time.Time, render func(invoice *entity.Invoice) (string, error)) (*entity.Invoice, error)
+}
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepository {
+	return &invoiceRepository{db}
+}
+
+// GetByOrder implements InvoiceRepository.
+func (i *invoiceRepository) GetByOrder(orderID uint) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	if err := i.db.Where("order_id = ?", orderID).First(&invoice).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// Issue takes the next number for the year the order was paid in and stores
+// the invoice. The
+// year's sequence row stays locked until render has stored the document, so
+// a failed render rolls the number back and no gaps are left. If the order
+// was invoiced while waiting for the lock, that invoice is returned instead.
+func (i *invoiceRepository) Issue(orderID uint, year int, issuedAt time.Time, render func(invoice *entity.Invoice) (string, error)) (*entity.Invoice, error) {
+	var invoice *entity.Invoice
+
+	err := i.db.Transaction(func(tx *gorm.DB) error {
+		sequence := entity.InvoiceSequence{Year: year}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "year = ?", year).Error; err != nil {
+			return err
+		}
+
+		var existing entity.Invoice
+		err := tx.Where("order_id = ?", orderID).First(&existing).Error
+		if err == nil {
+			invoice = &existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		sequence.LastNumber++
+		invoice = &entity.Invoice{
+			OrderID:  orderID,
+			Number:   fmt.Sprintf("INV/%d/%06d", sequence.Year, sequence.LastNumber),
+			Year:     sequence.Year,
+			Sequence: sequence.LastNumber,
+			IssuedAt: issuedAt,
+		}
+
+		filePath, err := render(invoice)
+		if err != nil {
+			return err
+		}
+		invoice.FilePath = filePath
+
+		if err := tx.Model(&sequence).Update("last_number", sequence.LastNumber).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(invoice).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrLoyaltyInsufficientPoints = errors.New("not enough loyalty points")
+
+type LoyaltyRepository interface {
+	GetBalance(userID uint) (int, error)
+	GetEntries(userID uint) ([]entity.LoyaltyEntry, error)
+	Expire(earnedBefore time.Time) (int64, error)
+}
+
+type loyaltyRepository struct {
+	db *gorm.DB
+}
+
+func NewLoyaltyRepository(db *gorm.DB) LoyaltyRepository {
+	return &loyaltyRepository{db}
+}
+
+// GetBalance returns the points the user can still spend.
+func (l *loyaltyRepository) GetBalance(userID uint) (int, error) {
+	var balance int
+	err := l.db.Model(&entity.LoyaltyEntry{}).
+		Select("COALESCE(SUM(remaining), 0)").
+		Where("user_id = ? AND remaining > 0", userID).
+		Scan(&balance).Error
+	if err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// GetEntries returns the user's ledger, newest first.
+func (l *loyaltyRepository) GetEntries(userID uint) ([]entity.LoyaltyEntry, error) {
+	var entries []entity.LoyaltyEntry
+	if err := l.db.Where("user_id = ?", userID).Order("id DESC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Expire writes off points earned before earnedBefore that have not been
+// spent, and returns how many entries were expired.
+func (l *loyaltyRepository) Expire(earnedBefore time.Time) (int64, error) {
+	var expired int64
+	err := l.db.Transaction(func(tx *gorm.DB) error {
+		var lots []entity.LoyaltyEntry
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("remaining > 0 AND created_at < ?", earnedBefore).
+			Order("id").
+			Find(&lots).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range lots {
+			lot := &lots[i]
+			if err := tx.Create(&entity.LoyaltyEntry{
+				UserID:  lot.UserID,
+				Type:    entity.LoyaltyEntryExpire,
+				Points:  -lot.Remaining,
+				OrderID: lot.OrderID,
+				Note:    "Points expired",
+			}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(lot).Update("remaining", 0).Error; err != nil {
+				return err
+			}
+			expired++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
+
+// lockLoyaltyLots locks the user's unspent points, oldest first. Every change
+// to a balance goes through these locks, so concurrent checkouts cannot
+// spend the same points twice.
+func lockLoyaltyLots(tx *gorm.DB, userID uint) ([]entity.LoyaltyEntry, error) {
+	var lots []entity.LoyaltyEntry
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND remaining > 0", userID).
+		Order("id").
+		Find(&lots).Error
+	if err != nil {
+		return nil, err
+	}
+	return lots, nil
+}
+
+// spendLoyaltyLots takes up to points from the lots in order and returns how
+// many were taken.
+func spendLoyaltyLots(tx *gorm.DB, lots []entity.LoyaltyEntry, points int) (int, error) {
+	taken := 0
+	for i := range lots {
+		if taken == points {
+			break
+		}
+
+		take := lots[i].Remaining
+		if take > points-taken {
+			take = points - taken
+		}
+
+		if err := tx.Model(&lots[i]).Update("remaining", lots[i].Remaining-take).Error; err != nil {
+			return 0, err
+		}
+		taken += take
+	}
+	return taken, nil
+}
+
+// redeemLoyaltyPoints spends the points an order redeemed, oldest first.
+func redeemLoyaltyPoints(tx *gorm.DB, order *entity.Order) error {
+	if order.PointsRedeemed == 0 {
+		return nil
+	}
+
+	lots, err := lockLoyaltyLots(tx, order.UserID)
+	if err != nil {
+		return err
+	}
+
+	available := 0
+	for _, lot := range lots {
+		available += lot.Remaining
+	}
+	if available < order.PointsRedeemed {
+		return ErrLoyaltyInsufficientPoints
+	}
+
+	if _, err := spendLoyaltyLots(tx, lots, order.PointsRedeemed); err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.LoyaltyEntry{
+		UserID:  order.UserID,
+		Type:    entity.LoyaltyEntryRedeem,
+		Points:  -order.PointsRedeemed,
+		OrderID: &order.ID,
+		Note:    "Checkout",
+	}).Error
+}
+
+// earnLoyaltyPoints credits the points an order earns once it is paid. An
+// order only ever earns once.
+func earnLoyaltyPoints(tx *gorm.DB, orderID uint) error {
+	var order entity.Order
+	if err := tx.First(&order, orderID).Error; err != nil {
+		return err
+	}
+	if order.PointsEarned == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&entity.LoyaltyEntry{}).Where("order_id = ? AND type = ?", orderID, entity.LoyaltyEntryEarn).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	return tx.Create(&entity.LoyaltyEntry{
+		UserID:    order.UserID,
+		Type:      entity.LoyaltyEntryEarn,
+		Points:    order.PointsEarned,
+		Remaining: order.PointsEarned,
+		OrderID:   &order.ID,
+		Note:      "Order paid",
+	}).Error
+}
+
+// cancelLoyaltyPoints gives back the points a cancelled order redeemed and
+// takes back what it earned. The returned points start a new lot, so they
+// expire a full period after the cancellation.
+func cancelLoyaltyPoints(tx *gorm.DB, orderID uint) error {
+	var order entity.Order
+	if err := tx.First(&order, orderID).Error; err != nil {
+		return err
+	}
+
+	var redeemed int
+	err := tx.Model(&entity.LoyaltyEntry{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("order_id = ? AND (type = ? OR (type = ? AND points > 0))", orderID, entity.LoyaltyEntryRedeem, entity.LoyaltyEntryReverse).
+		Scan(&redeemed).Error
+	if err != nil {
+		return err
+	}
+
+	if redeemed < 0 {
+		if err := tx.Create(&entity.LoyaltyEntry{
+			UserID:    order.UserID,
+			Type:      entity.LoyaltyEntryReverse,
+			Points:    -redeemed,
+			Remaining: -redeemed,
+			OrderID:   &order.ID,
+			Note:      "Order cancelled",
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return reverseEarnedPoints(tx, &order, order.PointsEarned, "Order cancelled")
+}
+
+// reverseEarnedPoints takes back up to points of what the order earned and
+// has not been reversed yet. Points still unspent on the order's own lot go
+// first, then the customer's other points.
+func reverseEarnedPoints(tx *gorm.DB, order *entity.Order, points int, note string) error {
+	var earned, reversed int
+	err := tx.Model(&entity.LoyaltyEntry{}).
+		Select("COALESCE(SUM(points), 0)").
+		Where("order_id = ? AND type = ?", order.ID, entity.LoyaltyEntryEarn).
+		Scan(&earned).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&entity.LoyaltyEntry{}).
+		Select("COALESCE(-SUM(points), 0)").
+		Where("order_id = ? AND type = ? AND points < 0", order.ID, entity.LoyaltyEntryReverse).
+		Scan(&reversed).Error
+	if err != nil {
+		return err
+	}
+
+	if points > earned-reversed {
+		points = earned - reversed
+	}
+	if points <= 0 {
+		return nil
+	}
+
+	lots, err := lockLoyaltyLots(tx, order.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Move the order's own lot to the front
+	for i := range lots {
+		if lots[i].Type == entity.LoyaltyEntryEarn && lots[i].OrderID != nil && *lots[i].OrderID == order.ID {
+			lots = append([]entity.LoyaltyEntry{lots[i]}, append(lots[:i:i], lots[i+1:]...)...)
+			break
+		}
+	}
+
+	taken, err := spendLoyaltyLots(tx, lots, points)
+	if err != nil {
+		return err
+	}
+	if taken == 0 {
+		return nil
+	}
+
+	return tx.Create(&entity.LoyaltyEntry{
+		UserID:  order.UserID,
+		Type:    entity.LoyaltyEntryReverse,
+		Points:  -taken,
+		OrderID: &order.ID,
+		Note:    note,
+	}).Error
+}
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrInsufficientStock  = errors.New("not enough stock for one of the books")
+	ErrOrderStatusChanged = errors.New("order status was changed by another request")
+)
+
+type OrderRepository interface {
+	Create(order *entity.Order) (*entity.Order, error)
+	GetAllByUser(userID uint) ([]entity.Order, error)
+	GetById(id uint) (*entity.Order, error)
+	UpdateStatus(id uint, from string, to string) (*entity.Order, error)
+	UpdatePayment(id uint, status string, paymentReference string) (*entity.Order, error)
+	GetReleasablePreorders() ([]entity.Order, error)
+	ClaimPreorderCapture(id uint) (*entity.Order, error)
+	ReleasePreorderCapture(id uint) error
+	HasPurchased(userID uint, bookID uint) (bool, error)
+}
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{db}
+}
+
+// Create stores the order with its items and coupon redemptions. Coupon usage
+// limits are re-checked while holding a lock on the coupon row so that two
+// concurrent checkouts cannot both use the last redemption. Pre-orders take
+// their stock when they are released rather than now. Gift card amounts
+// and redeemed loyalty points are taken in the same transaction.
+func (o *orderRepository) Create(order *entity.Order) (*entity.Order, error) {
+	err := o.db.Transaction(func(tx *gorm.DB) error {
+		for _, redemption := range order.CouponRedemptions {
+			var coupon entity.Coupon
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&coupon, redemption.CouponID).Error; err != nil {
+				return ErrCouponNotFound
+			}
+
+			if coupon.UsageLimit > 0 {
+				var count int64
+				if err := tx.Model(&entity.CouponRedemption{}).Where("coupon_id = ?", coupon.ID).Count(&count).Error; err != nil {
+					return err
+				}
+				if count >= int64(coupon.UsageLimit) {
+					return ErrCouponUsageLimitReached
+				}
+			}
+
+			if coupon.PerUserLimit > 0 {
+				var count int64
+				if err := tx.Model(&entity.CouponRedemption{}).Where("coupon_id = ? AND user_id = ?", coupon.ID, order.UserID).Count(&count).Error; err != nil {
+					return err
+				}
+				if count >= int64(coupon.PerUserLimit) {
+					return ErrCouponUsageLimitReached
+				}
+			}
+		}
+
+		if !order.IsPreorder {
+			if err := takeStock(tx, order.Items); err != nil {
+				return err
+			}
+		}
+
+		giftCardRedemptions := order.GiftCardTransactions
+		if err := tx.Omit("CouponRedemptions.Coupon", "GiftCardTransactions").Create(order).Error; err != nil {
+			return err
+		}
+
+		if err := redeemGiftCards(tx, order, giftCardRedemptions, time.Now()); err != nil {
+			return err
+		}
+
+		return redeemLoyaltyPoints(tx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetById(order.ID)
+}
+
+func (o *orderRepository) GetAllByUser(userID uint) ([]entity.Order, error) {
+	var orders []entity.Order
+	if err := o.db.Preload("Items").Preload("CouponRedemptions.Coupon").Where("user_id = ?", userID).Order("id DESC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (o *orderRepository) GetById(id uint) (*entity.Order, error) {
+	var order entity.Order
+	if err := o.db.Preload("Items").Preload("CouponRedemptions.Coupon").First(&order, id).Error; err != nil {
+		return nil, ErrOrderNotFound
+	}
+	return &order, nil
+}
+
+// UpdateStatus moves the order from one status to another. The order row is
+// locked while its status is checked, so two requests cannot both make the
+// same change. A paid order records when it was paid and earns its loyalty
+// points. Cancelling an order
+// puts its items back into stock, except for pre-orders that have not been
+// released and so never took any, releases its coupon redemptions, returns
+// gift card amounts to their cards and gives back redeemed points while
+// taking back earned ones.
+func (o *orderRepository) UpdateStatus(id uint, from string, to string) (*entity.Order, error) {
+	err := o.db.Transaction(func(tx *gorm.DB) error {
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error; err != nil {
+			return ErrOrderNotFound
+		}
+		if order.Status != from {
+			return ErrOrderStatusChanged
+		}
+
+		if err := tx.Model(&entity.Order{}).Where("id = ?", id).Updates(statusChanges(to)).Error; err != nil {
+			return err
+		}
+
+		if to == entity.OrderStatusPaid {
+			return earnLoyaltyPoints(tx, id)
+		}
+
+		if to != entity.OrderStatusCancelled {
+			return nil
+		}
+
+		if err := tx.Where("order_id = ?", id).Delete(&entity.CouponRedemption{}).Error; err != nil {
+			return err
+		}
+
+		if err := refundGiftCards(tx, id, order.GiftCardTotal, "Order cancelled"); err != nil {
+			return err
+		}
+
+		if err := cancelLoyaltyPoints(tx, id); err != nil {
+			return err
+		}
+
+		if order.IsPreorder && (order.Status == entity.OrderStatusPending || order.Status == entity.OrderStatusPreordered) {
+			return nil
+		}
+		return putBackStock(tx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetById(id)
+}
+
+// UpdatePayment sets the status together with the provider's payment
+// reference. Like UpdateStatus, a paid order earns its loyalty points and
+// records when it was paid.
+func (o *orderRepository) UpdatePayment(id uint, status string, paymentReference string) (*entity.Order, error) {
+	err := o.db.Transaction(func(tx *gorm.DB) error {
+		changes := statusChanges(status)
+		changes["payment_reference"] = paymentReference
+
+		result := tx.Model(&entity.Order{}).Where("id = ?", id).Updates(changes)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderNotFound
+		}
+
+		if status == entity.OrderStatusPaid {
+			return earnLoyaltyPoints(tx, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetById(id)
+}
+
+// GetReleasablePreorders returns pre-orders whose books have all been
+// released.
+func (o *orderRepository) GetReleasablePreorders() ([]entity.Order, error) {
+	var orders []entity.Order
+	err := o.db.Preload("Items").
+		Where("status = ?", entity.OrderStatusPreordered).
+		Where("NOT EXISTS (?)", o.db.Table("order_items").
+			Select("1").
+			Joins("JOIN books ON books.id = order_items.book_id").
+			Where("order_items.order_id = orders.id AND books.availability = ?", entity.BookAvailabilityPreorder)).
+		Order("id").
+		Find(&orders).Error
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// ClaimPreorderCapture moves a released pre-order to capturing and takes its
+// stock before the payment is captured, so no other run can capture it too.
+// An order left capturing by a crash must be checked against the provider by
+// hand.
+func (o *orderRepository) ClaimPreorderCapture(id uint) (*entity.Order, error) {
+	err := o.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Order{}).
+			Where("id = ? AND status = ?", id, entity.OrderStatusPreordered).
+			Update("status", entity.OrderStatusCapturing)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderStatusChanged
+		}
+
+		var items []entity.OrderItem
+		if err := tx.Where("order_id = ?", id).Find(&items).Error; err != nil {
+			return err
+		}
+		return takeStock(tx, items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetById(id)
+}
+
+// ReleasePreorderCapture puts a claimed pre-order back to pre-ordered with its
+// stock after the capture failed, so the next run tries again.
+func (o *orderRepository) ReleasePreorderCapture(id uint) error {
+	return o.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Order{}).
+			Where("id = ? AND status = ?", id, entity.OrderStatusCapturing).
+			Update("status", entity.OrderStatusPreordered)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderStatusChanged
+		}
+
+		return putBackStock(tx, id)
+	})
+}
+
+// HasPurchased reports whether the user has a paid order containing the book.
+func (o *orderRepository) HasPurchased(userID uint, bookID uint) (bool, error) {
+	var count int64
+	err := o.db.Model(&entity.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND order_items.book_id = ?", userID, bookID).
+		Where("orders.status IN ?", []string{entity.OrderStatusPaid, entity.OrderStatusShipped, entity.OrderStatusDelivered}).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// statusChanges sets the status, and the time the order was paid the first
+// time it is paid. A paid order put back to paid keeps its first time.
+func statusChanges(status string) map[string]interface{} {
+	changes := map[string]interface{}{"status": status}
+	if status == entity.OrderStatusPaid {
+		changes["paid_at"] = gorm.Expr("COALESCE(paid_at, ?)", time.Now())
+	}
+	return changes
+}
+
+// putBackStock returns the items of an order to stock.
+func putBackStock(tx *gorm.DB, orderID uint) error {
+	var items []entity.OrderItem
+	if err := tx.Where("order_id = ?", orderID).Find(&items).Error; err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := tx.Model(&entity.Book{}).Where("id = ?", item.BookID).Update("stock", gorm.Expr("stock + ?", item.Quantity)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// takeStock decrements stock for each item, failing if any book would go
+// below zero. Books whose stock is not tracked are never short.
+func takeStock(tx *gorm.DB, items []entity.OrderItem) error {
+	for _, item := range items {
+		result := tx.Model(&entity.Book{}).
+			Where("id = ? AND stock IS NOT NULL AND stock >= ?", item.BookID, item.Quantity).
+			Update("stock", gorm.Expr("stock - ?", item.Quantity))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			continue
+		}
+
+		var untracked int64
+		if err := tx.Model(&entity.Book{}).Where("id = ? AND stock IS NULL", item.BookID).Count(&untracked).Error; err != nil {
+			return err
+		}
+		if untracked == 0 {
+			return ErrInsufficientStock
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+// BookScore pairs a book with how strongly it relates to another book.
+type BookScore struct {
+	BookID uint
+	Score  int
+}
+
+type RecommendationRepository interface {
+	Rebuild(minSupport int) error
+	GetByBook(bookID uint, limit int) ([]entity.BookRecommendation, error)
+	GetByCategories(bookID uint, excludeIDs []uint, limit int) ([]BookScore, error)
+}
+
+type recommendationRepository struct {
+	db *gorm.DB
+}
+
+func NewRecommendationRepository(db *gorm.DB) RecommendationRepository {
+	return &recommendationRepository{db}
+}
+
+// Rebuild replaces the precomputed pairs with fresh counts of how many paid
+// orders contained both books. Pairs seen in fewer than minSupport orders are
+// dropped.
+func (r *recommendationRepository) Rebuild(minSupport int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM book_recommendations").Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			INSERT INTO book_recommendations (book_id, recommended_book_id, score, created_at)
+			SELECT a.book_id, b.book_id, COUNT(DISTINCT a.order_id), NOW()
+			FROM order_items a
+			JOIN order_items b ON b.order_id = a.order_id AND b.book_id <> a.book_id
+			JOIN orders o ON o.id = a.order_id
+			JOIN books ba ON ba.id = a.book_id
+			JOIN books bb ON bb.id = b.book_id
+			WHERE o.status IN ?
+			GROUP BY a.book_id, b.book_id
+			HAVING COUNT(DISTINCT a.order_id) >= ?`,
+			[]string{entity.OrderStatusPaid, entity.OrderStatusShipped, entity.OrderStatusDelivered},
+			minSupport,
+		).Error
+	})
+}
+
+// GetByBook implements RecommendationRepository.
+func (r *recommendationRepository) GetByBook(bookID uint, limit int) ([]entity.BookRecommendation, error) {
+	var recommendations []entity.BookRecommendation
+	err := r.db.Preload("RecommendedBook.Categories").Preload("RecommendedBook.Images", orderBookImages).
+		Where("book_id = ?", bookID).
+		Order("score DESC").Order("recommended_book_id").
+		Limit(limit).
+		Find(&recommendations).Error
+	if err != nil {
+		return nil, err
+	}
+	return recommendations, nil
+}
+
+// GetByCategories returns books sharing categories with the given book, most
+// shared categories first.
+func (r *recommendationRepository) GetByCategories(bookID uint, excludeIDs []uint, limit int) ([]BookScore, error) {
+	var scores []BookScore
+
+	db := r.db.Table("book_categories").
+		Select("book_categories.book_id AS book_id, COUNT(*) AS score").
+		Joins("JOIN books ON books.id = book_categories.book_id").
+		Where("book_categories.category_id IN (?)", r.db.Table("book_categories").Select("category_id").Where("book_id = ?", bookID)).
+		Where("book_categories.book_id <> ?", bookID)
+	if len(excludeIDs) > 0 {
+		db = db.Where("book_categories.book_id NOT IN ?", excludeIDs)
+	}
+
+	err := db.Group("book_categories.book_id").
+		Order("score DESC").Order("MAX(books.rating_average) DESC").Order("book_categories.book_id").
+		Limit(limit).
+		Scan(&scores).Error
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrReturnNotFound         = errors.New("return not found")
+	ErrReturnQuantityExceeded = errors.New("return quantity exceeds what was ordered")
+	ErrReturnStatusChanged    = errors.New("return status was changed by another request")
+)
+
+type ReturnRepository interface {
+	Create(orderReturn *entity.Return) (*entity.Return, error)
+	GetAllByUser(userID uint) ([]entity.Return, error)
+	GetById(id uint) (*entity.Return, error)
+	UpdateStatus(id uint, from string, to string, note string) (*entity.Return, error)
+	ClaimRefund(id uint, amount int) (*entity.Return, error)
+	ReleaseRefund(id uint, note string) (*entity.Return, error)
+	MarkRefunded(id uint, amount int, reference string, note string) (*entity.Return, error)
+}
+
+type returnRepository struct {
+	db *gorm.DB
+}
+
+func NewReturnRepository(db *gorm.DB) ReturnRepository {
+	return &returnRepository{db}
+}
+
+// Create stores the return with its first status history entry. The order row
+// is locked while quantities are checked so that concurrent requests cannot
+// return more than was bought.
+func (r *returnRepository) Create(orderReturn *entity.Return) (*entity.Return, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderReturn.OrderID).Error; err != nil {
+			return ErrOrderNotFound
+		}
+
+		var items []entity.OrderItem
+		if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+			return err
+		}
+
+		returned, err := returnedQuantities(tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		ordered := map[uint]int{}
+		for _, item := range items {
+			ordered[item.ID] = item.Quantity
+		}
+
+		for _, item := range orderReturn.Items {
+			returned[item.OrderItemID] += item.Quantity
+			if returned[item.OrderItemID] > ordered[item.OrderItemID] {
+				return ErrReturnQuantityExceeded
+			}
+		}
+
+		return tx.Create(orderReturn).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(orderReturn.ID)
+}
+
+// GetAllByUser implements ReturnRepository.
+func (r *returnRepository) GetAllByUser(userID uint) ([]entity.Return, error) {
+	var orderReturns []entity.Return
+	if err := r.preload().Where("user_id = ?", userID).Order("id DESC").Find(&orderReturns).Error; err != nil {
+		return nil, err
+	}
+	return orderReturns, nil
+}
+
+// GetById implements ReturnRepository.
+func (r *returnRepository) GetById(id uint) (*entity.Return, error) {
+	var orderReturn entity.Return
+	if err := r.preload().First(&orderReturn, id).Error; err != nil {
+		return nil, ErrReturnNotFound
+	}
+	return &orderReturn, nil
+}
+
+// UpdateStatus moves the return from one status to another. Receiving a
+// return puts its items back into stock.
+func (r *returnRepository) UpdateStatus(id uint, from string, to string, note string) (*entity.Return, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateReturnStatus(tx, id, from, to, note, map[string]interface{}{"status": to}); err != nil {
+			return err
+		}
+
+		if to != entity.ReturnStatusReceived {
+			return nil
+		}
+
+		var items []entity.ReturnItem
+		if err := tx.Where("return_id = ?", id).Find(&items).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := tx.Model(&entity.Book{}).Where("id = ?", item.BookID).Update("stock", gorm.Expr("stock + ?", item.Quantity)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(id)
+}
+
+// ClaimRefund moves a received return to refunding before the provider is
+// asked to pay out, so only one request can refund it. The amount is split
+// the way the order was paid and stored on the return, leaving RefundAmount
+// as what the provider should pay back and UnrefundedAmount as what no way
+// of payment has left. A return left in refunding by a crash must be checked
+// against the provider by hand.
+func (r *returnRepository) ClaimRefund(id uint, amount int) (*entity.Return, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var orderReturn entity.Return
+		if err := tx.First(&orderReturn, id).Error; err != nil {
+			return ErrReturnNotFound
+		}
+
+		// Claims on returns of the same order take turns, so each one sees
+		// what the others have already refunded
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderReturn.OrderID).Error; err != nil {
+			return err
+		}
+
+		split, err := splitRefund(tx, &order, id, amount)
+		if err != nil {
+			return err
+		}
+
+		return updateReturnStatus(tx, id, entity.ReturnStatusReceived, entity.ReturnStatusRefunding, "Refund started", map[string]interface{}{
+			"status":                  entity.ReturnStatusRefunding,
+			"refund_amount":           split.Cash,
+			"gift_card_refund_amount": split.GiftCard,
+			"points_refunded":         split.Points,
+			"unrefunded_amount":       split.Unrefunded,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(id)
+}
+
+// ReleaseRefund puts a claimed return back to received after the provider
+// refused the refund, so it can be tried again.
+func (r *returnRepository) ReleaseRefund(id uint, note string) (*entity.Return, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return updateReturnStatus(tx, id, entity.ReturnStatusRefunding, entity.ReturnStatusReceived, note, map[string]interface{}{
+			"status":                  entity.ReturnStatusReceived,
+			"refund_amount":           0,
+			"gift_card_refund_amount": 0,
+			"points_refunded":         0,
+			"unrefunded_amount":       0,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(id)
+}
+
+// MarkRefunded stores the provider's refund and closes the claimed return. The
+// gift card part goes back on the cards and the points part back to the
+// customer, while the loyalty points the order earned are taken back in
+// proportion to the money refunded.
+func (r *returnRepository) MarkRefunded(id uint, amount int, reference string, note string) (*entity.Return, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := updateReturnStatus(tx, id, entity.ReturnStatusRefunding, entity.ReturnStatusRefunded, note, map[string]interface{}{
+			"status":           entity.ReturnStatusRefunded,
+			"refund_amount":    amount,
+			"refund_reference": reference,
+			"refunded_at":      time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		var orderReturn entity.Return
+		if err := tx.First(&orderReturn, id).Error; err != nil {
+			return err
+		}
+
+		var order entity.Order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, orderReturn.OrderID).Error; err != nil {
+			return err
+		}
+
+		if err := refundGiftCards(tx, order.ID, orderReturn.GiftCardRefundAmount, "Order refunded"); err != nil {
+			return err
+		}
+
+		if orderReturn.PointsRefunded > 0 {
+			if err := tx.Create(&entity.LoyaltyEntry{
+				UserID:    order.UserID,
+				Type:      entity.LoyaltyEntryReverse,
+				Points:    orderReturn.PointsRefunded,
+				Remaining: orderReturn.PointsRefunded,
+				OrderID:   &order.ID,
+				Note:      "Order refunded",
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		paid := order.Total - order.PointsDiscount
+		if order.PointsEarned == 0 || paid <= 0 {
+			return nil
+		}
+
+		refunded := amount + orderReturn.GiftCardRefundAmount
+		points := int(int64(order.PointsEarned) * int64(refunded) / int64(paid))
+		return reverseEarnedPoints(tx, &order, points, "Order refunded")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetById(id)
+}
+
+func (r *returnRepository) preload() *gorm.DB {
+	return r.db.Preload("Items").Preload("Histories", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
+
+// updateReturnStatus only applies the changes while the return is still in
+// the expected status, so two staff members cannot act on it twice.
+func updateReturnStatus(tx *gorm.DB, id uint, from string, to string, note string, changes map[string]interface{}) error {
+	result := tx.Model(&entity.Return{}).Where("id = ? AND status = ?", id, from).Updates(changes)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrReturnStatusChanged
+	}
+
+	return tx.Create(&entity.ReturnStatusHistory{ReturnID: id, Status: to, Note: note}).Error
+}
+
+// refundSplit is a refund divided between the ways an order was paid: cash
+// from the provider, gift card balance and redeemed points. Unrefunded is
+// the part of the refund none of them can pay back.
+type refundSplit struct {
+	Cash       int
+	GiftCard   int
+	Points     int
+	Unrefunded int
+}
+
+// splitRefund divides a refund between the ways the order was paid, after
+// what the order's other refunds have already taken.
+func splitRefund(tx *gorm.DB, order *entity.Order, returnID uint, amount int) (refundSplit, error) {
+	var refunded refundSplit
+	err := tx.Model(&entity.Return{}).
+		Select("COALESCE(SUM(refund_amount), 0) AS cash, COALESCE(SUM(gift_card_refund_amount), 0) AS gift_card, COALESCE(SUM(points_refunded), 0) AS points").
+		Where("order_id = ? AND id <> ? AND status IN ?", order.ID, returnID, []string{entity.ReturnStatusRefunding, entity.ReturnStatusRefunded}).
+		Scan(&refunded).Error
+	if err != nil {
+		return refundSplit{}, err
+	}
+
+	return divideRefund(order, amount, refunded), nil
+}
+
+// divideRefund splits the amount in the same shares as the order's total.
+// Each part is capped at what is left of it after the refunds already made,
+// so the provider never pays back more than it captured, and what the caps
+// take off is reported as unrefunded. The points part is given as the points
+// that were redeemed for it.
+func divideRefund(order *entity.Order, amount int, refunded refundSplit) refundSplit {
+	if order.Total <= 0 {
+		return refundSplit{}
+	}
+
+	discount := int(int64(amount) * int64(order.PointsDiscount) / int64(order.Total))
+	giftCard := int(int64(amount) * int64(order.GiftCardTotal) / int64(order.Total))
+	cash := amount - discount - giftCard
+	points := 0
+	if order.PointsDiscount > 0 {
+		points = int(int64(order.PointsRedeemed) * int64(discount) / int64(order.PointsDiscount))
+	}
+
+	split := refundSplit{
+		Cash:     clampRefund(cash, order.AmountDue-refunded.Cash),
+		GiftCard: clampRefund(giftCard, order.GiftCardTotal-refunded.GiftCard),
+		Points:   clampRefund(points, order.PointsRedeemed-refunded.Points),
+	}
+
+	// Points that cannot be given back count at what they were redeemed for
+	split.Unrefunded = cash - split.Cash + giftCard - split.GiftCard
+	if split.Points < points {
+		split.Unrefunded += int(int64(points-split.Points) * int64(order.PointsDiscount) / int64(order.PointsRedeemed))
+	}
+	return split
+}
+
+// clampRefund keeps a refund part between zero and what is left of it.
+func clampRefund(part int, left int) int {
+	if part > left {
+		part = left
+	}
+	if part < 0 {
+		return 0
+	}
+	return part
+}
+
+// returnedQuantities sums, per order line, the quantities already on returns
+// that were not rejected.
+func returnedQuantities(tx *gorm.DB, orderID uint) (map[uint]int, error) {
+	var rows []struct {
+		OrderItemID uint
+		Quantity    int
+	}
+
+	err := tx.Model(&entity.ReturnItem{}).
+		Select("return_items.order_item_id, SUM(return_items.quantity) AS quantity").
+		Joins("JOIN returns ON returns.id = return_items.return_id").
+		Where("returns.order_id = ? AND returns.status <> ?", orderID, entity.ReturnStatusRejected).
+		Group("return_items.order_item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := map[uint]int{}
+	for _, row := range rows {
+		quantities[row.OrderItemID] = row.Quantity
+	}
+	return quantities, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+func TestDivideRefund(t *testing.T) {
+	// Paid 70000 in cash, 20000 from gift cards and 10000 with 1000 points
+	mixed := &entity.Order{Total: 100000, AmountDue: 70000, GiftCardTotal: 20000, PointsDiscount: 10000, PointsRedeemed: 1000}
+	cashOnly := &entity.Order{Total: 50000, AmountDue: 50000}
+
+	tests := []struct {
+		name     string
+		order    *entity.Order
+		amount   int
+		refunded refundSplit
+		want     refundSplit
+	}{
+		{"whole order", mixed, 100000, refundSplit{}, refundSplit{Cash: 70000, GiftCard: 20000, Points: 1000}},
+		{"half the order", mixed, 50000, refundSplit{}, refundSplit{Cash: 35000, GiftCard: 10000, Points: 500}},
+		{"rounding goes to cash", mixed, 33333, refundSplit{}, refundSplit{Cash: 23334, GiftCard: 6666, Points: 333}},
+		{"capped at what is left", mixed, 50000, refundSplit{Cash: 60000, GiftCard: 15000, Points: 900}, refundSplit{Cash: 10000, GiftCard: 5000, Points: 100, Unrefunded: 34000}},
+		{"nothing left", mixed, 50000, refundSplit{Cash: 70000, GiftCard: 20000, Points: 1000}, refundSplit{Unrefunded: 50000}},
+		{"cash capped by one", cashOnly, 20000, refundSplit{Cash: 30001}, refundSplit{Cash: 19999, Unrefunded: 1}},
+		{"cash only", cashOnly, 20000, refundSplit{}, refundSplit{Cash: 20000}},
+		{"free order", &entity.Order{}, 20000, refundSplit{}, refundSplit{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := divideRefund(tt.order, tt.amount, tt.refunded); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClampRefund(t *testing.T) {
+	tests := []struct {
+		name string
+		part int
+		left int
+		want int
+	}{
+		{"within what is left", 500, 1000, 500},
+		{"more than is left", 1500, 1000, 1000},
+		{"nothing left", 500, 0, 0},
+		{"over refunded", 500, -200, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clampRefund(tt.part, tt.left); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
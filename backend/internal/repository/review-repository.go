@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReviewAlreadyExists = errors.New("you have already reviewed this book")
+)
+
+type ReviewRepository interface {
+	Create(review *entity.Review) (*entity.Review, error)
+	Update(review *entity.Review) (*entity.Review, error)
+	Delete(review *entity.Review) error
+	GetById(id uint) (*entity.Review, error)
+	GetByBook(bookID uint) ([]entity.Review, error)
+	GetByBookAndUser(bookID uint, userID uint) (*entity.Review, error)
+}
+
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db}
+}
+
+func (r *reviewRepository) Create(review *entity.Review) (*entity.Review, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.Review{}).Where("book_id = ? AND user_id = ?", review.BookID, review.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrReviewAlreadyExists
+		}
+
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return refreshBookRating(tx, review.BookID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (r *reviewRepository) Update(review *entity.Review) (*entity.Review, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(review).Select("Rating", "Body", "VerifiedPurchase").Updates(review).Error; err != nil {
+			return err
+		}
+		return refreshBookRating(tx, review.BookID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetById(review.ID)
+}
+
+func (r *reviewRepository) Delete(review *entity.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.Review{}, review.ID).Error; err != nil {
+			return err
+		}
+		return refreshBookRating(tx, review.BookID)
+	})
+}
+
+func (r *reviewRepository) GetById(id uint) (*entity.Review, error) {
+	var review entity.Review
+	if err := r.db.First(&review, id).Error; err != nil {
+		return nil, ErrReviewNotFound
+	}
+	return &review, nil
+}
+
+func (r *reviewRepository) GetByBook(bookID uint) ([]entity.Review, error) {
+	var reviews []entity.Review
+	if err := r.db.Where("book_id = ?", bookID).Order("created_at DESC").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *reviewRepository) GetByBookAndUser(bookID uint, userID uint) (*entity.Review, error) {
+	var review entity.Review
+	if err := r.db.Where("book_id = ? AND user_id = ?", bookID, userID).First(&review).Error; err != nil {
+		return nil, ErrReviewNotFound
+	}
+	return &review, nil
+}
+
+// refreshBookRating stores the book's review count and average on the books
+// row, so listings read them without aggregating reviews.
+func refreshBookRating(tx *gorm.DB, bookID uint) error {
+	return tx.Exec(`
+		UPDATE books SET
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE book_id = ?),
+			rating_average = COALESCE((SELECT ROUND(AVG(rating), 2) FROM reviews WHERE book_id = ?), 0)
+		WHERE id = ?`, bookID, bookID, bookID).Error
+}
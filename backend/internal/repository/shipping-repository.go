@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrShippingZoneNotFound   = errors.New("shipping zone not found")
+	ErrShippingMethodNotFound = errors.New("shipping method not found")
+)
+
+type ShippingRepository interface {
+	CreateZone(zone *entity.ShippingZone) (*entity.ShippingZone, error)
+	UpdateZone(zone *entity.ShippingZone) (*entity.ShippingZone, error)
+	DeleteZone(id uint) error
+	GetAllZones() ([]entity.ShippingZone, error)
+	GetZoneById(id uint) (*entity.ShippingZone, error)
+	CreateMethod(method *entity.ShippingMethod) (*entity.ShippingMethod, error)
+	UpdateMethod(method *entity.ShippingMethod) (*entity.ShippingMethod, error)
+	DeleteMethod(id uint) error
+	GetMethodById(id uint) (*entity.ShippingMethod, error)
+}
+
+type shippingRepository struct {
+	db *gorm.DB
+}
+
+func NewShippingRepository(db *gorm.DB) ShippingRepository {
+	return &shippingRepository{db}
+}
+
+func (r *shippingRepository) CreateZone(zone *entity.ShippingZone) (*entity.ShippingZone, error) {
+	if err := r.db.Create(zone).Error; err != nil {
+		return nil, err
+	}
+	return r.GetZoneById(zone.ID)
+}
+
+// UpdateZone renames the zone and replaces its regions.
+func (r *shippingRepository) UpdateZone(zone *entity.ShippingZone) (*entity.ShippingZone, error) {
+	var existingZone entity.ShippingZone
+	if err := r.db.First(&existingZone, zone.ID).Error; err != nil {
+		return nil, ErrShippingZoneNotFound
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&existingZone).Update("name", zone.Name).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("shipping_zone_id = ?", zone.ID).Delete(&entity.ShippingZoneRegion{}).Error; err != nil {
+			return err
+		}
+		for i := range zone.Regions {
+			zone.Regions[i].ShippingZoneID = zone.ID
+		}
+		return tx.Create(&zone.Regions).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetZoneById(zone.ID)
+}
+
+func (r *shippingRepository) DeleteZone(id uint) error {
+	if err := r.db.Delete(&entity.ShippingZone{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *shippingRepository) GetAllZones() ([]entity.ShippingZone, error) {
+	var zones []entity.ShippingZone
+	if err := r.db.Preload("Regions").Preload("Methods.Rates").Find(&zones).Error; err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+func (r *shippingRepository) GetZoneById(id uint) (*entity.ShippingZone, error) {
+	var zone entity.ShippingZone
+	if err := r.db.Preload("Regions").Preload("Methods.Rates").First(&zone, id).Error; err != nil {
+		return nil, ErrShippingZoneNotFound
+	}
+	return &zone, nil
+}
+
+func (r *shippingRepository) CreateMethod(method *entity.ShippingMethod) (*entity.ShippingMethod, error) {
+	if err := r.db.Create(method).Error; err != nil {
+		return nil, err
+	}
+	return r.GetMethodById(method.ID)
+}
+
+// UpdateMethod renames the method and replaces its rate table.
+func (r *shippingRepository) UpdateMethod(method *entity.ShippingMethod) (*entity.ShippingMethod, error) {
+	var existingMethod entity.ShippingMethod
+	if err := r.db.First(&existingMethod, method.ID).Error; err != nil {
+		return nil, ErrShippingMethodNotFound
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&existingMethod).Update("name", method.Name).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("shipping_method_id = ?", method.ID).Delete(&entity.ShippingRate{}).Error; err != nil {
+			return err
+		}
+		for i := range method.Rates {
+			method.Rates[i].ShippingMethodID = method.ID
+		}
+		return tx.Create(&method.Rates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetMethodById(method.ID)
+}
+
+func (r *shippingRepository) DeleteMethod(id uint) error {
+	if err := r.db.Delete(&entity.ShippingMethod{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *shippingRepository) GetMethodById(id uint) (*entity.ShippingMethod, error) {
+	var method entity.ShippingMethod
+	if err := r.db.Preload("Rates").First(&method, id).Error; err != nil {
+		return nil, ErrShippingMethodNotFound
+	}
+	return &method, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+var ErrTaxRateNotFound = errors.New("tax rate not found")
+
+type TaxRateRepository interface {
+	Create(taxRate *entity.TaxRate) (*entity.TaxRate, error)
+	Update(taxRate *entity.TaxRate) (*entity.TaxRate, error)
+	Delete(id uint) error
+	GetAll() ([]entity.TaxRate, error)
+	GetById(id uint) (*entity.TaxRate, error)
+}
+
+type taxRateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRateRepository(db *gorm.DB) TaxRateRepository {
+	return &taxRateRepository{db}
+}
+
+func (r *taxRateRepository) Create(taxRate *entity.TaxRate) (*entity.TaxRate, error) {
+	if err := r.db.Create(taxRate).Error; err != nil {
+		return nil, err
+	}
+	return taxRate, nil
+}
+
+func (r *taxRateRepository) Update(taxRate *entity.TaxRate) (*entity.TaxRate, error) {
+	var existingTaxRate entity.TaxRate
+	if err := r.db.First(&existingTaxRate, taxRate.ID).Error; err != nil {
+		return nil, ErrTaxRateNotFound
+	}
+
+	// Select the columns explicitly so a rate of 0 is written
+	if err := r.db.Model(&existingTaxRate).Select("Name", "Rate").Updates(taxRate).Error; err != nil {
+		return nil, err
+	}
+	return &existingTaxRate, nil
+}
+
+func (r *taxRateRepository) Delete(id uint) error {
+	if err := r.db.Delete(&entity.TaxRate{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *taxRateRepository) GetAll() ([]entity.TaxRate, error) {
+	var taxRates []entity.TaxRate
+	if err := r.db.Find(&taxRates).Error; err != nil {
+		return nil, err
+	}
+	return taxRates, nil
+}
+
+func (r *taxRateRepository) GetById(id uint) (*entity.TaxRate, error) {
+	var taxRate entity.TaxRate
+	if err := r.db.First(&taxRate, id).Error; err != nil {
+		return nil, ErrTaxRateNotFound
+	}
+	return &taxRate, nil
+}
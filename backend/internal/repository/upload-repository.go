@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+var ErrUploadNotFound = errors.New("upload not found")
+
+type UploadRepository interface {
+	Create(upload *entity.Upload) (*entity.Upload, error)
+	Delete(upload *entity.Upload) error
+	GetById(id string) (*entity.Upload, error)
+	GetExpired(now time.Time) ([]entity.Upload, error)
+}
+
+type uploadRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) UploadRepository {
+	return &uploadRepository{db}
+}
+
+func (r *uploadRepository) Create(upload *entity.Upload) (*entity.Upload, error) {
+	if err := r.db.Create(upload).Error; err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+func (r *uploadRepository) Delete(upload *entity.Upload) error {
+	return r.db.Delete(&entity.Upload{}, "id = ?", upload.ID).Error
+}
+
+// GetById returns an upload that has not expired yet.
+func (r *uploadRepository) GetById(id string) (*entity.Upload, error) {
+	var upload entity.Upload
+	if err := r.db.Where("id = ? AND expires_at > ?", id, time.Now()).First(&upload).Error; err != nil {
+		return nil, ErrUploadNotFound
+	}
+	return &upload, nil
+}
+
+// GetExpired returns the uploads that were not used in time.
+func (r *uploadRepository) GetExpired(now time.Time) ([]entity.Upload, error) {
+	var uploads []entity.Upload
+	if err := r.db.Where("expires_at <= ?", now).Order("expires_at").Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWishlistNotFound     = errors.New("wishlist not found")
+	ErrWishlistItemNotFound = errors.New("book is not in this wishlist")
+)
+
+type WishlistRepository interface {
+	Create(wishlist *entity.Wishlist) (*entity.Wishlist, error)
+	Update(wishlist *entity.Wishlist) (*entity.Wishlist, error)
+	Delete(id uint) error
+	GetAllByUser(userID uint) ([]entity.Wishlist, error)
+	GetById(id uint) (*entity.Wishlist, error)
+	GetByShareToken(token string) (*entity.Wishlist, error)
+	AddItem(item *entity.WishlistItem) error
+	RemoveItem(wishlistID uint, bookID uint) error
+}
+
+type wishlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWishlistRepository(db *gorm.DB) WishlistRepository {
+	return &wishlistRepository{db}
+}
+
+func (r *wishlistRepository) Create(wishlist *entity.Wishlist) (*entity.Wishlist, error) {
+	if err := r.db.Create(wishlist).Error; err != nil {
+		return nil, err
+	}
+	return r.GetById(wishlist.ID)
+}
+
+func (r *wishlistRepository) Update(wishlist *entity.Wishlist) (*entity.Wishlist, error) {
+	if err := r.db.Model(wishlist).Select("Name", "ShareToken").Updates(wishlist).Error; err != nil {
+		return nil, err
+	}
+	return r.GetById(wishlist.ID)
+}
+
+func (r *wishlistRepository) Delete(id uint) error {
+	if err := r.db.Delete(&entity.Wishlist{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *wishlistRepository) GetAllByUser(userID uint) ([]entity.Wishlist, error) {
+	var wishlists []entity.Wishlist
+	if err := r.preloadItems().Where("user_id = ?", userID).Find(&wishlists).Error; err != nil {
+		return nil, err
+	}
+	return wishlists, nil
+}
+
+func (r *wishlistRepository) GetById(id uint) (*entity.Wishlist, error) {
+	var wishlist entity.Wishlist
+	if err := r.preloadItems().First(&wishlist, id).Error; err != nil {
+		return nil, ErrWishlistNotFound
+	}
+	return &wishlist, nil
+}
+
+func (r *wishlistRepository) GetByShareToken(token string) (*entity.Wishlist, error) {
+	var wishlist entity.Wishlist
+	if err := r.preloadItems().Where("share_token = ?", token).First(&wishlist).Error; err != nil {
+		return nil, ErrWishlistNotFound
+	}
+	return &wishlist, nil
+}
+
+// AddItem saves the book to the wishlist. Adding a book that is already on the
+// list keeps the original entry and its price.
+func (r *wishlistRepository) AddItem(item *entity.WishlistItem) error {
+	var count int64
+	if err := r.db.Model(&entity.WishlistItem{}).Where("wishlist_id = ? AND book_id = ?", item.WishlistID, item.BookID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return r.db.Omit("Book").Create(item).Error
+}
+
+func (r *wishlistRepository) RemoveItem(wishlistID uint, bookID uint) error {
+	result := r.db.Where("wishlist_id = ? AND book_id = ?", wishlistID, bookID).Delete(&entity.WishlistItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWishlistItemNotFound
+	}
+	return nil
+}
+
+func (r *wishlistRepository) preloadItems() *gorm.DB {
+	return r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at DESC")
+	}).Preload("Items.Book").Preload("Items.Book.Images", orderBookImages)
+}
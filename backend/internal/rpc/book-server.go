@@ -0,0 +1,266 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	v2 "github.com/aws-cakap-intern/book-store/internal/dto/v2"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	pb "github.com/aws-cakap-intern/book-store/pkg/pb/bookstore/v1"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+	"github.com/aws-cakap-intern/book-store/pkg/validator"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type BookServer struct {
+	pb.UnimplementedBookServiceServer
+	bookService    service.BookService
+	uploadService  service.UploadService
+	imageValidator *upload.ImageValidator
+}
+
+func NewBookServer(bookService service.BookService, uploadService service.UploadService, imageValidator *upload.ImageValidator) *BookServer {
+	return &BookServer{bookService: bookService, uploadService: uploadService, imageValidator: imageValidator}
+}
+
+var (
+	bookSorts = map[pb.BookSort]string{
+		pb.BookSort_BOOK_SORT_RATING: "rating",
+	}
+	availabilityFilters = map[pb.AvailabilityFilter]string{
+		pb.AvailabilityFilter_AVAILABILITY_FILTER_AVAILABLE:   "available",
+		pb.AvailabilityFilter_AVAILABILITY_FILTER_COMING_SOON: "coming_soon",
+	}
+	availabilities = map[pb.Availability]string{
+		pb.Availability_AVAILABILITY_AVAILABLE: entity.BookAvailabilityAvailable,
+		pb.Availability_AVAILABILITY_PREORDER:  entity.BookAvailabilityPreorder,
+	}
+)
+
+func (s *BookServer) ListBooks(ctx context.Context, req *pb.ListBooksRequest) (*pb.ListBooksResponse, error) {
+	input := binder.GetBooks{
+		Sort:         bookSorts[req.GetSort()],
+		Availability: availabilityFilters[req.GetAvailability()],
+	}
+
+	books, execption := s.bookService.GetBooks(input)
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+
+	res := &pb.ListBooksResponse{Books: []*pb.Book{}}
+	for _, book := range books {
+		res.Books = append(res.Books, toBook(book))
+	}
+	return res, nil
+}
+
+func (s *BookServer) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.GetBookResponse, error) {
+	book, execption := s.bookService.GetBook(formatID(req.GetId()))
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+	return &pb.GetBookResponse{Book: toBook(book)}, nil
+}
+
+func (s *BookServer) CreateBook(ctx context.Context, req *pb.CreateBookRequest) (*pb.CreateBookResponse, error) {
+	in := req.GetBook()
+	input := binder.CreateBook{
+		Title:        in.GetTitle(),
+		Price:        int(in.GetPrice()),
+		Stock:        toIntPtr(in.Stock),
+		Availability: availabilities[in.GetAvailability()],
+		ReleaseDate:  in.GetReleaseDate(),
+		Description:  in.GetDescription(),
+		WeightGrams:  int(in.GetWeightGrams()),
+		LengthMm:     int(in.GetLengthMm()),
+		WidthMm:      int(in.GetWidthMm()),
+		HeightMm:     int(in.GetHeightMm()),
+		UploadID:     in.GetUploadId(),
+		Categories:   joinIDs(in.GetCategoryIds()),
+	}
+
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	image, err := s.readCover(in)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, invalidArgument(map[string]string{"cover": "cover is required"})
+	}
+	defer image.File.Close()
+
+	book, execption := s.bookService.CreateBook(input, toUints(in.GetCategoryIds()), image)
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+
+	if input.UploadID != "" {
+		s.uploadService.FinishUpload(input.UploadID)
+	}
+	return &pb.CreateBookResponse{Book: toBook(book)}, nil
+}
+
+func (s *BookServer) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.UpdateBookResponse, error) {
+	in := req.GetBook()
+	input := binder.UpdateBook{
+		ID:           formatID(req.GetId()),
+		Title:        in.GetTitle(),
+		Price:        int(in.GetPrice()),
+		Stock:        toIntPtr(in.Stock),
+		Availability: availabilities[in.GetAvailability()],
+		ReleaseDate:  in.GetReleaseDate(),
+		Description:  in.GetDescription(),
+		WeightGrams:  int(in.GetWeightGrams()),
+		LengthMm:     int(in.GetLengthMm()),
+		WidthMm:      int(in.GetWidthMm()),
+		HeightMm:     int(in.GetHeightMm()),
+		UploadID:     in.GetUploadId(),
+		Categories:   joinIDs(in.GetCategoryIds()),
+	}
+
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	image, err := s.readCover(in)
+	if err != nil {
+		return nil, err
+	}
+	if image != nil {
+		defer image.File.Close()
+	}
+
+	book, execption := s.bookService.UpdateBook(input, toUints(in.GetCategoryIds()), image)
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+
+	if input.UploadID != "" {
+		s.uploadService.FinishUpload(input.UploadID)
+	}
+	return &pb.UpdateBookResponse{Book: toBook(book)}, nil
+}
+
+func (s *BookServer) DeleteBook(ctx context.Context, req *pb.DeleteBookRequest) (*pb.DeleteBookResponse, error) {
+	if execption := s.bookService.DeleteBook(formatID(req.GetId())); execption != nil {
+		return nil, toStatus(execption)
+	}
+	return &pb.DeleteBookResponse{}, nil
+}
+
+// readCover returns the cover sent with a book, either as the image itself or
+// as the ID of a direct upload, or nil when there is neither. The caller
+// closes the image file.
+func (s *BookServer) readCover(in *pb.BookInput) (*upload.Image, error) {
+	switch cover := in.GetCover().(type) {
+	case *pb.BookInput_CoverImage:
+		image, err := s.imageValidator.ValidateReader(bytes.NewReader(cover.CoverImage))
+		if err != nil {
+			return nil, invalidArgument(map[string]string{"cover_image": err.Error()})
+		}
+		return image, nil
+	case *pb.BookInput_UploadId:
+		image, execption := s.uploadService.OpenUpload(cover.UploadId)
+		if execption != nil {
+			return nil, toStatus(execption)
+		}
+		return image, nil
+	}
+	return nil, nil
+}
+
+func toBook(book *dto.BookResponse) *pb.Book {
+	// The v2 response carries the timestamps as times rather than text
+	response := v2.NewBookResponse(book)
+
+	message := &pb.Book{
+		Id:            uint64(response.ID),
+		Title:         response.Title,
+		Price:         int64(response.Price),
+		ReleaseDate:   response.ReleaseDate,
+		Description:   response.Description,
+		Cover:         response.Cover,
+		WeightGrams:   int32(response.WeightGrams),
+		LengthMm:      int32(response.LengthMm),
+		WidthMm:       int32(response.WidthMm),
+		HeightMm:      int32(response.HeightMm),
+		RatingAverage: response.RatingAverage,
+		RatingCount:   int32(response.RatingCount),
+		CreatedAt:     timestamppb.New(response.CreatedAt),
+		UpdatedAt:     timestamppb.New(response.UpdatedAt),
+	}
+
+	if response.Stock != nil {
+		stock := int32(*response.Stock)
+		message.Stock = &stock
+	}
+
+	for value, availability := range availabilities {
+		if availability == response.Availability {
+			message.Availability = value
+		}
+	}
+
+	for _, image := range response.Images {
+		message.Images = append(message.Images, &pb.BookImage{
+			Id:        uint64(image.ID),
+			Alt:       image.Alt,
+			Position:  int32(image.Position),
+			IsPrimary: image.IsPrimary,
+			Urls:      image.URLs,
+		})
+	}
+
+	for _, category := range response.Categories {
+		message.Categories = append(message.Categories, &pb.BookCategory{Id: uint64(category.ID), Name: category.Name})
+	}
+
+	return message
+}
+
+// validate checks a binder with the rules of the REST API and reports the
+// invalid fields under their proto names.
+func validate(input interface{}) error {
+	validationErrors := validator.Validate(input)
+	if validationErrors == nil {
+		return nil
+	}
+
+	fields := map[string]string{}
+	for name, message := range validationErrors {
+		if name == "categories" {
+			name = "category_ids"
+		}
+		fields[name] = message
+	}
+	return invalidArgument(fields)
+}
+
+func formatID(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+func joinIDs(ids []uint64) string {
+	var parts []string
+	for _, id := range ids {
+		parts = append(parts, formatID(id))
+	}
+	return strings.Join(parts, ",")
+}
+
+func toUints(ids []uint64) []uint {
+	var result []uint
+	for _, id := range ids {
+		result = append(result, uint(id))
+	}
+	return result
+}
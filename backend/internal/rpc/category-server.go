@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	v2 "github.com/aws-cakap-intern/book-store/internal/dto/v2"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/service"
+	pb "github.com/aws-cakap-intern/book-store/pkg/pb/bookstore/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CategoryServer struct {
+	pb.UnimplementedCategoryServiceServer
+	categoryService service.CategoryService
+}
+
+func NewCategoryServer(categoryService service.CategoryService) *CategoryServer {
+	return &CategoryServer{categoryService: categoryService}
+}
+
+func (s *CategoryServer) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	categories, execption := s.categoryService.GetCategories()
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+
+	res := &pb.ListCategoriesResponse{Categories: []*pb.Category{}}
+	for _, category := range categories {
+		res.Categories = append(res.Categories, toCategory(category))
+	}
+	return res, nil
+}
+
+func (s *CategoryServer) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.GetCategoryResponse, error) {
+	category, execption := s.categoryService.GetCategory(formatID(req.GetId()))
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+	return &pb.GetCategoryResponse{Category: toCategory(category)}, nil
+}
+
+func (s *CategoryServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+	in := req.GetCategory()
+	input := binder.CreateCategory{
+		Name:              in.GetName(),
+		TaxRateID:         toUintPtr(in.TaxRateId),
+		LoyaltyMultiplier: toIntPtr(in.LoyaltyMultiplier),
+	}
+
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	category, execption := s.categoryService.CreateCategory(input)
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+	return &pb.CreateCategoryResponse{Category: toCategory(category)}, nil
+}
+
+func (s *CategoryServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.UpdateCategoryResponse, error) {
+	in := req.GetCategory()
+	input := binder.UpdateCategory{
+		ID:                formatID(req.GetId()),
+		Name:              in.GetName(),
+		TaxRateID:         toUintPtr(in.TaxRateId),
+		LoyaltyMultiplier: toIntPtr(in.LoyaltyMultiplier),
+	}
+
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	category, execption := s.categoryService.UpdateCategory(input)
+	if execption != nil {
+		return nil, toStatus(execption)
+	}
+	return &pb.UpdateCategoryResponse{Category: toCategory(category)}, nil
+}
+
+func (s *CategoryServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if execption := s.categoryService.DeleteCategory(formatID(req.GetId())); execption != nil {
+		return nil, toStatus(execption)
+	}
+	return &pb.DeleteCategoryResponse{}, nil
+}
+
+func toCategory(category *dto.CategoryResponse) *pb.Category {
+	response := v2.NewCategoryResponse(category)
+
+	message := &pb.Category{
+		Id:                uint64(response.ID),
+		Name:              response.Name,
+		LoyaltyMultiplier: int32(response.LoyaltyMultiplier),
+		CreatedAt:         timestamppb.New(response.CreatedAt),
+		UpdatedAt:         timestamppb.New(response.UpdatedAt),
+	}
+	if response.TaxRateID != nil {
+		taxRateID := uint64(*response.TaxRateID)
+		message.TaxRateId = &taxRateID
+	}
+	return message
+}
+
+func toUintPtr(id *uint64) *uint {
+	if id == nil {
+		return nil
+	}
+	value := uint(*id)
+	return &value
+}
+
+func toIntPtr(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+	value := int(*n)
+	return &value
+}
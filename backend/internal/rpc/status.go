@@ -0,0 +1,67 @@
+// Package rpc serves the book and category services over gRPC for the other
+// services of the store. The servers delegate to the same services as the
+// REST API; the messages in pkg/pb are generated from proto with
+// `buf generate`.
+package rpc
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusCodes maps the HTTP statuses the services fail with to the gRPC code
+// of the same meaning.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusPaymentRequired:     codes.FailedPrecondition,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusBadGateway:          codes.Unavailable,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// toStatus turns a service failure into a gRPC status error. Statuses with
+// no counterpart are Unknown.
+func toStatus(execption *execption.ApiExecption) error {
+	code, ok := statusCodes[execption.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+	return status.Error(code, execption.Message)
+}
+
+// invalidArgument reports invalid input with a BadRequest detail holding the
+// problem of each field, as the REST API does in the data of a 400 response.
+func invalidArgument(fields map[string]string) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	badRequest := &errdetails.BadRequest{}
+	for _, name := range names {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       name,
+			Description: fields[name],
+		})
+	}
+
+	st, err := status.New(codes.InvalidArgument, "validasi input gagal").WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "validasi input gagal")
+	}
+	return st.Err()
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+)
+
+type BookImageService interface {
+	GetBookImages(bookID string) ([]dto.BookImageResponse, *execption.ApiExecption)
+	CreateBookImage(input binder.CreateBookImage, image *upload.Image) ([]dto.BookImageResponse, *execption.ApiExecption)
+	UpdateBookImage(input binder.UpdateBookImage) ([]dto.BookImageResponse, *execption.ApiExecption)
+	ReorderBookImages(input binder.ReorderBookImages) ([]dto.BookImageResponse, *execption.ApiExecption)
+	DeleteBookImage(input binder.DeleteBookImage) *execption.ApiExecption
+}
+
+type bookImageService struct {
+	bookRepo         repository.BookRepository
+	bookImageRepo    repository.BookImageRepository
+	imageFileRepo    repository.ImageFileRepository
+	fileStorage      storage.Storage
+	variantGenerator *upload.VariantGenerator
+}
+
+func NewBookImageService(bookRepo repository.BookRepository, bookImageRepo repository.BookImageRepository, imageFileRepo repository.ImageFileRepository, fileStorage storage.Storage, variantGenerator *upload.VariantGenerator) BookImageService {
+	return &bookImageService{bookRepo: bookRepo, bookImageRepo: bookImageRepo, imageFileRepo: imageFileRepo, fileStorage: fileStorage, variantGenerator: variantGenerator}
+}
+
+// GetBookImages implements BookImageService.
+func (b *bookImageService) GetBookImages(bookID string) ([]dto.BookImageResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(bookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := b.bookRepo.GetById(uint(uintID)); err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return b.gallery(uint(uintID))
+}
+
+// CreateBookImage implements BookImageService. Images without alt text are
+// described by the book's title.
+func (b *bookImageService) CreateBookImage(input binder.CreateBookImage, image *upload.Image) ([]dto.BookImageResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(input.BookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	book, err := b.bookRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	alt := input.Alt
+	if alt == "" {
+		alt = book.Title
+	}
+
+	imagePath, imageVariants, err := saveImage(b.imageFileRepo, b.fileStorage, b.variantGenerator, image)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error saving image")
+	}
+
+	_, err = b.bookImageRepo.Create(&entity.BookImage{
+		BookID:    book.ID,
+		Path:      imagePath,
+		Variants:  imageVariants,
+		Alt:       alt,
+		IsPrimary: input.IsPrimary,
+	})
+	if err != nil {
+		releaseImage(b.imageFileRepo, b.fileStorage, imagePath, imageVariants)
+		if err == repository.ErrBookNotFound {
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return b.gallery(book.ID)
+}
+
+// UpdateBookImage implements BookImageService. A book always keeps one
+// primary image, so the flag moves by making another image primary.
+func (b *bookImageService) UpdateBookImage(input binder.UpdateBookImage) ([]dto.BookImageResponse, *execption.ApiExecption) {
+	image, apiErr := b.getImage(input.BookID, input.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if input.Alt != nil {
+		image.Alt = *input.Alt
+	}
+
+	if input.IsPrimary != nil {
+		if !*input.IsPrimary && image.IsPrimary {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "A book needs a primary image; make another image primary instead")
+		}
+		image.IsPrimary = *input.IsPrimary
+	}
+
+	if _, err := b.bookImageRepo.Update(image); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return b.gallery(image.BookID)
+}
+
+// ReorderBookImages implements BookImageService.
+func (b *bookImageService) ReorderBookImages(input binder.ReorderBookImages) ([]dto.BookImageResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(input.BookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if err := b.bookImageRepo.Reorder(uint(uintID), input.ImageIDs); err != nil {
+		switch err {
+		case repository.ErrBookNotFound:
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		case repository.ErrBookImageOrderMismatch:
+			return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return b.gallery(uint(uintID))
+}
+
+// DeleteBookImage implements BookImageService. The files are released once
+// the row is gone, so a failed delete keeps both.
+func (b *bookImageService) DeleteBookImage(input binder.DeleteBookImage) *execption.ApiExecption {
+	image, apiErr := b.getImage(input.BookID, input.ID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if err := b.bookImageRepo.Delete(image); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	releaseImage(b.imageFileRepo, b.fileStorage, image.Path, image.Variants)
+
+	return nil
+}
+
+func (b *bookImageService) getImage(bookID string, imageID string) (*entity.BookImage, *execption.ApiExecption) {
+	uintBookID, err := strconv.ParseUint(bookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	uintImageID, err := strconv.ParseUint(imageID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	image, err := b.bookImageRepo.GetById(uint(uintBookID), uint(uintImageID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return image, nil
+}
+
+func (b *bookImageService) gallery(bookID uint) ([]dto.BookImageResponse, *execption.ApiExecption) {
+	images, err := b.bookImageRepo.GetByBook(bookID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toBookImageResponses(images, b.fileStorage), nil
+}
+
+// saveImage stores a validated image with its variants next to it, and
+// returns the key of the original and the keys of the variants by name.
+// Widths the image is too small for point at the original. The extension
+// comes from the sniffed type, never from the uploaded file name.
+//
+// Files are named after the hash of the image, so an image that is already
+// stored is not stored again; it only gains a reference, which releaseImage
+// gives back. Files left by a failed save are overwritten by the next save
+// of the same image, or removed by the storage check.
+func saveImage(imageFileRepo repository.ImageFileRepository, fileStorage storage.Storage, variantGenerator *upload.VariantGenerator, image *upload.Image) (string, map[string]string, error) {
+	stored, err := imageFileRepo.Acquire(image.Hash)
+	if err == nil {
+		return stored.Path, stored.Variants, nil
+	}
+	if err != repository.ErrImageFileNotFound {
+		return "", nil, err
+	}
+
+	variants, err := variantGenerator.Generate(image)
+	if err != nil {
+		return "", nil, err
+	}
+
+	base := bookImagePrefix + image.Hash
+	key := base + image.Extension
+
+	if err := fileStorage.Put(key, image.File, image.Size, image.ContentType); err != nil {
+		return "", nil, err
+	}
+
+	keys := map[string]string{}
+	for _, width := range variantGenerator.Widths() {
+		keys[strconv.Itoa(width)] = key
+	}
+
+	for _, variant := range variants {
+		variantKey := fmt.Sprintf("%s_%d%s", base, variant.Width, variant.Extension)
+		if err := fileStorage.Put(variantKey, bytes.NewReader(variant.Content), int64(len(variant.Content)), variant.ContentType); err != nil {
+			return "", nil, err
+		}
+		keys[variant.Name] = variantKey
+	}
+
+	if err := imageFileRepo.Add(&entity.ImageFile{Hash: image.Hash, Path: key, Variants: keys}); err != nil {
+		return "", nil, err
+	}
+
+	return key, keys, nil
+}
+
+// releaseImage gives back a reference taken by saveImage, and deletes the
+// files when no image uses them anymore. Images stored before files were
+// shared have no record and are deleted right away.
+func releaseImage(imageFileRepo repository.ImageFileRepository, fileStorage storage.Storage, key string, variants map[string]string) {
+	released, err := imageFileRepo.Release(key)
+	switch {
+	case err == repository.ErrImageFileNotFound:
+		deleteImage(fileStorage, key, variants)
+	case err != nil:
+		log.Printf("failed to release image %s: %v", key, err)
+	case released != nil:
+		deleteImage(fileStorage, released.Path, released.Variants)
+	}
+}
+
+func releaseImages(imageFileRepo repository.ImageFileRepository, fileStorage storage.Storage, images []entity.BookImage) {
+	for _, image := range images {
+		releaseImage(imageFileRepo, fileStorage, image.Path, image.Variants)
+	}
+}
+
+// deleteFile removes a stored file. Failures are only logged, since the
+// change they belong to has already been saved.
+func deleteFile(fileStorage storage.Storage, key string) {
+	if key == "" {
+		return
+	}
+
+	if err := fileStorage.Delete(key); err != nil {
+		log.Printf("failed to delete file %s: %v", key, err)
+	}
+}
+
+// deleteImage removes an image and its variants.
+func deleteImage(fileStorage storage.Storage, key string, variants map[string]string) {
+	deleteFile(fileStorage, key)
+	for _, variantKey := range variants {
+		if variantKey != key {
+			deleteFile(fileStorage, variantKey)
+		}
+	}
+}
+
+// primaryImage returns the cover of a gallery, or nil when it is empty.
+func primaryImage(images []entity.BookImage) *entity.BookImage {
+	for i := range images {
+		if images[i].IsPrimary {
+			return &images[i]
+		}
+	}
+	if len(images) > 0 {
+		return &images[0]
+	}
+	return nil
+}
+
+// imageURLs returns the download URLs of an image by variant, with the
+// original under "original".
+func imageURLs(fileStorage storage.Storage, image *entity.BookImage) map[string]string {
+	urls := map[string]string{"original": fileStorage.URL(image.Path)}
+	for name, key := range image.Variants {
+		urls[name] = fileStorage.URL(key)
+	}
+	return urls
+}
+
+// coverURLs returns the URLs of the primary image, or an empty map for books
+// without images.
+func coverURLs(fileStorage storage.Storage, images []entity.BookImage) map[string]string {
+	primary := primaryImage(images)
+	if primary == nil {
+		return map[string]string{}
+	}
+	return imageURLs(fileStorage, primary)
+}
+
+func toBookImageResponses(images []entity.BookImage, fileStorage storage.Storage) []dto.BookImageResponse {
+	responses := []dto.BookImageResponse{}
+	for i := range images {
+		responses = append(responses, dto.BookImageResponse{
+			ID:        images[i].ID,
+			Alt:       images[i].Alt,
+			Position:  images[i].Position,
+			IsPrimary: images[i].IsPrimary,
+			URLs:      imageURLs(fileStorage, &images[i]),
+		})
+	}
+	return responses
+}
@@ -1,13 +1,8 @@
 package service
 
 import (
-	"fmt"
-	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/aws-cakap-intern/book-store/internal/dto"
@@ -15,28 +10,36 @@ import (
 	"github.com/aws-cakap-intern/book-store/internal/http/binder"
 	"github.com/aws-cakap-intern/book-store/internal/repository"
 	"github.com/aws-cakap-intern/book-store/pkg/execption"
-	"github.com/google/uuid"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
 )
 
 type BookService interface {
-	GetBooks() ([]*dto.BookResponse, *execption.ApiExecption)
+	GetBooks(input binder.GetBooks) ([]*dto.BookResponse, *execption.ApiExecption)
 	GetBook(bookID string) (*dto.BookResponse, *execption.ApiExecption)
-	CreateBook(input binder.CreateBook, categoryIDS []uint, file multipart.File, fileHeader *multipart.FileHeader) (*dto.BookResponse, *execption.ApiExecption)
-	UpdateBook(input binder.UpdateBook, categoryIDS []uint, file multipart.File, fileHeader *multipart.FileHeader) (*dto.BookResponse, *execption.ApiExecption)
+	CreateBook(input binder.CreateBook, categoryIDS []uint, image *upload.Image) (*dto.BookResponse, *execption.ApiExecption)
+	UpdateBook(input binder.UpdateBook, categoryIDS []uint, image *upload.Image) (*dto.BookResponse, *execption.ApiExecption)
 	DeleteBook(bookID string) *execption.ApiExecption
 }
 
 type bookService struct {
-	bookRepo     repository.BookRepository
-	categoryRepo repository.CategoryRepository
+	bookRepo          repository.BookRepository
+	bookImageRepo     repository.BookImageRepository
+	imageFileRepo     repository.ImageFileRepository
+	bookFileRepo      repository.BookFileRepository
+	categoryRepo      repository.CategoryRepository
+	similarityService SimilarityService
+	fileStorage       storage.Storage
+	privateStorage    storage.Storage
+	variantGenerator  *upload.VariantGenerator
 }
 
-func NewBookService(bookRepo repository.BookRepository, categoryRepo repository.CategoryRepository) BookService {
-	return &bookService{bookRepo: bookRepo, categoryRepo: categoryRepo}
+func NewBookService(bookRepo repository.BookRepository, bookImageRepo repository.BookImageRepository, imageFileRepo repository.ImageFileRepository, bookFileRepo repository.BookFileRepository, categoryRepo repository.CategoryRepository, similarityService SimilarityService, fileStorage storage.Storage, privateStorage storage.Storage, variantGenerator *upload.VariantGenerator) BookService {
+	return &bookService{bookRepo: bookRepo, bookImageRepo: bookImageRepo, imageFileRepo: imageFileRepo, bookFileRepo: bookFileRepo, categoryRepo: categoryRepo, similarityService: similarityService, fileStorage: fileStorage, privateStorage: privateStorage, variantGenerator: variantGenerator}
 }
 
 // CreateBook implements BookService.
-func (b *bookService) CreateBook(input binder.CreateBook, categoryIDS []uint, file multipart.File, fileHeader *multipart.FileHeader) (*dto.BookResponse, *execption.ApiExecption) {
+func (b *bookService) CreateBook(input binder.CreateBook, categoryIDS []uint, image *upload.Image) (*dto.BookResponse, *execption.ApiExecption) {
 	var categories []*entity.Category
 	err := b.categoryRepo.FindByIDs(categoryIDS, &categories)
 	if err != nil {
@@ -47,36 +50,65 @@ func (b *bookService) CreateBook(input binder.CreateBook, categoryIDS []uint, fi
 		return nil, execption.NewApiExecption(http.StatusBadRequest, "Some category IDs do not exist")
 	}
 
-	imagePath := ""
-	if file != nil && fileHeader != nil {
-		imagePath, err = b.saveFile(file, fileHeader)
+	availability, releaseDate, apiErr := parseAvailability(input.Availability, input.ReleaseDate)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// The uploaded image starts the gallery as its primary image
+	var images []entity.BookImage
+	if image != nil {
+		imagePath, imageVariants, err := saveImage(b.imageFileRepo, b.fileStorage, b.variantGenerator, image)
 		if err != nil {
 			return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error saving image")
 		}
+		images = append(images, entity.BookImage{Path: imagePath, Variants: imageVariants, Alt: input.Title, IsPrimary: true})
 	}
 
 	book := &entity.Book{
-		Title:       input.Title,
-		Price:       input.Price,
-		ImagePath:   imagePath,
-		Description: input.Description,
-		Categories:  convertCategories(categories),
+		Title:        input.Title,
+		Price:        input.Price,
+		Stock:        input.Stock,
+		Availability: availability,
+		ReleaseDate:  releaseDate,
+		Images:       images,
+		Description:  input.Description,
+		WeightGrams:  input.WeightGrams,
+		LengthMm:     input.LengthMm,
+		WidthMm:      input.WidthMm,
+		HeightMm:     input.HeightMm,
+		Categories:   convertCategories(categories),
 	}
 
 	book, err = b.bookRepo.Create(book, categoryIDS)
 	if err != nil {
+		releaseImages(b.imageFileRepo, b.fileStorage, images)
 		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
 	}
 
+	b.similarityService.IndexBook(book)
+
 	response := &dto.BookResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Price:       book.Price,
-		ImagePath:   book.ImagePath,
-		Description: book.Description,
-		Categories:  []dto.CategoryResponse{},
-		CreatedAt:   book.CreatedAt.String(),
-		UpdatedAt:   book.UpdatedAt.String(),
+		ID:            book.ID,
+		Title:         book.Title,
+		Price:         book.Price,
+		Stock:         book.Stock,
+		Availability:  book.Availability,
+		ReleaseDate:   formatReleaseDate(book.ReleaseDate),
+		Cover:         coverURLs(b.fileStorage, book.Images),
+		Images:        toBookImageResponses(book.Images, b.fileStorage),
+		Description:   book.Description,
+		WeightGrams:   book.WeightGrams,
+		LengthMm:      book.LengthMm,
+		WidthMm:       book.WidthMm,
+		HeightMm:      book.HeightMm,
+		RatingAverage: book.RatingAverage,
+		RatingCount:   book.RatingCount,
+		Categories:    []dto.CategoryResponse{},
+		CreatedAt:     book.CreatedAt.String(),
+		CreatedTime:   book.CreatedAt,
+		UpdatedAt:     book.UpdatedAt.String(),
+		UpdatedTime:   book.UpdatedAt,
 	}
 
 	for _, category := range categories {
@@ -107,29 +139,28 @@ func (b *bookService) DeleteBook(bookID string) *execption.ApiExecption {
 		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
 	}
 
-	// Delete the image file if it exists
-	if book.ImagePath != "" {
-		imagePath := "uploads/" + book.ImagePath // Ensure correct path
-		err := os.Remove(imagePath)
-		if err != nil && !os.IsNotExist(err) {
-			fmt.Println("Error deleting image file:", err)
-		}
-	}
-
-	// Remove category associations (if applicable)
-	if len(book.Categories) > 0 {
-		err = b.bookRepo.Delete(uint(uintID)) // Ensure this function exists in the repository
-		if err != nil {
-			return execption.NewApiExecption(http.StatusInternalServerError, "Failed to remove category associations")
-		}
+	files, err := b.bookFileRepo.GetByBook(book.ID)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
 	}
 
-	// Proceed with deletion
+	// Proceed with deletion. The category associations go with the book.
 	err = b.bookRepo.Delete(uint(uintID))
 	if err != nil {
+		if err == repository.ErrBookOrdered {
+			return execption.NewApiExecption(http.StatusConflict, err.Error())
+		}
 		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
 	}
 
+	b.similarityService.RemoveBook(uint(uintID))
+
+	// The files go once the book is gone, so a failed delete keeps both
+	releaseImages(b.imageFileRepo, b.fileStorage, book.Images)
+	for _, file := range files {
+		deleteFile(b.privateStorage, file.Path)
+	}
+
 	return nil
 }
 
@@ -157,22 +188,34 @@ func (b *bookService) GetBook(bookID string) (*dto.BookResponse, *execption.ApiE
 	}
 
 	response := &dto.BookResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Price:       book.Price,
-		ImagePath:   book.ImagePath,
-		Description: book.Description,
-		Categories:  categoryResponses,
-		CreatedAt:   book.CreatedAt.String(),
-		UpdatedAt:   book.UpdatedAt.String(),
+		ID:            book.ID,
+		Title:         book.Title,
+		Price:         book.Price,
+		Stock:         book.Stock,
+		Availability:  book.Availability,
+		ReleaseDate:   formatReleaseDate(book.ReleaseDate),
+		Cover:         coverURLs(b.fileStorage, book.Images),
+		Images:        toBookImageResponses(book.Images, b.fileStorage),
+		Description:   book.Description,
+		WeightGrams:   book.WeightGrams,
+		LengthMm:      book.LengthMm,
+		WidthMm:       book.WidthMm,
+		HeightMm:      book.HeightMm,
+		RatingAverage: book.RatingAverage,
+		RatingCount:   book.RatingCount,
+		Categories:    categoryResponses,
+		CreatedAt:     book.CreatedAt.String(),
+		CreatedTime:   book.CreatedAt,
+		UpdatedAt:     book.UpdatedAt.String(),
+		UpdatedTime:   book.UpdatedAt,
 	}
 
 	return response, nil
 }
 
 // GetBooks implements BookService.
-func (b *bookService) GetBooks() ([]*dto.BookResponse, *execption.ApiExecption) {
-	books, err := b.bookRepo.GetAll()
+func (b *bookService) GetBooks(input binder.GetBooks) ([]*dto.BookResponse, *execption.ApiExecption) {
+	books, err := b.bookRepo.GetAll(repository.BookQuery{Sort: input.Sort, Availability: input.Availability})
 	if err != nil {
 		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
 	}
@@ -194,14 +237,26 @@ func (b *bookService) GetBooks() ([]*dto.BookResponse, *execption.ApiExecption)
 		}
 
 		responses = append(responses, &dto.BookResponse{
-			ID:          book.ID,
-			Title:       book.Title,
-			Price:       book.Price,
-			ImagePath:   book.ImagePath,
-			Description: book.Description,
-			CreatedAt:   book.CreatedAt.String(),
-			UpdatedAt:   book.UpdatedAt.String(),
-			Categories:  categoryResponses, // Include categories in response
+			ID:            book.ID,
+			Title:         book.Title,
+			Price:         book.Price,
+			Stock:         book.Stock,
+			Availability:  book.Availability,
+			ReleaseDate:   formatReleaseDate(book.ReleaseDate),
+			Cover:         coverURLs(b.fileStorage, book.Images),
+			Images:        toBookImageResponses(book.Images, b.fileStorage),
+			Description:   book.Description,
+			WeightGrams:   book.WeightGrams,
+			LengthMm:      book.LengthMm,
+			WidthMm:       book.WidthMm,
+			HeightMm:      book.HeightMm,
+			RatingAverage: book.RatingAverage,
+			RatingCount:   book.RatingCount,
+			CreatedAt:     book.CreatedAt.String(),
+			CreatedTime:   book.CreatedAt,
+			UpdatedAt:     book.UpdatedAt.String(),
+			UpdatedTime:   book.UpdatedAt,
+			Categories:    categoryResponses, // Include categories in response
 		})
 	}
 
@@ -209,7 +264,7 @@ func (b *bookService) GetBooks() ([]*dto.BookResponse, *execption.ApiExecption)
 }
 
 // UpdateBook implements BookService.
-func (b *bookService) UpdateBook(input binder.UpdateBook, categoryIDS []uint, file multipart.File, fileHeader *multipart.FileHeader) (*dto.BookResponse, *execption.ApiExecption) {
+func (b *bookService) UpdateBook(input binder.UpdateBook, categoryIDS []uint, image *upload.Image) (*dto.BookResponse, *execption.ApiExecption) {
 	bookID, err := strconv.ParseUint(input.ID, 10, 0)
 	if err != nil {
 		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
@@ -226,43 +281,81 @@ func (b *bookService) UpdateBook(input binder.UpdateBook, categoryIDS []uint, fi
 		return nil, execption.NewApiExecption(http.StatusBadRequest, "Some category IDs do not exist")
 	}
 
+	availability, releaseDate, apiErr := parseAvailability(input.Availability, input.ReleaseDate)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
 	book, err := b.bookRepo.GetById(uint(bookID))
 	if err != nil {
 		return nil, execption.NewApiExecption(http.StatusNotFound, "Book not found")
 	}
 
-	// Handle image update (delete old file if a new one is provided)
-	if file != nil && fileHeader != nil {
-		// Delete old image file if it exists
-		if book.ImagePath != "" {
-			_ = os.Remove(book.ImagePath) // Ignore errors in deletion
-		}
-
-		// Save new file
-		imagePath, err := b.saveFile(file, fileHeader)
+	// An uploaded image replaces the primary image (the old files are released
+	// once the gallery points to the new ones)
+	var newImage, oldImage *entity.BookImage
+	if image != nil {
+		imagePath, imageVariants, err := saveImage(b.imageFileRepo, b.fileStorage, b.variantGenerator, image)
 		if err != nil {
 			return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error saving image")
 		}
-		book.ImagePath = imagePath
+
+		newImage = &entity.BookImage{BookID: book.ID, Path: imagePath, Variants: imageVariants, Alt: input.Title, IsPrimary: true}
+		if primary := primaryImage(book.Images); primary != nil {
+			old := *primary
+			oldImage = &old
+			newImage.ID, newImage.Alt = primary.ID, primary.Alt
+		}
 	}
 
 	updatedBook := &entity.Book{
-		ID:          uint(bookID),
-		Title:       input.Title,
-		Price:       input.Price,
-		ImagePath:   book.ImagePath,
-		Description: input.Description,
-		Categories:  convertCategories(categories), // Assign updated categories
+		ID:           uint(bookID),
+		Title:        input.Title,
+		Price:        input.Price,
+		Stock:        input.Stock,
+		Availability: availability,
+		ReleaseDate:  releaseDate,
+		Description:  input.Description,
+		WeightGrams:  input.WeightGrams,
+		LengthMm:     input.LengthMm,
+		WidthMm:      input.WidthMm,
+		HeightMm:     input.HeightMm,
+		Categories:   convertCategories(categories), // Assign updated categories
 	}
 
 	book, err = b.bookRepo.Update(updatedBook, categoryIDS)
 	if err != nil {
+		if newImage != nil {
+			releaseImage(b.imageFileRepo, b.fileStorage, newImage.Path, newImage.Variants)
+		}
 		if err == repository.ErrBookNotFound {
 			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
 		}
 		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
 	}
 
+	if newImage != nil {
+		if newImage.ID != 0 {
+			_, err = b.bookImageRepo.Update(newImage)
+		} else {
+			_, err = b.bookImageRepo.Create(newImage)
+		}
+		if err != nil {
+			releaseImage(b.imageFileRepo, b.fileStorage, newImage.Path, newImage.Variants)
+			return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error saving image")
+		}
+		if oldImage != nil {
+			releaseImage(b.imageFileRepo, b.fileStorage, oldImage.Path, oldImage.Variants)
+		}
+	}
+
+	book.Images, err = b.bookImageRepo.GetByBook(book.ID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	b.similarityService.IndexBook(book)
+
 	// Convert categories to response format
 	var categoryResponses []dto.CategoryResponse
 	for _, category := range categories {
@@ -273,14 +366,26 @@ func (b *bookService) UpdateBook(input binder.UpdateBook, categoryIDS []uint, fi
 	}
 
 	response := &dto.BookResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Price:       book.Price,
-		ImagePath:   book.ImagePath,
-		Description: book.Description,
-		CreatedAt:   book.CreatedAt.String(),
-		UpdatedAt:   book.UpdatedAt.String(),
-		Categories:  categoryResponses, // Include categories in response
+		ID:            book.ID,
+		Title:         book.Title,
+		Price:         book.Price,
+		Stock:         book.Stock,
+		Availability:  book.Availability,
+		ReleaseDate:   formatReleaseDate(book.ReleaseDate),
+		Cover:         coverURLs(b.fileStorage, book.Images),
+		Images:        toBookImageResponses(book.Images, b.fileStorage),
+		Description:   book.Description,
+		WeightGrams:   book.WeightGrams,
+		LengthMm:      book.LengthMm,
+		WidthMm:       book.WidthMm,
+		HeightMm:      book.HeightMm,
+		RatingAverage: book.RatingAverage,
+		RatingCount:   book.RatingCount,
+		CreatedAt:     book.CreatedAt.String(),
+		CreatedTime:   book.CreatedAt,
+		UpdatedAt:     book.UpdatedAt.String(),
+		UpdatedTime:   book.UpdatedAt,
+		Categories:    categoryResponses, // Include categories in response
 	}
 
 	return response, nil
@@ -294,31 +399,68 @@ func convertCategories(categories []*entity.Category) []entity.Category {
 	return result
 }
 
-func (b *bookService) saveFile(file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
-	dir := "uploads"
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return "", err
+func toBookResponse(book *entity.Book, fileStorage storage.Storage) dto.BookResponse {
+	response := dto.BookResponse{
+		ID:            book.ID,
+		Title:         book.Title,
+		Price:         book.Price,
+		Stock:         book.Stock,
+		Availability:  book.Availability,
+		ReleaseDate:   formatReleaseDate(book.ReleaseDate),
+		Cover:         coverURLs(fileStorage, book.Images),
+		Images:        toBookImageResponses(book.Images, fileStorage),
+		Description:   book.Description,
+		WeightGrams:   book.WeightGrams,
+		LengthMm:      book.LengthMm,
+		WidthMm:       book.WidthMm,
+		HeightMm:      book.HeightMm,
+		RatingAverage: book.RatingAverage,
+		RatingCount:   book.RatingCount,
+		Categories:    []dto.CategoryResponse{},
+		CreatedAt:     book.CreatedAt.String(),
+		CreatedTime:   book.CreatedAt,
+		UpdatedAt:     book.UpdatedAt.String(),
+		UpdatedTime:   book.UpdatedAt,
 	}
 
-	// Generate a unique file name using timestamp and UUID
-	uniqueName := fmt.Sprintf("%d_%s%s", time.Now().Unix(), uuid.New().String(), filepath.Ext(fileHeader.Filename))
-	filePath := filepath.Join(dir, uniqueName)
+	for _, category := range book.Categories {
+		response.Categories = append(response.Categories, dto.CategoryResponse{
+			ID:   category.ID,
+			Name: category.Name,
+		})
+	}
+
+	return response
+}
 
-	// Normalize to forward slashes for JSON compatibility
-	filePath = strings.ReplaceAll(filePath, "\\", "/")
+// parseAvailability checks the availability mode of a book. Pre-order books
+// need a release date in the future; the release job makes them available on
+// that day.
+func parseAvailability(availability string, releaseDate string) (string, *time.Time, *execption.ApiExecption) {
+	if availability == "" {
+		availability = entity.BookAvailabilityAvailable
+	}
 
-	// Create the new file
-	outFile, err := os.Create(filePath)
-	if err != nil {
-		return "", err
+	if releaseDate == "" {
+		return availability, nil, nil
 	}
-	defer outFile.Close()
 
-	// Copy file data
-	_, err = outFile.ReadFrom(file)
+	date, err := time.ParseInLocation("2006-01-02", releaseDate, time.Local)
 	if err != nil {
-		return "", err
+		return "", nil, execption.NewApiExecption(http.StatusBadRequest, "Release date must be in YYYY-MM-DD format")
 	}
 
-	return filePath, nil
+	if availability == entity.BookAvailabilityPreorder && !date.After(time.Now()) {
+		return "", nil, execption.NewApiExecption(http.StatusBadRequest, "Release date of a pre-order book must be in the future")
+	}
+
+	return availability, &date, nil
+}
+
+func formatReleaseDate(releaseDate *time.Time) *string {
+	if releaseDate == nil {
+		return nil
+	}
+	formatted := releaseDate.Format("2006-01-02")
+	return &formatted
 }
@@ -0,0 +1,459 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+)
+
+type CartService interface {
+	QuoteCart(userID uint, input binder.QuoteCart) (*dto.CartResponse, *execption.ApiExecption)
+}
+
+type cartService struct {
+	pricer *cartPricer
+}
+
+func NewCartService(bookRepo repository.BookRepository, couponRepo repository.CouponRepository, shippingRepo repository.ShippingRepository, giftCardRepo repository.GiftCardRepository, loyaltyRepo repository.LoyaltyRepository, cfg *config.Config) CartService {
+	return &cartService{pricer: newCartPricer(bookRepo, couponRepo, shippingRepo, giftCardRepo, loyaltyRepo, cfg)}
+}
+
+// QuoteCart implements CartService.
+func (c *cartService) QuoteCart(userID uint, input binder.QuoteCart) (*dto.CartResponse, *execption.ApiExecption) {
+	quote, apiErr := c.pricer.price(userID, input)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return quote.toResponse(), nil
+}
+
+type appliedCoupon struct {
+	Coupon *entity.Coupon
+	Amount int
+}
+
+type rejectedCoupon struct {
+	Code   string
+	Reason string
+}
+
+type appliedGiftCard struct {
+	GiftCard *entity.GiftCard
+	Amount   int
+}
+
+type rejectedGiftCard struct {
+	Code   string
+	Reason string
+}
+
+// cartQuote is the priced state of a cart, shared by cart quotes and checkout.
+type cartQuote struct {
+	Lines             []*cartLine
+	Applied           []appliedCoupon
+	Rejected          []rejectedCoupon
+	Subtotal          int
+	DiscountTotal     int
+	TaxTotal          int
+	ShippingTotal     int
+	PricesIncludeTax  bool
+	Total             int
+	PointsRedeemed    int
+	PointsDiscount    int
+	PointsEarned      int
+	ShippingOptions   []shippingOption
+	Shipping          *shippingOption
+	GiftCards         []appliedGiftCard
+	RejectedGiftCards []rejectedGiftCard
+	GiftCardTotal     int
+	AmountDue         int
+}
+
+type cartPricer struct {
+	bookRepo       repository.BookRepository
+	couponRepo     repository.CouponRepository
+	shippingRepo   repository.ShippingRepository
+	giftCardRepo   repository.GiftCardRepository
+	loyaltyRepo    repository.LoyaltyRepository
+	taxConfig      config.TaxConfig
+	shippingConfig config.ShippingConfig
+	loyaltyConfig  config.LoyaltyConfig
+}
+
+func newCartPricer(bookRepo repository.BookRepository, couponRepo repository.CouponRepository, shippingRepo repository.ShippingRepository, giftCardRepo repository.GiftCardRepository, loyaltyRepo repository.LoyaltyRepository, cfg *config.Config) *cartPricer {
+	return &cartPricer{
+		bookRepo:       bookRepo,
+		couponRepo:     couponRepo,
+		shippingRepo:   shippingRepo,
+		giftCardRepo:   giftCardRepo,
+		loyaltyRepo:    loyaltyRepo,
+		taxConfig:      cfg.Tax,
+		shippingConfig: cfg.Shipping,
+		loyaltyConfig:  cfg.Loyalty,
+	}
+}
+
+func (p *cartPricer) price(userID uint, input binder.QuoteCart) (*cartQuote, *execption.ApiExecption) {
+	lines, apiErr := p.buildLines(input.Items)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	quote := &cartQuote{Lines: lines, PricesIncludeTax: p.taxConfig.PricesIncludeTax}
+	for _, line := range lines {
+		quote.Subtotal += line.Subtotal
+	}
+
+	now := time.Now()
+	seen := map[string]bool{}
+	for _, rawCode := range input.CouponCodes {
+		code := strings.ToUpper(strings.TrimSpace(rawCode))
+		if code == "" {
+			continue
+		}
+
+		if seen[code] {
+			quote.Rejected = append(quote.Rejected, rejectedCoupon{Code: code, Reason: "coupon was already applied"})
+			continue
+		}
+		seen[code] = true
+
+		coupon, reason, apiErr := p.checkCoupon(userID, code, quote.Subtotal, now)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if reason != "" {
+			quote.Rejected = append(quote.Rejected, rejectedCoupon{Code: code, Reason: reason})
+			continue
+		}
+
+		amount := applyCoupon(coupon, lines)
+		if amount == 0 {
+			quote.Rejected = append(quote.Rejected, rejectedCoupon{Code: code, Reason: "no items in the cart are eligible for this coupon"})
+			continue
+		}
+
+		quote.Applied = append(quote.Applied, appliedCoupon{Coupon: coupon, Amount: amount})
+		quote.DiscountTotal += amount
+	}
+
+	// Tax is charged on what the customer pays, so it runs after discounts
+	quote.TaxTotal = applyTax(lines, p.taxConfig)
+	for _, line := range lines {
+		quote.Total += line.total(quote.PricesIncludeTax)
+	}
+
+	if apiErr := p.priceShipping(quote, input); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if apiErr := p.applyLoyaltyPoints(quote, userID, input.RedeemPoints); apiErr != nil {
+		return nil, apiErr
+	}
+
+	p.applyGiftCards(quote, input.GiftCardCodes, now)
+
+	quote.PointsEarned = loyaltyPointsEarned(quote, p.loyaltyConfig)
+
+	return quote, nil
+}
+
+// applyLoyaltyPoints takes redeemed points off the total. Points work like
+// store credit: they lower what the customer pays but not the tax already
+// charged on the items. No more points are used than it takes to cover the
+// total.
+func (p *cartPricer) applyLoyaltyPoints(quote *cartQuote, userID uint, points int) *execption.ApiExecption {
+	if points == 0 {
+		return nil
+	}
+
+	if p.loyaltyConfig.PointValue <= 0 {
+		return execption.NewApiExecption(http.StatusBadRequest, "Loyalty points cannot be redeemed")
+	}
+
+	balance, err := p.loyaltyRepo.GetBalance(userID)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+	if points > balance {
+		return execption.NewApiExecption(http.StatusBadRequest, "Not enough loyalty points, your balance is "+strconv.Itoa(balance))
+	}
+
+	if maxPoints := quote.Total / p.loyaltyConfig.PointValue; points > maxPoints {
+		points = maxPoints
+	}
+
+	quote.PointsRedeemed = points
+	quote.PointsDiscount = points * p.loyaltyConfig.PointValue
+	return nil
+}
+
+// loyaltyPointsEarned works out the points a paid order earns. Each item
+// earns on what was paid for it, scaled by the highest multiplier among its
+// book's categories, and the part of the total paid with points earns
+// nothing. Shipping does not earn points.
+func loyaltyPointsEarned(quote *cartQuote, cfg config.LoyaltyConfig) int {
+	if cfg.RupiahPerPoint <= 0 || quote.Total <= 0 {
+		return 0
+	}
+
+	var weighted int64
+	for _, line := range quote.Lines {
+		multiplier := 100
+		for i, category := range line.Book.Categories {
+			if i == 0 || category.LoyaltyMultiplier > multiplier {
+				multiplier = category.LoyaltyMultiplier
+			}
+		}
+		weighted += int64(line.total(quote.PricesIncludeTax)) * int64(multiplier)
+	}
+
+	paid := int64(quote.Total - quote.PointsDiscount)
+	return int(weighted * paid / (100 * int64(quote.Total) * int64(cfg.RupiahPerPoint)))
+}
+
+// applyGiftCards lets gift cards pay for the total in the order they were
+// entered. Whatever they do not cover is left as the amount due, to be paid
+// another way.
+func (p *cartPricer) applyGiftCards(quote *cartQuote, codes []string, now time.Time) {
+	quote.AmountDue = quote.Total - quote.PointsDiscount
+
+	seen := map[string]bool{}
+	for _, rawCode := range codes {
+		code := normalizeGiftCardCode(rawCode)
+		if code == "" {
+			continue
+		}
+
+		if seen[code] {
+			quote.RejectedGiftCards = append(quote.RejectedGiftCards, rejectedGiftCard{Code: code, Reason: "gift card was already applied"})
+			continue
+		}
+		seen[code] = true
+
+		giftCard, err := p.giftCardRepo.GetByCode(code)
+		if err != nil {
+			quote.RejectedGiftCards = append(quote.RejectedGiftCards, rejectedGiftCard{Code: code, Reason: "gift card code not found"})
+			continue
+		}
+
+		if reason := giftCardRejection(giftCard, now); reason != "" {
+			quote.RejectedGiftCards = append(quote.RejectedGiftCards, rejectedGiftCard{Code: code, Reason: reason})
+			continue
+		}
+
+		if quote.AmountDue == 0 {
+			quote.RejectedGiftCards = append(quote.RejectedGiftCards, rejectedGiftCard{Code: code, Reason: "the order is already fully paid"})
+			continue
+		}
+
+		amount := giftCard.Balance
+		if amount > quote.AmountDue {
+			amount = quote.AmountDue
+		}
+
+		quote.GiftCards = append(quote.GiftCards, appliedGiftCard{GiftCard: giftCard, Amount: amount})
+		quote.GiftCardTotal += amount
+		quote.AmountDue -= amount
+	}
+}
+
+// priceShipping lists the methods that can ship the cart to the address and
+// adds the chosen one to the total. The free shipping threshold is checked
+// against the total before shipping.
+func (p *cartPricer) priceShipping(quote *cartQuote, input binder.QuoteCart) *execption.ApiExecption {
+	if input.ShippingAddress == nil {
+		if input.ShippingMethodID != 0 {
+			return execption.NewApiExecption(http.StatusBadRequest, "Shipping address is required to choose a shipping method")
+		}
+		return nil
+	}
+
+	zones, err := p.shippingRepo.GetAllZones()
+	if err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, "Error retrieving shipping zones")
+	}
+
+	if zone := matchShippingZone(zones, input.ShippingAddress.Province, input.ShippingAddress.PostalCode); zone != nil {
+		freeShipping := p.shippingConfig.FreeThreshold > 0 && quote.Total >= p.shippingConfig.FreeThreshold
+		quote.ShippingOptions = shippingOptionsFor(zone, chargeableWeight(quote.Lines), freeShipping)
+	}
+
+	if input.ShippingMethodID == 0 {
+		return nil
+	}
+
+	for i := range quote.ShippingOptions {
+		if quote.ShippingOptions[i].Method.ID == input.ShippingMethodID {
+			quote.Shipping = &quote.ShippingOptions[i]
+			quote.ShippingTotal = quote.Shipping.Price
+			quote.Total += quote.ShippingTotal
+			return nil
+		}
+	}
+
+	return execption.NewApiExecption(http.StatusBadRequest, "Shipping method is not available for this address")
+}
+
+func (p *cartPricer) buildLines(items []binder.CartItem) ([]*cartLine, *execption.ApiExecption) {
+	// Merge repeated books into a single line
+	var bookIDs []uint
+	quantities := map[uint]int{}
+	for _, item := range items {
+		if _, exists := quantities[item.BookID]; !exists {
+			bookIDs = append(bookIDs, item.BookID)
+		}
+		quantities[item.BookID] += item.Quantity
+	}
+
+	var books []*entity.Book
+	if err := p.bookRepo.FindByIDs(bookIDs, &books); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error retrieving books")
+	}
+
+	if len(books) != len(bookIDs) {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Some book IDs do not exist")
+	}
+
+	booksByID := map[uint]*entity.Book{}
+	for _, book := range books {
+		booksByID[book.ID] = book
+	}
+
+	var lines []*cartLine
+	for _, bookID := range bookIDs {
+		book := booksByID[bookID]
+		lines = append(lines, &cartLine{
+			Book:      book,
+			Quantity:  quantities[bookID],
+			UnitPrice: book.Price,
+			Subtotal:  book.Price * quantities[bookID],
+		})
+	}
+
+	return lines, nil
+}
+
+func (p *cartPricer) checkCoupon(userID uint, code string, subtotal int, now time.Time) (*entity.Coupon, string, *execption.ApiExecption) {
+	coupon, err := p.couponRepo.GetByCode(code)
+	if err != nil {
+		return nil, "coupon code not found", nil
+	}
+
+	if reason := couponRejection(coupon, subtotal, now); reason != "" {
+		return nil, reason, nil
+	}
+
+	if coupon.UsageLimit > 0 {
+		count, err := p.couponRepo.CountRedemptions(coupon.ID)
+		if err != nil {
+			return nil, "", execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+		}
+		if count >= int64(coupon.UsageLimit) {
+			return nil, "coupon usage limit has been reached", nil
+		}
+	}
+
+	if coupon.PerUserLimit > 0 {
+		count, err := p.couponRepo.CountUserRedemptions(coupon.ID, userID)
+		if err != nil {
+			return nil, "", execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+		}
+		if count >= int64(coupon.PerUserLimit) {
+			return nil, "you have already used this coupon the maximum number of times", nil
+		}
+	}
+
+	return coupon, "", nil
+}
+
+func (q *cartQuote) toResponse() *dto.CartResponse {
+	response := &dto.CartResponse{
+		Items:             []dto.CartItemResponse{},
+		AppliedCoupons:    []dto.AppliedCouponResponse{},
+		RejectedCoupons:   []dto.RejectedCouponResponse{},
+		Subtotal:          q.Subtotal,
+		DiscountTotal:     q.DiscountTotal,
+		TaxTotal:          q.TaxTotal,
+		ShippingTotal:     q.ShippingTotal,
+		PricesIncludeTax:  q.PricesIncludeTax,
+		Total:             q.Total,
+		PointsRedeemed:    q.PointsRedeemed,
+		PointsDiscount:    q.PointsDiscount,
+		PointsEarned:      q.PointsEarned,
+		ShippingMethods:   []dto.ShippingOptionResponse{},
+		AppliedGiftCards:  []dto.AppliedGiftCardResponse{},
+		RejectedGiftCards: []dto.RejectedGiftCardResponse{},
+		GiftCardTotal:     q.GiftCardTotal,
+		AmountDue:         q.AmountDue,
+	}
+
+	for _, option := range q.ShippingOptions {
+		response.ShippingMethods = append(response.ShippingMethods, dto.ShippingOptionResponse{
+			ID:           option.Method.ID,
+			Name:         option.Method.Name,
+			Price:        option.Price,
+			FreeShipping: option.FreeShipping,
+		})
+	}
+
+	if q.Shipping != nil {
+		response.ShippingMethodID = &q.Shipping.Method.ID
+	}
+
+	for _, line := range q.Lines {
+		response.Items = append(response.Items, dto.CartItemResponse{
+			BookID:    line.Book.ID,
+			Title:     line.Book.Title,
+			UnitPrice: line.UnitPrice,
+			Quantity:  line.Quantity,
+			Subtotal:  line.Subtotal,
+			Discount:  line.Discount,
+			TaxRate:   line.TaxRate,
+			Tax:       line.Tax,
+			Total:     line.total(q.PricesIncludeTax),
+		})
+	}
+
+	for _, applied := range q.Applied {
+		response.AppliedCoupons = append(response.AppliedCoupons, dto.AppliedCouponResponse{
+			Code:        applied.Coupon.Code,
+			Description: applied.Coupon.Description,
+			Type:        applied.Coupon.Type,
+			Amount:      applied.Amount,
+		})
+	}
+
+	for _, rejected := range q.Rejected {
+		response.RejectedCoupons = append(response.RejectedCoupons, dto.RejectedCouponResponse{
+			Code:   rejected.Code,
+			Reason: rejected.Reason,
+		})
+	}
+
+	for _, applied := range q.GiftCards {
+		response.AppliedGiftCards = append(response.AppliedGiftCards, dto.AppliedGiftCardResponse{
+			Code:             maskGiftCardCode(applied.GiftCard.Code),
+			Amount:           applied.Amount,
+			RemainingBalance: applied.GiftCard.Balance - applied.Amount,
+		})
+	}
+
+	for _, rejected := range q.RejectedGiftCards {
+		response.RejectedGiftCards = append(response.RejectedGiftCards, dto.RejectedGiftCardResponse{
+			Code:   maskGiftCardCode(rejected.Code),
+			Reason: rejected.Reason,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+// CatalogService reads books and categories in pages, and their relations in
+// batches, for clients that choose which relations they need.
+type CatalogService interface {
+	SearchBooks(search repository.BookSearch) (*dto.BookPageResponse, *execption.ApiExecption)
+	SearchCategories(search repository.CategorySearch) (*dto.CategoryPageResponse, *execption.ApiExecption)
+	GetBooksByIDs(bookIDs []uint) (map[uint]*dto.BookResponse, *execption.ApiExecption)
+	GetCategoriesOfBooks(bookIDs []uint) (map[uint][]*dto.CategoryResponse, *execption.ApiExecption)
+	GetBookIDsOfCategories(categoryIDs []uint) (map[uint][]uint, *execption.ApiExecption)
+}
+
+type catalogService struct {
+	bookRepo         repository.BookRepository
+	categoryRepo     repository.CategoryRepository
+	bookCategoryRepo repository.BookCategoryRepository
+	fileStorage      storage.Storage
+}
+
+func NewCatalogService(bookRepo repository.BookRepository, categoryRepo repository.CategoryRepository, bookCategoryRepo repository.BookCategoryRepository, fileStorage storage.Storage) CatalogService {
+	return &catalogService{bookRepo: bookRepo, categoryRepo: categoryRepo, bookCategoryRepo: bookCategoryRepo, fileStorage: fileStorage}
+}
+
+// SearchBooks implements CatalogService. The books come without their
+// categories, which GetCategoriesOfBooks loads for a whole page at once.
+func (s *catalogService) SearchBooks(search repository.BookSearch) (*dto.BookPageResponse, *execption.ApiExecption) {
+	books, total, err := s.bookRepo.Search(search)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	page := &dto.BookPageResponse{Books: []*dto.BookResponse{}, Total: total}
+	for i := range books {
+		response := toBookResponse(&books[i], s.fileStorage)
+		page.Books = append(page.Books, &response)
+	}
+	return page, nil
+}
+
+// SearchCategories implements CatalogService.
+func (s *catalogService) SearchCategories(search repository.CategorySearch) (*dto.CategoryPageResponse, *execption.ApiExecption) {
+	categories, total, err := s.categoryRepo.Search(search)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	page := &dto.CategoryPageResponse{Categories: []*dto.CategoryResponse{}, Total: total}
+	for i := range categories {
+		page.Categories = append(page.Categories, toCategoryResponse(&categories[i]))
+	}
+	return page, nil
+}
+
+// GetBooksByIDs implements CatalogService. Books that do not exist are left
+// out of the map.
+func (s *catalogService) GetBooksByIDs(bookIDs []uint) (map[uint]*dto.BookResponse, *execption.ApiExecption) {
+	var books []*entity.Book
+	if err := s.bookRepo.FindByIDs(bookIDs, &books); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := make(map[uint]*dto.BookResponse, len(books))
+	for _, book := range books {
+		response := toBookResponse(book, s.fileStorage)
+		responses[book.ID] = &response
+	}
+	return responses, nil
+}
+
+// GetCategoriesOfBooks implements CatalogService.
+func (s *catalogService) GetCategoriesOfBooks(bookIDs []uint) (map[uint][]*dto.CategoryResponse, *execption.ApiExecption) {
+	links, err := s.bookCategoryRepo.FindByBookIDs(bookIDs)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	var categoryIDs []uint
+	for _, link := range links {
+		categoryIDs = append(categoryIDs, link.CategoryID)
+	}
+
+	result := make(map[uint][]*dto.CategoryResponse, len(bookIDs))
+	if len(categoryIDs) == 0 {
+		return result, nil
+	}
+
+	var categories []*entity.Category
+	if err := s.categoryRepo.FindByIDs(categoryIDs, &categories); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := make(map[uint]*dto.CategoryResponse, len(categories))
+	for _, category := range categories {
+		responses[category.ID] = toCategoryResponse(category)
+	}
+
+	for _, link := range links {
+		if response, ok := responses[link.CategoryID]; ok {
+			result[link.BookID] = append(result[link.BookID], response)
+		}
+	}
+	return result, nil
+}
+
+// GetBookIDsOfCategories implements CatalogService. The newest books of a
+// category come first.
+func (s *catalogService) GetBookIDsOfCategories(categoryIDs []uint) (map[uint][]uint, *execption.ApiExecption) {
+	links, err := s.bookCategoryRepo.FindByCategoryIDs(categoryIDs)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	result := make(map[uint][]uint, len(categoryIDs))
+	for _, link := range links {
+		result[link.CategoryID] = append(result[link.CategoryID], link.BookID)
+	}
+	return result, nil
+}
+
+func toCategoryResponse(category *entity.Category) *dto.CategoryResponse {
+	return &dto.CategoryResponse{
+		ID:                category.ID,
+		Name:              category.Name,
+		TaxRateID:         category.TaxRateID,
+		LoyaltyMultiplier: category.LoyaltyMultiplier,
+		CreatedAt:         category.CreatedAt.String(),
+		CreatedTime:       category.CreatedAt,
+		UpdatedAt:         category.UpdatedAt.String(),
+		UpdatedTime:       category.UpdatedAt,
+	}
+}
@@ -21,10 +21,11 @@ type CategoryService interface {
 
 type categoryService struct {
 	categoryRepo repository.CategoryRepository
+	taxRateRepo  repository.TaxRateRepository
 }
 
-func NewCategoryService(categoryRepo repository.CategoryRepository) CategoryService {
-	return &categoryService{categoryRepo: categoryRepo}
+func NewCategoryService(categoryRepo repository.CategoryRepository, taxRateRepo repository.TaxRateRepository) CategoryService {
+	return &categoryService{categoryRepo: categoryRepo, taxRateRepo: taxRateRepo}
 }
 
 func (s *categoryService) GetCategories() ([]*dto.CategoryResponse, *execption.ApiExecption)  {
@@ -42,10 +43,14 @@ func (s *categoryService) GetCategories() ([]*dto.CategoryResponse, *execption.A
 
 	for _, category := range categories {
 		responses = append(responses, &dto.CategoryResponse{
-			ID:        category.ID,
-			Name:      category.Name,
-			CreatedAt: category.CreatedAt.String(),
-			UpdatedAt: category.UpdatedAt.String(),
+			ID:                category.ID,
+			Name:              category.Name,
+			TaxRateID:         category.TaxRateID,
+			LoyaltyMultiplier: category.LoyaltyMultiplier,
+			CreatedAt:         category.CreatedAt.String(),
+			CreatedTime:       category.CreatedAt,
+			UpdatedAt:         category.UpdatedAt.String(),
+			UpdatedTime:       category.UpdatedAt,
 		})
 	}
 
@@ -66,18 +71,28 @@ func (s *categoryService) GetCategory(categoryID string) (*dto.CategoryResponse,
 	}
 
 	response := &dto.CategoryResponse{
-		ID:        category.ID,	
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt.String(),
-		UpdatedAt: category.UpdatedAt.String(),
+		ID:                category.ID,	
+		Name:              category.Name,
+		TaxRateID:         category.TaxRateID,
+		LoyaltyMultiplier: category.LoyaltyMultiplier,
+		CreatedAt:         category.CreatedAt.String(),
+		CreatedTime:       category.CreatedAt,
+		UpdatedAt:         category.UpdatedAt.String(),
+		UpdatedTime:       category.UpdatedAt,
 	}
 
 	return response, nil
 }
 
 func (s *categoryService) CreateCategory(input binder.CreateCategory) (*dto.CategoryResponse, *execption.ApiExecption)  {
+	if apiErr := s.checkTaxRate(input.TaxRateID); apiErr != nil {
+		return nil, apiErr
+	}
+
 	category := &entity.Category{
-		Name: input.Name,
+		Name:              input.Name,
+		TaxRateID:         input.TaxRateID,
+		LoyaltyMultiplier: loyaltyMultiplier(input.LoyaltyMultiplier),
 	}
 
 	category, err := s.categoryRepo.Create(category)
@@ -87,10 +102,14 @@ func (s *categoryService) CreateCategory(input binder.CreateCategory) (*dto.Cate
 	}
 
 	response := &dto.CategoryResponse{
-		ID:        category.ID,	
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt.String(),
-		UpdatedAt: category.UpdatedAt.String(),
+		ID:                category.ID,	
+		Name:              category.Name,
+		TaxRateID:         category.TaxRateID,
+		LoyaltyMultiplier: category.LoyaltyMultiplier,
+		CreatedAt:         category.CreatedAt.String(),
+		CreatedTime:       category.CreatedAt,
+		UpdatedAt:         category.UpdatedAt.String(),
+		UpdatedTime:       category.UpdatedAt,
 	}
 
 	return response, nil
@@ -103,9 +122,15 @@ func (s *categoryService) UpdateCategory(input binder.UpdateCategory) (*dto.Cate
 		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
 	}
 
+	if apiErr := s.checkTaxRate(input.TaxRateID); apiErr != nil {
+		return nil, apiErr
+	}
+
 	category := &entity.Category{
-		ID:   uint(categoryID),
-		Name: input.Name,
+		ID:                uint(categoryID),
+		Name:              input.Name,
+		TaxRateID:         input.TaxRateID,
+		LoyaltyMultiplier: loyaltyMultiplier(input.LoyaltyMultiplier),
 	}
 
 	category, err = s.categoryRepo.Update(category)
@@ -118,10 +143,14 @@ func (s *categoryService) UpdateCategory(input binder.UpdateCategory) (*dto.Cate
 	}
 
 	response := &dto.CategoryResponse{
-		ID:        category.ID,	
-		Name:      category.Name,
-		CreatedAt: category.CreatedAt.String(),
-		UpdatedAt: category.UpdatedAt.String(),
+		ID:                category.ID,	
+		Name:              category.Name,
+		TaxRateID:         category.TaxRateID,
+		LoyaltyMultiplier: category.LoyaltyMultiplier,
+		CreatedAt:         category.CreatedAt.String(),
+		CreatedTime:       category.CreatedAt,
+		UpdatedAt:         category.UpdatedAt.String(),
+		UpdatedTime:       category.UpdatedAt,
 	}
 
 	return response, nil
@@ -144,4 +173,26 @@ func (s *categoryService) DeleteCategory(categoryID string)  *execption.ApiExecp
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *categoryService) checkTaxRate(taxRateID *uint) *execption.ApiExecption {
+	if taxRateID == nil {
+		return nil
+	}
+
+	if _, err := s.taxRateRepo.GetById(*taxRateID); err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, "Tax rate does not exist")
+	}
+
+	return nil
+}
+
+// loyaltyMultiplier returns the given multiplier, or 100 (normal points) when
+// none was given.
+func loyaltyMultiplier(multiplier *int) int {
+	if multiplier == nil {
+		return 100
+	}
+
+	return *multiplier
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+// cartLine is a priced line of a cart. Discounts are allocated to the lines
+// they were earned on so later steps can work on the discounted amount.
+type cartLine struct {
+	Book      *entity.Book
+	Quantity  int
+	UnitPrice int
+	Subtotal  int
+	Discount  int
+	TaxRate   int
+	Tax       int
+}
+
+func (l *cartLine) remaining() int {
+	return l.Subtotal - l.Discount
+}
+
+// total is what the customer pays for the line. Inclusive prices already
+// contain the tax.
+func (l *cartLine) total(pricesIncludeTax bool) int {
+	if pricesIncludeTax {
+		return l.remaining()
+	}
+	return l.remaining() + l.Tax
+}
+
+// couponRejection returns why the coupon cannot be used on a cart with the
+// given subtotal, or an empty string when it can.
+func couponRejection(coupon *entity.Coupon, subtotal int, now time.Time) string {
+	if !coupon.IsActive {
+		return "coupon is not active"
+	}
+	if coupon.StartsAt != nil && now.Before(*coupon.StartsAt) {
+		return "coupon is not valid yet"
+	}
+	if coupon.EndsAt != nil && now.After(*coupon.EndsAt) {
+		return "coupon has expired"
+	}
+	if subtotal < coupon.MinSubtotal {
+		return fmt.Sprintf("order subtotal must be at least %d to use this coupon", coupon.MinSubtotal)
+	}
+	return ""
+}
+
+// applyCoupon allocates the coupon's discount to the eligible lines and returns
+// the total amount discounted.
+func applyCoupon(coupon *entity.Coupon, lines []*cartLine) int {
+	var eligible []*cartLine
+	for _, line := range lines {
+		if couponTargets(coupon, line.Book) && line.remaining() > 0 {
+			eligible = append(eligible, line)
+		}
+	}
+
+	if len(eligible) == 0 {
+		return 0
+	}
+
+	switch coupon.Type {
+	case entity.CouponTypePercentage:
+		return applyPercentage(coupon.Value, eligible)
+	case entity.CouponTypeFixedAmount:
+		return applyFixedAmount(coupon.Value, eligible)
+	case entity.CouponTypeBuyXGetY:
+		return applyBuyXGetY(coupon.BuyQuantity, coupon.GetQuantity, eligible)
+	}
+
+	return 0
+}
+
+func couponTargets(coupon *entity.Coupon, book *entity.Book) bool {
+	if len(coupon.Books) == 0 && len(coupon.Categories) == 0 {
+		return true
+	}
+
+	for _, target := range coupon.Books {
+		if target.ID == book.ID {
+			return true
+		}
+	}
+
+	for _, target := range coupon.Categories {
+		for _, category := range book.Categories {
+			if target.ID == category.ID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func applyPercentage(percent int, lines []*cartLine) int {
+	total := 0
+	for _, line := range lines {
+		discount := line.remaining() * percent / 100
+		line.Discount += discount
+		total += discount
+	}
+	return total
+}
+
+// applyFixedAmount spreads the amount over the lines in proportion to their
+// remaining value. Rounding leftovers go to the first lines that still have room.
+func applyFixedAmount(amount int, lines []*cartLine) int {
+	eligibleTotal := 0
+	for _, line := range lines {
+		eligibleTotal += line.remaining()
+	}
+
+	if amount > eligibleTotal {
+		amount = eligibleTotal
+	}
+
+	allocated := 0
+	shares := make([]int, len(lines))
+	for i, line := range lines {
+		shares[i] = amount * line.remaining() / eligibleTotal
+		allocated += shares[i]
+	}
+
+	for i := 0; allocated < amount; i = (i + 1) % len(lines) {
+		if shares[i] < lines[i].remaining() {
+			shares[i]++
+			allocated++
+		}
+	}
+
+	for i, line := range lines {
+		line.Discount += shares[i]
+	}
+
+	return amount
+}
+
+// applyBuyXGetY makes the cheapest units free: for every buy+get units in the
+// eligible lines, get units are discounted.
+func applyBuyXGetY(buy int, get int, lines []*cartLine) int {
+	if buy <= 0 || get <= 0 {
+		return 0
+	}
+
+	units := 0
+	for _, line := range lines {
+		units += line.Quantity
+	}
+
+	free := units / (buy + get) * get
+	if free == 0 {
+		return 0
+	}
+
+	sorted := make([]*cartLine, len(lines))
+	copy(sorted, lines)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].UnitPrice < sorted[j].UnitPrice
+	})
+
+	total := 0
+	for _, line := range sorted {
+		if free == 0 {
+			break
+		}
+
+		quantity := line.Quantity
+		if quantity > free {
+			quantity = free
+		}
+		free -= quantity
+
+		discount := quantity * line.UnitPrice
+		if discount > line.remaining() {
+			discount = line.remaining()
+		}
+		line.Discount += discount
+		total += discount
+	}
+
+	return total
+}
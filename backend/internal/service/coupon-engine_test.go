@@ -0,0 +1,147 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+func TestCouponRejection(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		coupon   entity.Coupon
+		subtotal int
+		want     string
+	}{
+		{"usable", entity.Coupon{IsActive: true, StartsAt: &before, EndsAt: &after, MinSubtotal: 10000}, 10000, ""},
+		{"inactive", entity.Coupon{IsActive: false}, 10000, "coupon is not active"},
+		{"not started", entity.Coupon{IsActive: true, StartsAt: &after}, 10000, "coupon is not valid yet"},
+		{"ended", entity.Coupon{IsActive: true, EndsAt: &before}, 10000, "coupon has expired"},
+		{"below minimum", entity.Coupon{IsActive: true, MinSubtotal: 10000}, 9999, "order subtotal must be at least 10000 to use this coupon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := couponRejection(&tt.coupon, tt.subtotal, now); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCoupon(t *testing.T) {
+	fiction := entity.Category{ID: 1}
+	novel := &entity.Book{ID: 1, Categories: []entity.Category{fiction}}
+	atlas := &entity.Book{ID: 2}
+	poems := &entity.Book{ID: 3}
+
+	tests := []struct {
+		name      string
+		coupon    entity.Coupon
+		lines     []*cartLine
+		want      int
+		discounts []int
+	}{
+		{
+			name:      "percentage rounds each line down",
+			coupon:    entity.Coupon{Type: entity.CouponTypePercentage, Value: 10},
+			lines:     []*cartLine{newCartLine(novel, 1, 15000), newCartLine(atlas, 1, 9999)},
+			want:      2499,
+			discounts: []int{1500, 999},
+		},
+		{
+			name:      "percentage on the remaining amount",
+			coupon:    entity.Coupon{Type: entity.CouponTypePercentage, Value: 50},
+			lines:     []*cartLine{{Book: novel, Quantity: 1, UnitPrice: 1000, Subtotal: 1000, Discount: 400}},
+			want:      300,
+			discounts: []int{700},
+		},
+		{
+			name:      "fixed amount in proportion",
+			coupon:    entity.Coupon{Type: entity.CouponTypeFixedAmount, Value: 1000},
+			lines:     []*cartLine{newCartLine(novel, 1, 3000), newCartLine(atlas, 1, 1000)},
+			want:      1000,
+			discounts: []int{750, 250},
+		},
+		{
+			name:      "fixed amount leftovers go to the first lines",
+			coupon:    entity.Coupon{Type: entity.CouponTypeFixedAmount, Value: 100},
+			lines:     []*cartLine{newCartLine(novel, 1, 100), newCartLine(atlas, 1, 100), newCartLine(poems, 1, 100)},
+			want:      100,
+			discounts: []int{34, 33, 33},
+		},
+		{
+			name:      "fixed amount capped at the cart",
+			coupon:    entity.Coupon{Type: entity.CouponTypeFixedAmount, Value: 5000},
+			lines:     []*cartLine{newCartLine(novel, 2, 1000)},
+			want:      2000,
+			discounts: []int{2000},
+		},
+		{
+			name:      "buy two get one makes the cheapest unit free",
+			coupon:    entity.Coupon{Type: entity.CouponTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1},
+			lines:     []*cartLine{newCartLine(novel, 2, 5000), newCartLine(atlas, 1, 3000)},
+			want:      3000,
+			discounts: []int{0, 3000},
+		},
+		{
+			name:      "buy two get one needs three units",
+			coupon:    entity.Coupon{Type: entity.CouponTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1},
+			lines:     []*cartLine{newCartLine(novel, 2, 5000)},
+			want:      0,
+			discounts: []int{0},
+		},
+		{
+			name:      "buy one get one over several lines",
+			coupon:    entity.Coupon{Type: entity.CouponTypeBuyXGetY, BuyQuantity: 1, GetQuantity: 1},
+			lines:     []*cartLine{newCartLine(novel, 3, 5000), newCartLine(atlas, 1, 3000)},
+			want:      8000,
+			discounts: []int{5000, 3000},
+		},
+		{
+			name:      "only targeted books",
+			coupon:    entity.Coupon{Type: entity.CouponTypePercentage, Value: 10, Books: []entity.Book{*atlas}},
+			lines:     []*cartLine{newCartLine(novel, 1, 1000), newCartLine(atlas, 1, 1000)},
+			want:      100,
+			discounts: []int{0, 100},
+		},
+		{
+			name:      "only targeted categories",
+			coupon:    entity.Coupon{Type: entity.CouponTypePercentage, Value: 10, Categories: []entity.Category{fiction}},
+			lines:     []*cartLine{newCartLine(novel, 1, 1000), newCartLine(atlas, 1, 1000)},
+			want:      100,
+			discounts: []int{100, 0},
+		},
+		{
+			name:      "no targeted books in the cart",
+			coupon:    entity.Coupon{Type: entity.CouponTypeFixedAmount, Value: 500, Books: []entity.Book{*poems}},
+			lines:     []*cartLine{newCartLine(novel, 1, 1000)},
+			want:      0,
+			discounts: []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyCoupon(&tt.coupon, tt.lines); got != tt.want {
+				t.Errorf("discounted %d, want %d", got, tt.want)
+			}
+
+			discounts := make([]int, len(tt.lines))
+			for i, line := range tt.lines {
+				discounts[i] = line.Discount
+			}
+			if !reflect.DeepEqual(discounts, tt.discounts) {
+				t.Errorf("line discounts %v, want %v", discounts, tt.discounts)
+			}
+		})
+	}
+}
+
+func newCartLine(book *entity.Book, quantity int, unitPrice int) *cartLine {
+	return &cartLine{Book: book, Quantity: quantity, UnitPrice: unitPrice, Subtotal: quantity * unitPrice}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+)
+
+type CouponService interface {
+	GetCoupons() ([]*dto.CouponResponse, *execption.ApiExecption)
+	GetCoupon(couponID string) (*dto.CouponResponse, *execption.ApiExecption)
+	CreateCoupon(input binder.CreateCoupon) (*dto.CouponResponse, *execption.ApiExecption)
+	UpdateCoupon(input binder.UpdateCoupon) (*dto.CouponResponse, *execption.ApiExecption)
+	DeleteCoupon(couponID string) *execption.ApiExecption
+}
+
+type couponService struct {
+	couponRepo repository.CouponRepository
+}
+
+func NewCouponService(couponRepo repository.CouponRepository) CouponService {
+	return &couponService{couponRepo: couponRepo}
+}
+
+func (s *couponService) GetCoupons() ([]*dto.CouponResponse, *execption.ApiExecption) {
+	coupons, err := s.couponRepo.GetAll()
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.CouponResponse{}
+	for i := range coupons {
+		responses = append(responses, toCouponResponse(&coupons[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *couponService) GetCoupon(couponID string) (*dto.CouponResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(couponID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	coupon, err := s.couponRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return toCouponResponse(coupon), nil
+}
+
+func (s *couponService) CreateCoupon(input binder.CreateCoupon) (*dto.CouponResponse, *execption.ApiExecption) {
+	coupon := &entity.Coupon{
+		Code:         strings.ToUpper(strings.TrimSpace(input.Code)),
+		Description:  input.Description,
+		Type:         input.Type,
+		Value:        input.Value,
+		MinSubtotal:  input.MinSubtotal,
+		BuyQuantity:  input.BuyQuantity,
+		GetQuantity:  input.GetQuantity,
+		UsageLimit:   input.UsageLimit,
+		PerUserLimit: input.PerUserLimit,
+		StartsAt:     input.StartsAt,
+		EndsAt:       input.EndsAt,
+		IsActive:     input.IsActive == nil || *input.IsActive,
+	}
+
+	if message := validateCouponRule(coupon); message != "" {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, message)
+	}
+
+	if _, err := s.couponRepo.GetByCode(coupon.Code); err == nil {
+		return nil, execption.NewApiExecption(http.StatusConflict, "Coupon code already exists")
+	}
+
+	coupon, err := s.couponRepo.Create(coupon, input.CategoryIDs, input.BookIDs)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toCouponResponse(coupon), nil
+}
+
+func (s *couponService) UpdateCoupon(input binder.UpdateCoupon) (*dto.CouponResponse, *execption.ApiExecption) {
+	couponID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	coupon := &entity.Coupon{
+		ID:           uint(couponID),
+		Code:         strings.ToUpper(strings.TrimSpace(input.Code)),
+		Description:  input.Description,
+		Type:         input.Type,
+		Value:        input.Value,
+		MinSubtotal:  input.MinSubtotal,
+		BuyQuantity:  input.BuyQuantity,
+		GetQuantity:  input.GetQuantity,
+		UsageLimit:   input.UsageLimit,
+		PerUserLimit: input.PerUserLimit,
+		StartsAt:     input.StartsAt,
+		EndsAt:       input.EndsAt,
+		IsActive:     input.IsActive == nil || *input.IsActive,
+	}
+
+	if message := validateCouponRule(coupon); message != "" {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, message)
+	}
+
+	if existing, err := s.couponRepo.GetByCode(coupon.Code); err == nil && existing.ID != coupon.ID {
+		return nil, execption.NewApiExecption(http.StatusConflict, "Coupon code already exists")
+	}
+
+	coupon, err = s.couponRepo.Update(coupon, input.CategoryIDs, input.BookIDs)
+	if err != nil {
+		if err == repository.ErrCouponNotFound {
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toCouponResponse(coupon), nil
+}
+
+func (s *couponService) DeleteCoupon(couponID string) *execption.ApiExecption {
+	uintID, err := strconv.ParseUint(couponID, 10, 0)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := s.couponRepo.GetById(uint(uintID)); err != nil {
+		return execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	if err := s.couponRepo.Delete(uint(uintID)); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+func validateCouponRule(coupon *entity.Coupon) string {
+	switch coupon.Type {
+	case entity.CouponTypePercentage:
+		if coupon.Value < 1 || coupon.Value > 100 {
+			return "Percentage coupons need a value between 1 and 100"
+		}
+	case entity.CouponTypeFixedAmount:
+		if coupon.Value < 1 {
+			return "Fixed amount coupons need a value greater than 0"
+		}
+	case entity.CouponTypeBuyXGetY:
+		if coupon.BuyQuantity < 1 || coupon.GetQuantity < 1 {
+			return "Buy X get Y coupons need buy_quantity and get_quantity of at least 1"
+		}
+	}
+
+	if coupon.StartsAt != nil && coupon.EndsAt != nil && coupon.EndsAt.Before(*coupon.StartsAt) {
+		return "ends_at must be after starts_at"
+	}
+
+	return ""
+}
+
+func toCouponResponse(coupon *entity.Coupon) *dto.CouponResponse {
+	response := &dto.CouponResponse{
+		ID:           coupon.ID,
+		Code:         coupon.Code,
+		Description:  coupon.Description,
+		Type:         coupon.Type,
+		Value:        coupon.Value,
+		MinSubtotal:  coupon.MinSubtotal,
+		BuyQuantity:  coupon.BuyQuantity,
+		GetQuantity:  coupon.GetQuantity,
+		UsageLimit:   coupon.UsageLimit,
+		PerUserLimit: coupon.PerUserLimit,
+		IsActive:     coupon.IsActive,
+		CategoryIDs:  []uint{},
+		BookIDs:      []uint{},
+		CreatedAt:    coupon.CreatedAt.String(),
+		UpdatedAt:    coupon.UpdatedAt.String(),
+	}
+
+	if coupon.StartsAt != nil {
+		response.StartsAt = coupon.StartsAt.String()
+	}
+	if coupon.EndsAt != nil {
+		response.EndsAt = coupon.EndsAt.String()
+	}
+
+	for _, category := range coupon.Categories {
+		response.CategoryIDs = append(response.CategoryIDs, category.ID)
+	}
+	for _, book := range coupon.Books {
+		response.BookIDs = append(response.BookIDs, book.ID)
+	}
+
+	return response
+}
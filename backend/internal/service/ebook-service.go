@@ -0,0 +1,391 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/ebookmeta"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/signer"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+	"github.com/google/uuid"
+)
+
+type EbookService interface {
+	GetBookFiles(bookID string) ([]dto.BookFileResponse, *execption.ApiExecption)
+	CreateBookFile(input binder.CreateBookFile, ebook *upload.Ebook) ([]dto.BookFileResponse, *execption.ApiExecption)
+	DeleteBookFile(input binder.DeleteBookFile) *execption.ApiExecption
+	CreateDownload(userID uint, input binder.CreateEbookDownload) (*dto.EbookDownloadResponse, *execption.ApiExecption)
+	Download(input binder.DownloadEbook, ipAddress string, userAgent string) (*dto.FileStreamResponse, *execption.ApiExecption)
+	ExtractBook(ebook *upload.Ebook) (*dto.BookExtractResponse, *execption.ApiExecption)
+}
+
+type ebookService struct {
+	bookRepo       repository.BookRepository
+	bookFileRepo   repository.BookFileRepository
+	downloadRepo   repository.EbookDownloadRepository
+	orderRepo      repository.OrderRepository
+	privateStorage storage.Storage
+	uploadService  UploadService
+	imageValidator *upload.ImageValidator
+	urlSigner      *signer.Signer
+	config         config.EbookConfig
+}
+
+func NewEbookService(bookRepo repository.BookRepository, bookFileRepo repository.BookFileRepository, downloadRepo repository.EbookDownloadRepository, orderRepo repository.OrderRepository, privateStorage storage.Storage, uploadService UploadService, imageValidator *upload.ImageValidator, urlSigner *signer.Signer, cfg *config.Config) EbookService {
+	return &ebookService{bookRepo: bookRepo, bookFileRepo: bookFileRepo, downloadRepo: downloadRepo, orderRepo: orderRepo, privateStorage: privateStorage, uploadService: uploadService, imageValidator: imageValidator, urlSigner: urlSigner, config: cfg.Ebook}
+}
+
+// GetBookFiles implements EbookService.
+func (e *ebookService) GetBookFiles(bookID string) ([]dto.BookFileResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(bookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := e.bookRepo.GetById(uint(uintID)); err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return e.bookFiles(uint(uintID))
+}
+
+// CreateBookFile implements EbookService. Buyers download the file under the
+// book's title, whatever it was called when uploaded.
+func (e *ebookService) CreateBookFile(input binder.CreateBookFile, ebook *upload.Ebook) ([]dto.BookFileResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(input.BookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	book, err := e.bookRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	key := fmt.Sprintf("ebooks/%d/%s%s", book.ID, uuid.New().String(), ebook.Extension)
+	if err := e.privateStorage.Put(key, ebook.File, ebook.Size, ebook.ContentType); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error saving file")
+	}
+
+	_, err = e.bookFileRepo.Create(&entity.BookFile{
+		BookID:      book.ID,
+		Format:      ebook.Format,
+		Path:        key,
+		FileName:    downloadName(book.Title) + ebook.Extension,
+		ContentType: ebook.ContentType,
+		Size:        ebook.Size,
+	})
+	if err != nil {
+		deleteFile(e.privateStorage, key)
+		switch err {
+		case repository.ErrBookNotFound:
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		case repository.ErrBookFileAlreadyExists:
+			return nil, execption.NewApiExecption(http.StatusConflict, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return e.bookFiles(book.ID)
+}
+
+// DeleteBookFile implements EbookService. Links already handed out for the
+// file stop working.
+func (e *ebookService) DeleteBookFile(input binder.DeleteBookFile) *execption.ApiExecption {
+	uintBookID, err := strconv.ParseUint(input.BookID, 10, 0)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	uintFileID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	file, err := e.bookFileRepo.GetById(uint(uintBookID), uint(uintFileID))
+	if err != nil {
+		return execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	if err := e.bookFileRepo.Delete(file); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	deleteFile(e.privateStorage, file.Path)
+
+	return nil
+}
+
+// CreateDownload implements EbookService. It hands the buyer of an order
+// item a short-lived link to each file of the book. Only downloads count
+// against the limit, so asking for links again is free.
+func (e *ebookService) CreateDownload(userID uint, input binder.CreateEbookDownload) (*dto.EbookDownloadResponse, *execption.ApiExecption) {
+	uintOrderID, err := strconv.ParseUint(input.OrderID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	uintItemID, err := strconv.ParseUint(input.ItemID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	order, item, apiErr := e.purchase(uint(uintOrderID), uint(uintItemID))
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if order.UserID != userID {
+		return nil, execption.NewApiExecption(http.StatusNotFound, repository.ErrOrderNotFound.Error())
+	}
+	if !orderIsPaid(order) {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Ebooks can only be downloaded from paid orders")
+	}
+
+	files, err := e.bookFileRepo.GetByBook(item.BookID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+	if len(files) == 0 {
+		return nil, execption.NewApiExecption(http.StatusNotFound, "This book has no ebook files")
+	}
+
+	used, err := e.downloadRepo.CountByOrderItem(item.ID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+	if used >= e.config.DownloadLimit {
+		return nil, execption.NewApiExecption(http.StatusForbidden, repository.ErrDownloadLimitReached.Error())
+	}
+
+	expires := time.Now().Add(e.config.LinkExpiry)
+
+	links := []dto.EbookLinkResponse{}
+	for _, file := range files {
+		query := e.urlSigner.Query(ebookResource(order.ID, item.ID, file.ID), expires)
+		query.Set("order", strconv.FormatUint(uint64(order.ID), 10))
+		query.Set("item", strconv.FormatUint(uint64(item.ID), 10))
+
+		links = append(links, dto.EbookLinkResponse{
+			Format:    file.Format,
+			FileName:  file.FileName,
+			Size:      file.Size,
+			URL:       fmt.Sprintf("%s/%d?%s", strings.TrimRight(e.config.DownloadURL, "/"), file.ID, query.Encode()),
+			ExpiresAt: expires.String(),
+		})
+	}
+
+	return &dto.EbookDownloadResponse{
+		OrderItemID:   item.ID,
+		DownloadLimit: e.config.DownloadLimit,
+		DownloadsUsed: used,
+		DownloadsLeft: e.config.DownloadLimit - used,
+		Links:         links,
+	}, nil
+}
+
+// Download implements EbookService. The signed link stands in for the
+// buyer's login, so the file can be fetched by a browser or an e-reader. The
+// order is checked again, since it may have been cancelled since the link
+// was made.
+func (e *ebookService) Download(input binder.DownloadEbook, ipAddress string, userAgent string) (*dto.FileStreamResponse, *execption.ApiExecption) {
+	uintFileID, err1 := strconv.ParseUint(input.FileID, 10, 0)
+	uintOrderID, err2 := strconv.ParseUint(input.OrderID, 10, 0)
+	uintItemID, err3 := strconv.ParseUint(input.ItemID, 10, 0)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil, execption.NewApiExecption(http.StatusForbidden, "Invalid download link")
+	}
+
+	if err := e.urlSigner.Verify(ebookResource(uint(uintOrderID), uint(uintItemID), uint(uintFileID)), input.Expires, input.Signature); err != nil {
+		if err == signer.ErrExpired {
+			return nil, execption.NewApiExecption(http.StatusForbidden, "Download link has expired")
+		}
+		return nil, execption.NewApiExecption(http.StatusForbidden, "Invalid download link")
+	}
+
+	order, item, apiErr := e.purchase(uint(uintOrderID), uint(uintItemID))
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !orderIsPaid(order) {
+		return nil, execption.NewApiExecption(http.StatusForbidden, "Ebooks can only be downloaded from paid orders")
+	}
+
+	file, err := e.bookFileRepo.GetById(item.BookID, uint(uintFileID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	// The file is opened before the download is counted, so a storage error
+	// does not use up a download
+	content, err := e.privateStorage.Get(file.Path)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error reading file")
+	}
+
+	_, err = e.downloadRepo.Record(&entity.EbookDownload{
+		OrderItemID: item.ID,
+		BookFileID:  file.ID,
+		UserID:      order.UserID,
+		Format:      file.Format,
+		IPAddress:   ipAddress,
+		UserAgent:   truncate(userAgent, 255),
+	}, e.config.DownloadLimit)
+	if err != nil {
+		content.Close()
+		if err == repository.ErrDownloadLimitReached {
+			return nil, execption.NewApiExecption(http.StatusForbidden, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return &dto.FileStreamResponse{
+		Name:        file.FileName,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		Content:     content,
+	}, nil
+}
+
+// ExtractBook implements EbookService. It drafts a book from the metadata of
+// an ebook file. A cover found in the file is checked like any uploaded image
+// and kept as an upload, so the draft can be saved with it; covers that fail
+// the checks are left out with a warning.
+func (e *ebookService) ExtractBook(ebook *upload.Ebook) (*dto.BookExtractResponse, *execption.ApiExecption) {
+	var metadata *ebookmeta.Metadata
+	var err error
+	switch ebook.Format {
+	case upload.EbookFormatEPUB:
+		metadata, err = ebookmeta.ExtractEPUB(ebook.File, ebook.Size, e.imageValidator.MaxBytes())
+	default:
+		metadata, err = ebookmeta.ExtractPDF(ebook.File, ebook.Size)
+	}
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	extracted := &dto.BookExtractResponse{
+		Book: binder.CreateBook{
+			Title:        metadata.Title,
+			Availability: entity.BookAvailabilityAvailable,
+			Description:  metadata.Description,
+		},
+		Metadata: dto.BookMetadataResponse{
+			Format:      ebook.Format,
+			Title:       metadata.Title,
+			Authors:     metadata.Authors,
+			Description: metadata.Description,
+			Language:    metadata.Language,
+			ISBN:        metadata.ISBN,
+			Publisher:   metadata.Publisher,
+		},
+		Warnings: []string{},
+	}
+
+	if metadata.Title == "" {
+		extracted.Warnings = append(extracted.Warnings, "the file has no title")
+	}
+
+	if len(metadata.Cover) == 0 {
+		extracted.Warnings = append(extracted.Warnings, "no cover image was found in the file")
+		return extracted, nil
+	}
+
+	cover, err := e.imageValidator.ValidateReader(bytes.NewReader(metadata.Cover))
+	if err != nil {
+		extracted.Warnings = append(extracted.Warnings, "the cover image was left out: "+err.Error())
+		return extracted, nil
+	}
+
+	preview, apiErr := e.uploadService.StoreUpload(cover)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	extracted.Cover = preview
+	extracted.Book.UploadID = preview.UploadID
+
+	return extracted, nil
+}
+
+// purchase looks up an order item.
+func (e *ebookService) purchase(orderID uint, itemID uint) (*entity.Order, *entity.OrderItem, *execption.ApiExecption) {
+	order, err := e.orderRepo.GetById(orderID)
+	if err != nil {
+		return nil, nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	var item *entity.OrderItem
+	for i := range order.Items {
+		if order.Items[i].ID == itemID {
+			item = &order.Items[i]
+		}
+	}
+	if item == nil {
+		return nil, nil, execption.NewApiExecption(http.StatusNotFound, "Order item not found")
+	}
+
+	return order, item, nil
+}
+
+// orderIsPaid reports whether an order has been paid for and not cancelled.
+func orderIsPaid(order *entity.Order) bool {
+	switch order.Status {
+	case entity.OrderStatusPaid, entity.OrderStatusShipped, entity.OrderStatusDelivered:
+		return true
+	}
+	return false
+}
+
+func (e *ebookService) bookFiles(bookID uint) ([]dto.BookFileResponse, *execption.ApiExecption) {
+	files, err := e.bookFileRepo.GetByBook(bookID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []dto.BookFileResponse{}
+	for _, file := range files {
+		responses = append(responses, dto.BookFileResponse{
+			ID:          file.ID,
+			Format:      file.Format,
+			FileName:    file.FileName,
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			CreatedAt:   file.CreatedAt.String(),
+		})
+	}
+	return responses, nil
+}
+
+// ebookResource names a book file bought with an order item in signed links.
+func ebookResource(orderID uint, itemID uint, fileID uint) string {
+	return fmt.Sprintf("ebook:%d:%d:%d", orderID, itemID, fileID)
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// downloadName turns a book title into a file name without an extension.
+func downloadName(title string) string {
+	name := strings.Trim(unsafeNameChars.ReplaceAllString(title, "-"), "-")
+	if name == "" {
+		return "ebook"
+	}
+	return truncate(name, 200)
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"crypto/rand"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/payment"
+)
+
+// giftCardAlphabet leaves out characters that are easy to misread.
+const giftCardAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+type GiftCardService interface {
+	GetGiftCards() ([]*dto.GiftCardResponse, *execption.ApiExecption)
+	GetGiftCard(giftCardID string) (*dto.GiftCardResponse, *execption.ApiExecption)
+	CheckBalance(input binder.CheckGiftCardBalance) (*dto.GiftCardBalanceResponse, *execption.ApiExecption)
+	IssueGiftCard(input binder.IssueGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption)
+	PurchaseGiftCard(userID uint, input binder.PurchaseGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption)
+	AdjustGiftCard(input binder.AdjustGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption)
+	VoidGiftCard(input binder.VoidGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption)
+}
+
+type giftCardService struct {
+	giftCardRepo    repository.GiftCardRepository
+	paymentProvider payment.Provider
+}
+
+func NewGiftCardService(giftCardRepo repository.GiftCardRepository, paymentProvider payment.Provider) GiftCardService {
+	return &giftCardService{giftCardRepo: giftCardRepo, paymentProvider: paymentProvider}
+}
+
+// GetGiftCards implements GiftCardService. The codes are masked, so the full
+// code is only shown for one card at a time.
+func (g *giftCardService) GetGiftCards() ([]*dto.GiftCardResponse, *execption.ApiExecption) {
+	giftCards, err := g.giftCardRepo.GetAll()
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.GiftCardResponse{}
+	for i := range giftCards {
+		response := toGiftCardResponse(&giftCards[i])
+		response.Code = maskGiftCardCode(response.Code)
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// GetGiftCard implements GiftCardService.
+func (g *giftCardService) GetGiftCard(giftCardID string) (*dto.GiftCardResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(giftCardID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	giftCard, err := g.giftCardRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return toGiftCardResponse(giftCard), nil
+}
+
+// CheckBalance implements GiftCardService. Only the masked code is returned.
+func (g *giftCardService) CheckBalance(input binder.CheckGiftCardBalance) (*dto.GiftCardBalanceResponse, *execption.ApiExecption) {
+	giftCard, err := g.giftCardRepo.GetByCode(normalizeGiftCardCode(input.Code))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	status := giftCard.Status
+	if status == entity.GiftCardStatusActive && giftCard.ExpiresAt != nil && !giftCard.ExpiresAt.After(time.Now()) {
+		status = "expired"
+	}
+
+	return &dto.GiftCardBalanceResponse{
+		Code:      maskGiftCardCode(giftCard.Code),
+		Balance:   giftCard.Balance,
+		Status:    status,
+		ExpiresAt: formatOptionalTime(giftCard.ExpiresAt),
+	}, nil
+}
+
+// IssueGiftCard implements GiftCardService.
+func (g *giftCardService) IssueGiftCard(input binder.IssueGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption) {
+	var expiresAt *time.Time
+	if input.ExpiresAt != "" {
+		date, err := time.ParseInLocation("2006-01-02", input.ExpiresAt, time.Local)
+		if err != nil {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "Expiry date must be in YYYY-MM-DD format")
+		}
+		if !date.After(time.Now()) {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "Expiry date must be in the future")
+		}
+		expiresAt = &date
+	}
+
+	note := input.Note
+	if note == "" {
+		note = "Issued by staff"
+	}
+
+	return g.issue(input.InitialBalance, nil, expiresAt, note)
+}
+
+// PurchaseGiftCard implements GiftCardService. The card is only issued once
+// the payment has been captured.
+func (g *giftCardService) PurchaseGiftCard(userID uint, input binder.PurchaseGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption) {
+	authorization, err := g.paymentProvider.Authorize(payment.AuthorizeRequest{Amount: input.Amount})
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusPaymentRequired, err.Error())
+	}
+
+	capture, err := g.paymentProvider.Capture(payment.CaptureRequest{AuthorizationReference: authorization.Reference, Amount: input.Amount})
+	if err != nil {
+		_ = g.paymentProvider.Void(authorization.Reference)
+		return nil, execption.NewApiExecption(http.StatusPaymentRequired, err.Error())
+	}
+
+	return g.issue(input.Amount, &userID, nil, "Purchased, payment "+capture.Reference)
+}
+
+// AdjustGiftCard implements GiftCardService.
+func (g *giftCardService) AdjustGiftCard(input binder.AdjustGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	giftCard, err := g.giftCardRepo.Adjust(uint(uintID), input.Amount, input.Note)
+	if err != nil {
+		return nil, giftCardError(err)
+	}
+
+	return toGiftCardResponse(giftCard), nil
+}
+
+// VoidGiftCard implements GiftCardService.
+func (g *giftCardService) VoidGiftCard(input binder.VoidGiftCard) (*dto.GiftCardResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	note := input.Note
+	if note == "" {
+		note = "Voided by staff"
+	}
+
+	giftCard, err := g.giftCardRepo.Void(uint(uintID), note)
+	if err != nil {
+		return nil, giftCardError(err)
+	}
+
+	return toGiftCardResponse(giftCard), nil
+}
+
+func (g *giftCardService) issue(amount int, purchasedByUserID *uint, expiresAt *time.Time, note string) (*dto.GiftCardResponse, *execption.ApiExecption) {
+	code, err := generateGiftCardCode()
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	giftCard, err := g.giftCardRepo.Create(&entity.GiftCard{
+		Code:              code,
+		InitialBalance:    amount,
+		Balance:           amount,
+		Status:            entity.GiftCardStatusActive,
+		PurchasedByUserID: purchasedByUserID,
+		ExpiresAt:         expiresAt,
+	}, note)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toGiftCardResponse(giftCard), nil
+}
+
+func giftCardError(err error) *execption.ApiExecption {
+	switch err {
+	case repository.ErrGiftCardNotFound:
+		return execption.NewApiExecption(http.StatusNotFound, err.Error())
+	case repository.ErrGiftCardNotActive, repository.ErrGiftCardInsufficientBalance:
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+	return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+}
+
+// giftCardRejection returns why the card cannot pay for an order, or an empty
+// string when it can.
+func giftCardRejection(giftCard *entity.GiftCard, now time.Time) string {
+	if giftCard.Status != entity.GiftCardStatusActive {
+		return "gift card is not active"
+	}
+	if giftCard.ExpiresAt != nil && !giftCard.ExpiresAt.After(now) {
+		return "gift card has expired"
+	}
+	if giftCard.Balance <= 0 {
+		return "gift card has no balance left"
+	}
+	return ""
+}
+
+// generateGiftCardCode returns a random code like ABCD-EFGH-JKLM-NPQR.
+func generateGiftCardCode() (string, error) {
+	var code strings.Builder
+	max := big.NewInt(int64(len(giftCardAlphabet)))
+
+	for i := 0; i < 16; i++ {
+		if i > 0 && i%4 == 0 {
+			code.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(giftCardAlphabet[n.Int64()])
+	}
+
+	return code.String(), nil
+}
+
+// normalizeGiftCardCode accepts codes typed in any case, with or without
+// dashes and spaces.
+func normalizeGiftCardCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != 16 {
+		return code
+	}
+	return code[0:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:16]
+}
+
+// maskGiftCardCode hides all but the last four characters of a code.
+func maskGiftCardCode(code string) string {
+	if len(code) <= 4 {
+		return code
+	}
+	return "****-" + code[len(code)-4:]
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.String()
+	return &formatted
+}
+
+func toGiftCardResponse(giftCard *entity.GiftCard) *dto.GiftCardResponse {
+	response := &dto.GiftCardResponse{
+		ID:                giftCard.ID,
+		Code:              giftCard.Code,
+		InitialBalance:    giftCard.InitialBalance,
+		Balance:           giftCard.Balance,
+		Status:            giftCard.Status,
+		PurchasedByUserID: giftCard.PurchasedByUserID,
+		ExpiresAt:         formatOptionalTime(giftCard.ExpiresAt),
+		Transactions:      []dto.GiftCardTransactionResponse{},
+		CreatedAt:         giftCard.CreatedAt.String(),
+		UpdatedAt:         giftCard.UpdatedAt.String(),
+	}
+
+	for _, transaction := range giftCard.Transactions {
+		response.Transactions = append(response.Transactions, dto.GiftCardTransactionResponse{
+			ID:           transaction.ID,
+			Type:         transaction.Type,
+			Amount:       transaction.Amount,
+			BalanceAfter: transaction.BalanceAfter,
+			OrderID:      transaction.OrderID,
+			Note:         transaction.Note,
+			CreatedAt:    transaction.CreatedAt.String(),
+		})
+	}
+
+	return response
+}
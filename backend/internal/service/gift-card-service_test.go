@@ -0,0 +1,121 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+)
+
+func TestGiftCardRejection(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		giftCard entity.GiftCard
+		want     string
+	}{
+		{"usable", entity.GiftCard{Status: entity.GiftCardStatusActive, Balance: 1, ExpiresAt: &later}, ""},
+		{"void", entity.GiftCard{Status: entity.GiftCardStatusVoid, Balance: 50000}, "gift card is not active"},
+		{"expires now", entity.GiftCard{Status: entity.GiftCardStatusActive, Balance: 50000, ExpiresAt: &now}, "gift card has expired"},
+		{"spent", entity.GiftCard{Status: entity.GiftCardStatusActive, Balance: 0}, "gift card has no balance left"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := giftCardRejection(&tt.giftCard, now); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeGiftCardCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"ABCD-EFGH-JKLM-NPQR", "ABCD-EFGH-JKLM-NPQR"},
+		{"abcdefghjklmnpqr", "ABCD-EFGH-JKLM-NPQR"},
+		{" abcd efgh-jklm npqr ", "ABCD-EFGH-JKLM-NPQR"},
+		{"abc-def", "ABCDEF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := normalizeGiftCardCode(tt.code); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyGiftCards(t *testing.T) {
+	pricer := &cartPricer{giftCardRepo: giftCardsByCode{giftCards: map[string]entity.GiftCard{
+		"AAAA-AAAA-AAAA-AAAA": {ID: 1, Code: "AAAA-AAAA-AAAA-AAAA", Status: entity.GiftCardStatusActive, Balance: 30000},
+		"BBBB-BBBB-BBBB-BBBB": {ID: 2, Code: "BBBB-BBBB-BBBB-BBBB", Status: entity.GiftCardStatusActive, Balance: 50000},
+		"VVVV-VVVV-VVVV-VVVV": {ID: 3, Code: "VVVV-VVVV-VVVV-VVVV", Status: entity.GiftCardStatusVoid, Balance: 50000},
+	}}}
+
+	tests := []struct {
+		name          string
+		total         int
+		pointsPaid    int
+		codes         []string
+		wantApplied   map[uint]int
+		wantRejected  []string
+		wantAmountDue int
+	}{
+		{"covers part of the order", 100000, 0, []string{"aaaa-aaaa-aaaa-aaaa"}, map[uint]int{1: 30000}, nil, 70000},
+		{"in the order entered", 60000, 0, []string{"AAAA-AAAA-AAAA-AAAA", "BBBB-BBBB-BBBB-BBBB"}, map[uint]int{1: 30000, 2: 30000}, nil, 0},
+		{"after points", 60000, 40000, []string{"BBBB-BBBB-BBBB-BBBB"}, map[uint]int{2: 20000}, nil, 0},
+		{"not needed once paid", 20000, 0, []string{"AAAA-AAAA-AAAA-AAAA", "BBBB-BBBB-BBBB-BBBB"}, map[uint]int{1: 20000}, []string{"the order is already fully paid"}, 0},
+		{"used twice", 100000, 0, []string{"AAAA-AAAA-AAAA-AAAA", "aaaaaaaaaaaaaaaa"}, map[uint]int{1: 30000}, []string{"gift card was already applied"}, 70000},
+		{"void", 100000, 0, []string{"VVVV-VVVV-VVVV-VVVV"}, map[uint]int{}, []string{"gift card is not active"}, 100000},
+		{"unknown", 100000, 0, []string{"ZZZZ-ZZZZ-ZZZZ-ZZZZ"}, map[uint]int{}, []string{"gift card code not found"}, 100000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := &cartQuote{Total: tt.total, PointsDiscount: tt.pointsPaid}
+			pricer.applyGiftCards(quote, tt.codes, time.Now())
+
+			applied := map[uint]int{}
+			for _, giftCard := range quote.GiftCards {
+				applied[giftCard.GiftCard.ID] = giftCard.Amount
+			}
+			var rejected []string
+			for _, giftCard := range quote.RejectedGiftCards {
+				rejected = append(rejected, giftCard.Reason)
+			}
+
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("applied %v, want %v", applied, tt.wantApplied)
+			}
+			if !reflect.DeepEqual(rejected, tt.wantRejected) {
+				t.Errorf("rejected %v, want %v", rejected, tt.wantRejected)
+			}
+			if quote.AmountDue != tt.wantAmountDue {
+				t.Errorf("amount due %d, want %d", quote.AmountDue, tt.wantAmountDue)
+			}
+			if quote.GiftCardTotal+quote.AmountDue != tt.total-tt.pointsPaid {
+				t.Errorf("gift cards %d and amount due %d do not add up to %d", quote.GiftCardTotal, quote.AmountDue, tt.total-tt.pointsPaid)
+			}
+		})
+	}
+}
+
+// giftCardsByCode looks gift cards up by code. The embedded repository is
+// nil, as applyGiftCards needs no other method.
+type giftCardsByCode struct {
+	repository.GiftCardRepository
+	giftCards map[string]entity.GiftCard
+}
+
+func (g giftCardsByCode) GetByCode(code string) (*entity.GiftCard, error) {
+	giftCard, ok := g.giftCards[code]
+	if !ok {
+		return nil, repository.ErrGiftCardNotFound
+	}
+	return &giftCard, nil
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/go-pdf/fpdf"
+)
+
+// invoiceTaxLine sums the taxable amount and tax of all order lines sharing a
+// tax rate.
+type invoiceTaxLine struct {
+	Rate    int
+	Taxable int
+	Tax     int
+}
+
+// renderInvoice draws the invoice for an order as an A4 PDF.
+func renderInvoice(invoice *entity.Invoice, order *entity.Order, store config.StoreConfig) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.SetTitle(invoice.Number, true)
+	pdf.SetAuthor(store.Name, true)
+	pdf.AddPage()
+
+	// Core fonts are cp1252, so titles and addresses are translated from UTF-8
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	// Seller
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(120, 8, tr(store.Name), "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(70, 8, "INVOICE", "", 1, "R", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 9)
+	seller := []string{store.Address, store.Phone, store.Email}
+	if store.TaxID != "" {
+		seller = append(seller, "NPWP: "+store.TaxID)
+	}
+	details := [][2]string{
+		{"Invoice No.", invoice.Number},
+		{"Invoice Date", invoice.IssuedAt.Format("02 Jan 2006")},
+		{"Order No.", "#" + strconv.FormatUint(uint64(order.ID), 10)},
+		{"Order Date", order.CreatedAt.Format("02 Jan 2006")},
+		{"Payment Date", order.PaidAt.Format("02 Jan 2006")},
+		{"Status", strings.ToUpper(order.Status)},
+	}
+
+	top := pdf.GetY() + 2
+	pdf.SetXY(10, top)
+	for _, line := range seller {
+		if line == "" {
+			continue
+		}
+		pdf.MultiCell(110, 4.5, tr(line), "", "L", false)
+	}
+	sellerBottom := pdf.GetY()
+
+	pdf.SetY(top)
+	for _, detail := range details {
+		pdf.SetX(130)
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(28, 4.5, detail[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(42, 4.5, tr(detail[1]), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetY(maxFloat(sellerBottom, pdf.GetY()) + 6)
+
+	// Buyer
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(190, 5, "Bill To", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	buyer := []string{
+		order.ShippingRecipient,
+		order.ShippingAddress,
+		strings.TrimSpace(order.ShippingProvince + " " + order.ShippingPostalCode),
+		order.ShippingPhone,
+	}
+	for _, line := range buyer {
+		if line == "" {
+			continue
+		}
+		pdf.MultiCell(190, 4.5, tr(line), "", "L", false)
+	}
+	pdf.Ln(5)
+
+	// Line items
+	widths := []float64{10, 66, 14, 28, 24, 20, 28}
+	headers := []string{"No", "Item", "Qty", "Unit Price", "Discount", "Tax", "Amount"}
+	aligns := []string{"C", "L", "C", "R", "R", "R", "R"}
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(235, 235, 235)
+	for i, header := range headers {
+		pdf.CellFormat(widths[i], 7, header, "1", 0, aligns[i], true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for i, item := range order.Items {
+		cells := []string{
+			strconv.Itoa(i + 1),
+			truncateText(pdf, tr(item.Title), widths[1]-2),
+			strconv.Itoa(item.Quantity),
+			formatRupiah(item.UnitPrice),
+			formatRupiah(item.Discount),
+			formatTaxRate(item.TaxRate),
+			formatRupiah(item.Total),
+		}
+		for j, cell := range cells {
+			pdf.CellFormat(widths[j], 6, cell, "1", 0, aligns[j], false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	pdf.Ln(5)
+
+	summaryTop := pdf.GetY()
+
+	// Tax breakdown
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(90, 5, "Tax Breakdown", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(25, 6, "Rate", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(35, 6, "Taxable Amount", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(30, 6, "Tax", "1", 1, "R", true, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range invoiceTaxLines(order) {
+		pdf.CellFormat(25, 6, formatTaxRate(line.Rate), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(35, 6, formatRupiah(line.Taxable), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 6, formatRupiah(line.Tax), "1", 1, "R", false, 0, "")
+	}
+	if order.PricesIncludeTax {
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(90, 5, "Prices include tax.", "", 1, "L", false, 0, "")
+	}
+	breakdownBottom := pdf.GetY()
+
+	// Totals
+	coupons := []string{}
+	for _, redemption := range order.CouponRedemptions {
+		if redemption.Coupon != nil {
+			coupons = append(coupons, redemption.Coupon.Code)
+		}
+	}
+	discountLabel := "Discount"
+	if len(coupons) > 0 {
+		discountLabel += " (" + strings.Join(coupons, ", ") + ")"
+	}
+	shippingLabel := "Shipping"
+	if order.ShippingMethodName != "" {
+		shippingLabel += " (" + order.ShippingMethodName + ")"
+	}
+	taxLabel := "Tax"
+	if order.PricesIncludeTax {
+		taxLabel += " (included)"
+	}
+
+	totals := [][2]string{
+		{"Subtotal", formatRupiah(order.Subtotal)},
+		{discountLabel, formatRupiah(-order.DiscountTotal)},
+		{taxLabel, formatRupiah(order.TaxTotal)},
+		{shippingLabel, formatRupiah(order.ShippingTotal)},
+	}
+
+	pdf.SetY(summaryTop)
+	pdf.SetFont("Helvetica", "", 9)
+	for _, total := range totals {
+		pdf.SetX(110)
+		pdf.CellFormat(55, 6, truncateText(pdf, tr(total[0]), 53), "", 0, "L", false, 0, "")
+		pdf.CellFormat(35, 6, total[1], "", 1, "R", false, 0, "")
+	}
+	pdf.SetX(110)
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(55, 8, "Total", "T", 0, "L", false, 0, "")
+	pdf.CellFormat(35, 8, formatRupiah(order.Total), "T", 1, "R", false, 0, "")
+	if order.PointsDiscount > 0 || order.GiftCardTotal > 0 {
+		pdf.SetFont("Helvetica", "", 9)
+		if order.PointsDiscount > 0 {
+			pdf.SetX(110)
+			pdf.CellFormat(55, 6, "Loyalty points ("+strconv.Itoa(order.PointsRedeemed)+")", "", 0, "L", false, 0, "")
+			pdf.CellFormat(35, 6, formatRupiah(-order.PointsDiscount), "", 1, "R", false, 0, "")
+		}
+		if order.GiftCardTotal > 0 {
+			pdf.SetX(110)
+			pdf.CellFormat(55, 6, "Gift card", "", 0, "L", false, 0, "")
+			pdf.CellFormat(35, 6, formatRupiah(-order.GiftCardTotal), "", 1, "R", false, 0, "")
+		}
+		pdf.SetX(110)
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(55, 8, "Amount due", "T", 0, "L", false, 0, "")
+		pdf.CellFormat(35, 8, formatRupiah(order.AmountDue), "T", 1, "R", false, 0, "")
+	}
+
+	pdf.SetY(maxFloat(breakdownBottom, pdf.GetY()) + 10)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(190, 4.5, tr("Thank you for shopping at "+store.Name+"."), "", "C", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// invoiceTaxLines groups order lines by tax rate, lowest rate first.
+func invoiceTaxLines(order *entity.Order) []invoiceTaxLine {
+	byRate := map[int]*invoiceTaxLine{}
+	for _, item := range order.Items {
+		line, ok := byRate[item.TaxRate]
+		if !ok {
+			line = &invoiceTaxLine{Rate: item.TaxRate}
+			byRate[item.TaxRate] = line
+		}
+
+		taxable := item.Subtotal - item.Discount
+		if order.PricesIncludeTax {
+			taxable -= item.Tax
+		}
+		line.Taxable += taxable
+		line.Tax += item.Tax
+	}
+
+	lines := make([]invoiceTaxLine, 0, len(byRate))
+	for _, line := range byRate {
+		lines = append(lines, *line)
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].Rate < lines[j].Rate })
+
+	return lines
+}
+
+// formatRupiah formats an amount as Rupiah with dots between thousands.
+func formatRupiah(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+	var out strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out.WriteByte('.')
+		}
+		out.WriteRune(digit)
+	}
+
+	return sign + "Rp " + out.String()
+}
+
+// formatTaxRate turns basis points into a percentage, e.g. 1100 -> "11%".
+func formatTaxRate(rate int) string {
+	if rate%100 == 0 {
+		return fmt.Sprintf("%d%%", rate/100)
+	}
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", float64(rate)/100), "0"), ".") + "%"
+}
+
+// truncateText shortens text to fit a cell of the given width.
+func truncateText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+	"github.com/google/uuid"
+)
+
+type InvoiceService interface {
+	GetInvoicePDF(userID uint, orderID string) (*dto.FileResponse, *execption.ApiExecption)
+	MoveInvoicesToPrivateStorage() (int, error)
+}
+
+type invoiceService struct {
+	invoiceRepo    repository.InvoiceRepository
+	orderRepo      repository.OrderRepository
+	privateStorage storage.Storage
+	fileStorage    storage.Storage
+	store          config.StoreConfig
+}
+
+func NewInvoiceService(invoiceRepo repository.InvoiceRepository, orderRepo repository.OrderRepository, privateStorage storage.Storage, fileStorage storage.Storage, cfg *config.Config) InvoiceService {
+	return &invoiceService{invoiceRepo: invoiceRepo, orderRepo: orderRepo, privateStorage: privateStorage, fileStorage: fileStorage, store: cfg.Store}
+}
+
+// GetInvoicePDF implements InvoiceService. The PDF is rendered and numbered
+// the first time it is asked for; later calls return the stored file. The
+// number counts in the year the order was paid, not the year it is asked for.
+func (i *invoiceService) GetInvoicePDF(userID uint, orderID string) (*dto.FileResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(orderID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	order, err := i.orderRepo.GetById(uint(uintID))
+	if err != nil || order.UserID != userID {
+		return nil, execption.NewApiExecption(http.StatusNotFound, repository.ErrOrderNotFound.Error())
+	}
+
+	if !orderIsPaid(order) {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Invoices are only issued for paid orders")
+	}
+
+	invoice, err := i.invoiceRepo.GetByOrder(order.ID)
+	if err == repository.ErrInvoiceNotFound {
+		if order.PaidAt == nil {
+			return nil, execption.NewApiExecption(http.StatusInternalServerError, "Order has no payment time to number its invoice by")
+		}
+		invoice, err = i.invoiceRepo.Issue(order.ID, order.PaidAt.Year(), time.Now(), func(invoice *entity.Invoice) (string, error) {
+			content, err := renderInvoice(invoice, order, i.store)
+			if err != nil {
+				return "", err
+			}
+			return i.saveInvoiceFile(content)
+		})
+	}
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	content, err := i.readInvoiceFile(invoice.FilePath)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error reading invoice")
+	}
+
+	return &dto.FileResponse{
+		Name:        strings.ReplaceAll(invoice.Number, "/", "-") + ".pdf",
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// MoveInvoicesToPrivateStorage implements InvoiceService. The first invoices
+// were kept in the public storage, where anyone with the link could read
+// them; this moves those that are left there and returns how many it moved.
+func (i *invoiceService) MoveInvoicesToPrivateStorage() (int, error) {
+	objects, err := i.fileStorage.List("invoices/")
+	if err != nil {
+		return 0, err
+	}
+
+	for n, object := range objects {
+		if _, err := i.moveInvoiceFile(object.Key); err != nil {
+			return n, fmt.Errorf("%s: %w", object.Key, err)
+		}
+	}
+	return len(objects), nil
+}
+
+// saveInvoiceFile stores a rendered invoice and returns its storage key.
+func (i *invoiceService) saveInvoiceFile(content []byte) (string, error) {
+	key := fmt.Sprintf("invoices/%d_%s.pdf", time.Now().Unix(), uuid.New().String())
+
+	if err := i.privateStorage.Put(key, bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// readInvoiceFile reads a stored invoice. Invoices left in the public
+// storage are moved by cmd/move-invoices, not here.
+func (i *invoiceService) readInvoiceFile(key string) ([]byte, error) {
+	file, err := i.privateStorage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+func (i *invoiceService) moveInvoiceFile(key string) ([]byte, error) {
+	file, err := i.fileStorage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.privateStorage.Put(key, bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		return nil, err
+	}
+	deleteFile(i.fileStorage, key)
+
+	return content, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+func TestGetInvoicePDFNumbersByPaymentYear(t *testing.T) {
+	paidAt := time.Date(2023, 12, 31, 23, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		paidAt     *time.Time
+		wantYear   int
+		wantStatus int
+	}{
+		{"paid last year", &paidAt, 2023, 0},
+		{"no payment time", nil, 0, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &entity.Order{ID: 7, UserID: 3, Status: entity.OrderStatusPaid, PaidAt: tt.paidAt}
+			invoices := &issuingInvoiceRepository{}
+			service := NewInvoiceService(invoices, orderByID{order: order}, storage.NewLocalStorage(t.TempDir(), ""), nil, &config.Config{})
+
+			_, apiErr := service.GetInvoicePDF(3, "7")
+			if tt.wantStatus != 0 {
+				if apiErr == nil || apiErr.Status != tt.wantStatus {
+					t.Fatalf("got %v, want status %d", apiErr, tt.wantStatus)
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatal(apiErr.Message)
+			}
+			if invoices.year != tt.wantYear {
+				t.Errorf("numbered in %d, want %d", invoices.year, tt.wantYear)
+			}
+		})
+	}
+}
+
+// issuingInvoiceRepository issues every invoice as the first of its year.
+type issuingInvoiceRepository struct {
+	year int
+}
+
+func (i *issuingInvoiceRepository) GetByOrder(orderID uint) (*entity.Invoice, error) {
+	return nil, repository.ErrInvoiceNotFound
+}
+
+func (i *issuingInvoiceRepository) Issue(orderID uint, year int, issuedAt time.Time, render func(invoice *entity.Invoice) (string, error)) (*entity.Invoice, error) {
+	i.year = year
+	invoice := &entity.Invoice{OrderID: orderID, Number: "INV/1", Year: year, Sequence: 1, IssuedAt: issuedAt}
+
+	filePath, err := render(invoice)
+	if err != nil {
+		return nil, err
+	}
+	invoice.FilePath = filePath
+	return invoice, nil
+}
+
+// orderByID returns its order. The embedded repository is nil, as invoices
+// need no other method.
+type orderByID struct {
+	repository.OrderRepository
+	order *entity.Order
+}
+
+func (o orderByID) GetById(id uint) (*entity.Order, error) {
+	if id != o.order.ID {
+		return nil, repository.ErrOrderNotFound
+	}
+	return o.order, nil
+}
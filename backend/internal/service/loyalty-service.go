@@ -0,0 +1,85 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+)
+
+type LoyaltyService interface {
+	GetMe(userID uint) (*dto.MeResponse, *execption.ApiExecption)
+	ExpirePoints() error
+}
+
+type loyaltyService struct {
+	loyaltyRepo repository.LoyaltyRepository
+	config      config.LoyaltyConfig
+}
+
+func NewLoyaltyService(loyaltyRepo repository.LoyaltyRepository, cfg *config.Config) LoyaltyService {
+	return &loyaltyService{loyaltyRepo: loyaltyRepo, config: cfg.Loyalty}
+}
+
+// GetMe implements LoyaltyService.
+func (l *loyaltyService) GetMe(userID uint) (*dto.MeResponse, *execption.ApiExecption) {
+	balance, err := l.loyaltyRepo.GetBalance(userID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	entries, err := l.loyaltyRepo.GetEntries(userID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	response := &dto.MeResponse{
+		UserID: userID,
+		Loyalty: dto.LoyaltyResponse{
+			Balance:    balance,
+			PointValue: l.config.PointValue,
+			Entries:    []dto.LoyaltyEntryResponse{},
+		},
+	}
+
+	for i := range entries {
+		response.Loyalty.Entries = append(response.Loyalty.Entries, l.toLoyaltyEntryResponse(&entries[i]))
+	}
+
+	return response, nil
+}
+
+// ExpirePoints writes off points that have gone unspent for the expiry
+// period. It is run periodically by the scheduler.
+func (l *loyaltyService) ExpirePoints() error {
+	if l.config.ExpiryPeriod <= 0 {
+		return nil
+	}
+
+	_, err := l.loyaltyRepo.Expire(time.Now().Add(-l.config.ExpiryPeriod))
+	return err
+}
+
+func (l *loyaltyService) toLoyaltyEntryResponse(entry *entity.LoyaltyEntry) dto.LoyaltyEntryResponse {
+	response := dto.LoyaltyEntryResponse{
+		ID:        entry.ID,
+		Type:      entry.Type,
+		Points:    entry.Points,
+		Remaining: entry.Remaining,
+		OrderID:   entry.OrderID,
+		Note:      entry.Note,
+		CreatedAt: entry.CreatedAt.String(),
+	}
+
+	// Only points that are still unspent have an expiry to show
+	if entry.Remaining > 0 && l.config.ExpiryPeriod > 0 {
+		expiresAt := entry.CreatedAt.Add(l.config.ExpiryPeriod).String()
+		response.ExpiresAt = &expiresAt
+	}
+
+	return response
+}
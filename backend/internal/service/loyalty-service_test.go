@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+)
+
+func TestLoyaltyPointsEarned(t *testing.T) {
+	cfg := config.LoyaltyConfig{RupiahPerPoint: 1000}
+	plain := &entity.Book{}
+	doubled := &entity.Book{Categories: []entity.Category{{LoyaltyMultiplier: 150}, {LoyaltyMultiplier: 200}}}
+	halved := &entity.Book{Categories: []entity.Category{{LoyaltyMultiplier: 50}}}
+
+	tests := []struct {
+		name           string
+		cfg            config.LoyaltyConfig
+		lines          []*cartLine
+		total          int
+		pointsDiscount int
+		want           int
+	}{
+		{"a point per 1000", cfg, []*cartLine{newCartLine(plain, 1, 100000)}, 100000, 0, 100},
+		{"highest category multiplier", cfg, []*cartLine{newCartLine(doubled, 1, 100000)}, 100000, 0, 200},
+		{"multiplier below one", cfg, []*cartLine{newCartLine(halved, 1, 100000)}, 100000, 0, 50},
+		{"per line", cfg, []*cartLine{newCartLine(plain, 1, 50000), newCartLine(doubled, 1, 50000)}, 100000, 0, 150},
+		{"shipping earns nothing", cfg, []*cartLine{newCartLine(plain, 1, 100000)}, 120000, 0, 100},
+		{"the part paid with points earns nothing", cfg, []*cartLine{newCartLine(plain, 1, 100000)}, 100000, 40000, 60},
+		{"rounded down", cfg, []*cartLine{newCartLine(plain, 1, 1999)}, 1999, 0, 1},
+		{"program off", config.LoyaltyConfig{}, []*cartLine{newCartLine(plain, 1, 100000)}, 100000, 0, 0},
+		{"free order", cfg, nil, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := &cartQuote{Lines: tt.lines, Total: tt.total, PointsDiscount: tt.pointsDiscount}
+			if got := loyaltyPointsEarned(quote, tt.cfg); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpirePoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		expiryPeriod time.Duration
+		wantExpire   bool
+	}{
+		{"a year", 365 * 24 * time.Hour, true},
+		{"kept forever", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &expiringLoyaltyRepository{}
+			service := NewLoyaltyService(repo, &config.Config{Loyalty: config.LoyaltyConfig{ExpiryPeriod: tt.expiryPeriod}})
+
+			before := time.Now()
+			if err := service.ExpirePoints(); err != nil {
+				t.Fatal(err)
+			}
+			after := time.Now()
+
+			if repo.expired != tt.wantExpire {
+				t.Fatalf("expired %v, want %v", repo.expired, tt.wantExpire)
+			}
+			if tt.wantExpire && (repo.earnedBefore.Before(before.Add(-tt.expiryPeriod)) || repo.earnedBefore.After(after.Add(-tt.expiryPeriod))) {
+				t.Errorf("expired points earned before %v, want %v ago", repo.earnedBefore, tt.expiryPeriod)
+			}
+		})
+	}
+}
+
+func TestLoyaltyEntryExpiresAt(t *testing.T) {
+	earned := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	year := 365 * 24 * time.Hour
+	expiresAt := earned.Add(year).String()
+
+	tests := []struct {
+		name         string
+		entry        entity.LoyaltyEntry
+		expiryPeriod time.Duration
+		want         *string
+	}{
+		{"unspent points", entity.LoyaltyEntry{Type: entity.LoyaltyEntryEarn, Points: 100, Remaining: 40, CreatedAt: earned}, year, &expiresAt},
+		{"spent points", entity.LoyaltyEntry{Type: entity.LoyaltyEntryEarn, Points: 100, Remaining: 0, CreatedAt: earned}, year, nil},
+		{"redemption", entity.LoyaltyEntry{Type: entity.LoyaltyEntryRedeem, Points: -100, CreatedAt: earned}, year, nil},
+		{"kept forever", entity.LoyaltyEntry{Type: entity.LoyaltyEntryEarn, Points: 100, Remaining: 100, CreatedAt: earned}, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &loyaltyService{config: config.LoyaltyConfig{ExpiryPeriod: tt.expiryPeriod}}
+			got := service.toLoyaltyEntryResponse(&tt.entry).ExpiresAt
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// expiringLoyaltyRepository records the call to Expire. The embedded
+// repository is nil, as ExpirePoints needs no other method.
+type expiringLoyaltyRepository struct {
+	repository.LoyaltyRepository
+	expired      bool
+	earnedBefore time.Time
+}
+
+func (e *expiringLoyaltyRepository) Expire(earnedBefore time.Time) (int64, error) {
+	e.expired = true
+	e.earnedBefore = earnedBefore
+	return 0, nil
+}
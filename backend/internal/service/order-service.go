@@ -0,0 +1,370 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/payment"
+)
+
+type OrderService interface {
+	CreateOrder(userID uint, input binder.CreateOrder) (*dto.OrderResponse, *execption.ApiExecption)
+	GetOrders(userID uint) ([]*dto.OrderResponse, *execption.ApiExecption)
+	GetOrder(userID uint, orderID string) (*dto.OrderResponse, *execption.ApiExecption)
+	UpdateOrderStatus(input binder.UpdateOrderStatus) (*dto.OrderResponse, *execption.ApiExecption)
+}
+
+type orderService struct {
+	orderRepo       repository.OrderRepository
+	pricer          *cartPricer
+	paymentProvider payment.Provider
+	preorderConfig  config.PreorderConfig
+}
+
+func NewOrderService(orderRepo repository.OrderRepository, bookRepo repository.BookRepository, couponRepo repository.CouponRepository, shippingRepo repository.ShippingRepository, giftCardRepo repository.GiftCardRepository, loyaltyRepo repository.LoyaltyRepository, paymentProvider payment.Provider, cfg *config.Config) OrderService {
+	return &orderService{
+		orderRepo:       orderRepo,
+		pricer:          newCartPricer(bookRepo, couponRepo, shippingRepo, giftCardRepo, loyaltyRepo, cfg),
+		paymentProvider: paymentProvider,
+		preorderConfig:  cfg.Preorder,
+	}
+}
+
+// orderStatusTransitions lists the statuses an order may move to from its current status.
+// Pre-orders are moved to paid by the release job, not by hand. Cancelling a paid order
+// refunds the amount the provider captured.
+var orderStatusTransitions = map[string][]string{
+	entity.OrderStatusPending:    {entity.OrderStatusPaid, entity.OrderStatusCancelled},
+	entity.OrderStatusPreordered: {entity.OrderStatusCancelled},
+	entity.OrderStatusPaid:       {entity.OrderStatusShipped, entity.OrderStatusCancelled},
+	entity.OrderStatusShipped:    {entity.OrderStatusDelivered},
+}
+
+// CreateOrder implements OrderService.
+func (o *orderService) CreateOrder(userID uint, input binder.CreateOrder) (*dto.OrderResponse, *execption.ApiExecption) {
+	quote, apiErr := o.pricer.price(userID, binder.QuoteCart{
+		Items:            input.Items,
+		CouponCodes:      input.CouponCodes,
+		GiftCardCodes:    input.GiftCardCodes,
+		RedeemPoints:     input.RedeemPoints,
+		ShippingAddress:  &input.ShippingAddress,
+		ShippingMethodID: input.ShippingMethodID,
+	})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// A checkout must not silently drop a code the customer entered
+	if len(quote.Rejected) > 0 {
+		rejected := quote.Rejected[0]
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Coupon "+rejected.Code+" was rejected: "+rejected.Reason)
+	}
+
+	if len(quote.RejectedGiftCards) > 0 {
+		rejected := quote.RejectedGiftCards[0]
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Gift card "+maskGiftCardCode(rejected.Code)+" was rejected: "+rejected.Reason)
+	}
+
+	isPreorder, apiErr := preorderCart(quote.Lines, o.preorderConfig.AuthorizationHold, time.Now())
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	order := &entity.Order{
+		UserID:             userID,
+		Status:             entity.OrderStatusPending,
+		IsPreorder:         isPreorder,
+		Subtotal:           quote.Subtotal,
+		DiscountTotal:      quote.DiscountTotal,
+		TaxTotal:           quote.TaxTotal,
+		ShippingTotal:      quote.ShippingTotal,
+		PricesIncludeTax:   quote.PricesIncludeTax,
+		Total:              quote.Total,
+		PointsRedeemed:     quote.PointsRedeemed,
+		PointsDiscount:     quote.PointsDiscount,
+		PointsEarned:       quote.PointsEarned,
+		GiftCardTotal:      quote.GiftCardTotal,
+		AmountDue:          quote.AmountDue,
+		ShippingMethodID:   &quote.Shipping.Method.ID,
+		ShippingMethodName: quote.Shipping.Method.Name,
+		ShippingRecipient:  input.ShippingAddress.Recipient,
+		ShippingPhone:      input.ShippingAddress.Phone,
+		ShippingAddress:    input.ShippingAddress.Address,
+		ShippingProvince:   input.ShippingAddress.Province,
+		ShippingPostalCode: input.ShippingAddress.PostalCode,
+	}
+
+	for _, line := range quote.Lines {
+		order.Items = append(order.Items, entity.OrderItem{
+			BookID:    line.Book.ID,
+			Title:     line.Book.Title,
+			UnitPrice: line.UnitPrice,
+			Quantity:  line.Quantity,
+			Subtotal:  line.Subtotal,
+			Discount:  line.Discount,
+			TaxRate:   line.TaxRate,
+			Tax:       line.Tax,
+			Total:     line.total(quote.PricesIncludeTax),
+		})
+	}
+
+	for _, applied := range quote.Applied {
+		order.CouponRedemptions = append(order.CouponRedemptions, entity.CouponRedemption{
+			CouponID: applied.Coupon.ID,
+			UserID:   userID,
+			Amount:   applied.Amount,
+		})
+	}
+
+	for _, applied := range quote.GiftCards {
+		order.GiftCardTransactions = append(order.GiftCardTransactions, entity.GiftCardTransaction{
+			GiftCardID: applied.GiftCard.ID,
+			Amount:     applied.Amount,
+			Note:       "Checkout",
+		})
+	}
+
+	order, err := o.orderRepo.Create(order)
+	if err != nil {
+		if err == repository.ErrCouponUsageLimitReached || err == repository.ErrInsufficientStock ||
+			err == repository.ErrGiftCardNotActive || err == repository.ErrGiftCardInsufficientBalance ||
+			err == repository.ErrLoyaltyInsufficientPoints {
+			return nil, execption.NewApiExecption(http.StatusConflict, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	if order.IsPreorder {
+		return o.authorizePreorder(order)
+	}
+
+	return toOrderResponse(order), nil
+}
+
+// authorizePreorder holds the amount due with the payment provider. It is
+// captured when the books are released; if the hold fails the order is
+// cancelled. Pre-orders fully paid by gift card need no hold.
+func (o *orderService) authorizePreorder(order *entity.Order) (*dto.OrderResponse, *execption.ApiExecption) {
+	if order.AmountDue == 0 {
+		order, err := o.orderRepo.UpdatePayment(order.ID, entity.OrderStatusPreordered, "")
+		if err != nil {
+			return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+		}
+		return toOrderResponse(order), nil
+	}
+
+	authorization, err := o.paymentProvider.Authorize(payment.AuthorizeRequest{OrderID: order.ID, Amount: order.AmountDue})
+	if err != nil {
+		if _, cancelErr := o.orderRepo.UpdateStatus(order.ID, order.Status, entity.OrderStatusCancelled); cancelErr != nil {
+			return nil, execption.NewApiExecption(http.StatusInternalServerError, cancelErr.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusPaymentRequired, err.Error())
+	}
+
+	order, err = o.orderRepo.UpdatePayment(order.ID, entity.OrderStatusPreordered, authorization.Reference)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toOrderResponse(order), nil
+}
+
+// GetOrders implements OrderService.
+func (o *orderService) GetOrders(userID uint) ([]*dto.OrderResponse, *execption.ApiExecption) {
+	orders, err := o.orderRepo.GetAllByUser(userID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.OrderResponse{}
+	for i := range orders {
+		responses = append(responses, toOrderResponse(&orders[i]))
+	}
+
+	return responses, nil
+}
+
+// GetOrder implements OrderService.
+func (o *orderService) GetOrder(userID uint, orderID string) (*dto.OrderResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(orderID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	order, err := o.orderRepo.GetById(uint(uintID))
+	if err != nil || order.UserID != userID {
+		return nil, execption.NewApiExecption(http.StatusNotFound, repository.ErrOrderNotFound.Error())
+	}
+
+	return toOrderResponse(order), nil
+}
+
+// UpdateOrderStatus implements OrderService.
+func (o *orderService) UpdateOrderStatus(input binder.UpdateOrderStatus) (*dto.OrderResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	order, err := o.orderRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	if !canTransitionOrder(order.Status, input.Status) {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Cannot change order status from "+order.Status+" to "+input.Status)
+	}
+
+	if order.Status == entity.OrderStatusPaid && input.Status == entity.OrderStatusCancelled && order.AmountDue > 0 {
+		return o.cancelPaidOrder(order)
+	}
+
+	// Release the hold on a cancelled pre-order before it is marked cancelled
+	if order.Status == entity.OrderStatusPreordered && order.PaymentReference != "" {
+		if err := o.paymentProvider.Void(order.PaymentReference); err != nil {
+			return nil, execption.NewApiExecption(http.StatusBadGateway, err.Error())
+		}
+	}
+
+	order, err = o.orderRepo.UpdateStatus(order.ID, order.Status, input.Status)
+	if err != nil {
+		return nil, orderStatusError(err)
+	}
+
+	return toOrderResponse(order), nil
+}
+
+// cancelPaidOrder refunds the captured amount before the order is cancelled.
+// The order is claimed as cancelling first so that two requests cannot both
+// refund it. If the refund fails the order goes back to paid.
+func (o *orderService) cancelPaidOrder(order *entity.Order) (*dto.OrderResponse, *execption.ApiExecption) {
+	if _, err := o.orderRepo.UpdateStatus(order.ID, entity.OrderStatusPaid, entity.OrderStatusCancelling); err != nil {
+		return nil, orderStatusError(err)
+	}
+
+	_, err := o.paymentProvider.Refund(payment.RefundRequest{
+		OrderID: order.ID,
+		Amount:  order.AmountDue,
+		Reason:  "Order cancelled",
+	})
+	if err != nil {
+		if _, releaseErr := o.orderRepo.UpdateStatus(order.ID, entity.OrderStatusCancelling, entity.OrderStatusPaid); releaseErr != nil {
+			return nil, execption.NewApiExecption(http.StatusInternalServerError, releaseErr.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusBadGateway, "Order not cancelled, the refund failed: "+err.Error())
+	}
+
+	order, err = o.orderRepo.UpdateStatus(order.ID, entity.OrderStatusCancelling, entity.OrderStatusCancelled)
+	if err != nil {
+		return nil, orderStatusError(err)
+	}
+
+	return toOrderResponse(order), nil
+}
+
+func orderStatusError(err error) *execption.ApiExecption {
+	if err == repository.ErrOrderStatusChanged {
+		return execption.NewApiExecption(http.StatusConflict, err.Error())
+	}
+	return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+}
+
+// preorderCart reports whether the cart is a pre-order. Unreleased books are
+// charged later, so they cannot share an order with books that ship now, and
+// are released after the payment hold placed now would have expired.
+func preorderCart(lines []*cartLine, hold time.Duration, now time.Time) (bool, *execption.ApiExecption) {
+	preorders := 0
+	for _, line := range lines {
+		if line.Book.Availability != entity.BookAvailabilityPreorder {
+			continue
+		}
+		preorders++
+
+		if line.Book.ReleaseDate != nil && line.Book.ReleaseDate.After(now.Add(hold)) {
+			opensAt := line.Book.ReleaseDate.Add(-hold)
+			return false, execption.NewApiExecption(http.StatusBadRequest, "Pre-orders for "+line.Book.Title+" open on "+opensAt.Format("2006-01-02"))
+		}
+	}
+
+	if preorders > 0 && preorders < len(lines) {
+		return false, execption.NewApiExecption(http.StatusBadRequest, "Pre-order books must be ordered separately from released books")
+	}
+
+	return preorders > 0, nil
+}
+
+func canTransitionOrder(from string, to string) bool {
+	for _, status := range orderStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+func toOrderResponse(order *entity.Order) *dto.OrderResponse {
+	response := &dto.OrderResponse{
+		ID:                 order.ID,
+		UserID:             order.UserID,
+		Status:             order.Status,
+		IsPreorder:         order.IsPreorder,
+		Items:              []dto.OrderItemResponse{},
+		AppliedCoupons:     []dto.AppliedCouponResponse{},
+		Subtotal:           order.Subtotal,
+		DiscountTotal:      order.DiscountTotal,
+		TaxTotal:           order.TaxTotal,
+		ShippingTotal:      order.ShippingTotal,
+		PricesIncludeTax:   order.PricesIncludeTax,
+		Total:              order.Total,
+		PointsRedeemed:     order.PointsRedeemed,
+		PointsDiscount:     order.PointsDiscount,
+		PointsEarned:       order.PointsEarned,
+		GiftCardTotal:      order.GiftCardTotal,
+		AmountDue:          order.AmountDue,
+		ShippingMethodID:   order.ShippingMethodID,
+		ShippingMethodName: order.ShippingMethodName,
+		ShippingAddress: dto.ShippingAddressResponse{
+			Recipient:  order.ShippingRecipient,
+			Phone:      order.ShippingPhone,
+			Address:    order.ShippingAddress,
+			Province:   order.ShippingProvince,
+			PostalCode: order.ShippingPostalCode,
+		},
+		CreatedAt: order.CreatedAt.String(),
+		UpdatedAt: order.UpdatedAt.String(),
+	}
+
+	for _, item := range order.Items {
+		response.Items = append(response.Items, dto.OrderItemResponse{
+			ID:        item.ID,
+			BookID:    item.BookID,
+			Title:     item.Title,
+			UnitPrice: item.UnitPrice,
+			Quantity:  item.Quantity,
+			Subtotal:  item.Subtotal,
+			Discount:  item.Discount,
+			TaxRate:   item.TaxRate,
+			Tax:       item.Tax,
+			Total:     item.Total,
+		})
+	}
+
+	for _, redemption := range order.CouponRedemptions {
+		applied := dto.AppliedCouponResponse{Amount: redemption.Amount}
+		if redemption.Coupon != nil {
+			applied.Code = redemption.Coupon.Code
+			applied.Description = redemption.Coupon.Description
+			applied.Type = redemption.Coupon.Type
+		}
+		response.AppliedCoupons = append(response.AppliedCoupons, applied)
+	}
+
+	return response
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+func TestPreorderCart(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	hold := 7 * 24 * time.Hour
+	inFiveDays := time.Date(2024, 6, 6, 0, 0, 0, 0, time.Local)
+	inTenDays := time.Date(2024, 6, 11, 0, 0, 0, 0, time.Local)
+
+	released := &entity.Book{Title: "Released", Availability: entity.BookAvailabilityAvailable}
+	soon := &entity.Book{Title: "Soon", Availability: entity.BookAvailabilityPreorder, ReleaseDate: &inFiveDays}
+	later := &entity.Book{Title: "Later", Availability: entity.BookAvailabilityPreorder, ReleaseDate: &inTenDays}
+
+	tests := []struct {
+		name        string
+		books       []*entity.Book
+		want        bool
+		wantMessage string
+	}{
+		{"released books", []*entity.Book{released}, false, ""},
+		{"released within the hold", []*entity.Book{soon}, true, ""},
+		{"mixed with released books", []*entity.Book{soon, released}, false, "Pre-order books must be ordered separately from released books"},
+		{"released after the hold", []*entity.Book{soon, later}, false, "Pre-orders for Later open on 2024-06-04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []*cartLine
+			for _, book := range tt.books {
+				lines = append(lines, newCartLine(book, 1, 10000))
+			}
+
+			got, apiErr := preorderCart(lines, hold, now)
+			if tt.wantMessage != "" {
+				if apiErr == nil || apiErr.Status != http.StatusBadRequest || apiErr.Message != tt.wantMessage {
+					t.Fatalf("got %v, want %q", apiErr, tt.wantMessage)
+				}
+				return
+			}
+			if apiErr != nil {
+				t.Fatal(apiErr.Message)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/payment"
+)
+
+type PreorderService interface {
+	ReleasePreorders() error
+}
+
+type preorderService struct {
+	bookRepo        repository.BookRepository
+	orderRepo       repository.OrderRepository
+	paymentProvider payment.Provider
+}
+
+func NewPreorderService(bookRepo repository.BookRepository, orderRepo repository.OrderRepository, paymentProvider payment.Provider) PreorderService {
+	return &preorderService{bookRepo: bookRepo, orderRepo: orderRepo, paymentProvider: paymentProvider}
+}
+
+// ReleasePreorders makes books available on their release date, then captures
+// the payment of every pre-order whose books are all released and moves it to
+// paid so it can be shipped. Orders whose capture fails, or whose books are
+// out of stock, stay pre-ordered and are retried on the next run.
+func (p *preorderService) ReleasePreorders() error {
+	if _, err := p.bookRepo.ReleaseDue(time.Now()); err != nil {
+		return err
+	}
+
+	orders, err := p.orderRepo.GetReleasablePreorders()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range orders {
+		if err := p.capture(&orders[i]); err != nil {
+			errs = append(errs, fmt.Errorf("order %d: %w", orders[i].ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// capture claims the order before charging it, so a second run or replica
+// skips an order that is already being captured. The claim takes the stock
+// the order ships from.
+func (p *preorderService) capture(order *entity.Order) error {
+	if _, err := p.orderRepo.ClaimPreorderCapture(order.ID); err != nil {
+		if err == repository.ErrOrderStatusChanged {
+			return nil
+		}
+		return err
+	}
+
+	reference := order.PaymentReference
+	if order.AmountDue > 0 {
+		capture, err := p.paymentProvider.Capture(payment.CaptureRequest{
+			OrderID:                order.ID,
+			AuthorizationReference: order.PaymentReference,
+			Amount:                 order.AmountDue,
+		})
+		if err != nil {
+			if releaseErr := p.orderRepo.ReleasePreorderCapture(order.ID); releaseErr != nil {
+				return errors.Join(err, releaseErr)
+			}
+			return err
+		}
+		reference = capture.Reference
+	}
+
+	_, err := p.orderRepo.UpdatePayment(order.ID, entity.OrderStatusPaid, reference)
+	return err
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+const (
+	RecommendationSourceCoPurchase = "co_purchase"
+	RecommendationSourceCategory   = "category"
+)
+
+type RecommendationService interface {
+	GetRecommendations(bookID string) ([]*dto.RecommendationResponse, *execption.ApiExecption)
+	RefreshRecommendations() error
+}
+
+type recommendationService struct {
+	recommendationRepo repository.RecommendationRepository
+	bookRepo           repository.BookRepository
+	fileStorage        storage.Storage
+	cfg                config.RecommendationConfig
+}
+
+func NewRecommendationService(recommendationRepo repository.RecommendationRepository, bookRepo repository.BookRepository, fileStorage storage.Storage, cfg *config.Config) RecommendationService {
+	return &recommendationService{recommendationRepo: recommendationRepo, bookRepo: bookRepo, fileStorage: fileStorage, cfg: cfg.Recommendation}
+}
+
+// GetRecommendations implements RecommendationService. Books bought together
+// with the given book come first; when there are not enough of them the list
+// is topped up with books from the same categories.
+func (r *recommendationService) GetRecommendations(bookID string) ([]*dto.RecommendationResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(bookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := r.bookRepo.GetById(uint(uintID)); err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	recommendations, err := r.recommendationRepo.GetByBook(uint(uintID), r.cfg.Limit)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.RecommendationResponse{}
+	excludeIDs := []uint{}
+	for i := range recommendations {
+		responses = append(responses, &dto.RecommendationResponse{
+			Book:   toBookResponse(&recommendations[i].RecommendedBook, r.fileStorage),
+			Score:  recommendations[i].Score,
+			Source: RecommendationSourceCoPurchase,
+		})
+		excludeIDs = append(excludeIDs, recommendations[i].RecommendedBookID)
+	}
+
+	remaining := r.cfg.Limit - len(responses)
+	if remaining <= 0 {
+		return responses, nil
+	}
+
+	scores, err := r.recommendationRepo.GetByCategories(uint(uintID), excludeIDs, remaining)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	if len(scores) == 0 {
+		return responses, nil
+	}
+
+	ids := make([]uint, 0, len(scores))
+	for _, score := range scores {
+		ids = append(ids, score.BookID)
+	}
+
+	var books []*entity.Book
+	if err := r.bookRepo.FindByIDs(ids, &books); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	booksByID := make(map[uint]*entity.Book, len(books))
+	for _, book := range books {
+		booksByID[book.ID] = book
+	}
+
+	for _, score := range scores {
+		book, ok := booksByID[score.BookID]
+		if !ok {
+			continue
+		}
+		responses = append(responses, &dto.RecommendationResponse{
+			Book:   toBookResponse(book, r.fileStorage),
+			Score:  score.Score,
+			Source: RecommendationSourceCategory,
+		})
+	}
+
+	return responses, nil
+}
+
+// RefreshRecommendations recomputes the co-purchase table. It is run by the
+// background job.
+func (r *recommendationService) RefreshRecommendations() error {
+	return r.recommendationRepo.Rebuild(r.cfg.MinSupport)
+}
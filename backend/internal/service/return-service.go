@@ -0,0 +1,273 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/payment"
+)
+
+type ReturnService interface {
+	CreateReturn(userID uint, input binder.CreateReturn) (*dto.ReturnResponse, *execption.ApiExecption)
+	GetReturns(userID uint) ([]*dto.ReturnResponse, *execption.ApiExecption)
+	GetReturn(userID uint, returnID string) (*dto.ReturnResponse, *execption.ApiExecption)
+	UpdateReturnStatus(input binder.UpdateReturnStatus) (*dto.ReturnResponse, *execption.ApiExecption)
+}
+
+type returnService struct {
+	returnRepo      repository.ReturnRepository
+	orderRepo       repository.OrderRepository
+	paymentProvider payment.Provider
+}
+
+func NewReturnService(returnRepo repository.ReturnRepository, orderRepo repository.OrderRepository, paymentProvider payment.Provider) ReturnService {
+	return &returnService{returnRepo: returnRepo, orderRepo: orderRepo, paymentProvider: paymentProvider}
+}
+
+// returnStatusTransitions lists the statuses staff may move a return to from
+// its current status. Refunded is set once the provider has paid out.
+var returnStatusTransitions = map[string][]string{
+	entity.ReturnStatusRequested: {entity.ReturnStatusApproved, entity.ReturnStatusRejected},
+	entity.ReturnStatusApproved:  {entity.ReturnStatusReceived},
+}
+
+// CreateReturn implements ReturnService.
+func (r *returnService) CreateReturn(userID uint, input binder.CreateReturn) (*dto.ReturnResponse, *execption.ApiExecption) {
+	orderID, err := strconv.ParseUint(input.OrderID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	order, err := r.orderRepo.GetById(uint(orderID))
+	if err != nil || order.UserID != userID {
+		return nil, execption.NewApiExecption(http.StatusNotFound, repository.ErrOrderNotFound.Error())
+	}
+
+	if order.Status != entity.OrderStatusDelivered {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Only delivered orders can be returned")
+	}
+
+	orderItems := map[uint]entity.OrderItem{}
+	for _, item := range order.Items {
+		orderItems[item.ID] = item
+	}
+
+	orderReturn := &entity.Return{
+		OrderID:   order.ID,
+		UserID:    userID,
+		Status:    entity.ReturnStatusRequested,
+		Reason:    input.Reason,
+		Histories: []entity.ReturnStatusHistory{{Status: entity.ReturnStatusRequested, Note: input.Reason}},
+	}
+
+	for _, item := range input.Items {
+		orderItem, ok := orderItems[item.OrderItemID]
+		if !ok {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "Order item "+strconv.FormatUint(uint64(item.OrderItemID), 10)+" is not part of this order")
+		}
+
+		orderReturn.Items = append(orderReturn.Items, entity.ReturnItem{
+			OrderItemID: orderItem.ID,
+			BookID:      orderItem.BookID,
+			Quantity:    item.Quantity,
+			Amount:      orderItem.Total * item.Quantity / orderItem.Quantity,
+		})
+	}
+
+	orderReturn, err = r.returnRepo.Create(orderReturn)
+	if err != nil {
+		if err == repository.ErrReturnQuantityExceeded {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toReturnResponse(orderReturn), nil
+}
+
+// GetReturns implements ReturnService.
+func (r *returnService) GetReturns(userID uint) ([]*dto.ReturnResponse, *execption.ApiExecption) {
+	orderReturns, err := r.returnRepo.GetAllByUser(userID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.ReturnResponse{}
+	for i := range orderReturns {
+		responses = append(responses, toReturnResponse(&orderReturns[i]))
+	}
+
+	return responses, nil
+}
+
+// GetReturn implements ReturnService.
+func (r *returnService) GetReturn(userID uint, returnID string) (*dto.ReturnResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(returnID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	orderReturn, err := r.returnRepo.GetById(uint(uintID))
+	if err != nil || orderReturn.UserID != userID {
+		return nil, execption.NewApiExecption(http.StatusNotFound, repository.ErrReturnNotFound.Error())
+	}
+
+	return toReturnResponse(orderReturn), nil
+}
+
+// UpdateReturnStatus implements ReturnService. Receiving a return restocks its
+// items and refunds RefundAmount, or the full refundable total when it is not
+// given. The refund is claimed first so that concurrent requests cannot both
+// pay out, and only the part the order paid through the provider is refunded
+// there. Whatever earlier refunds of the order leave no room for is given as
+// the unrefunded amount. If the refund fails the return goes back to received
+// and receiving it again only retries the refund.
+func (r *returnService) UpdateReturnStatus(input binder.UpdateReturnStatus) (*dto.ReturnResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	orderReturn, err := r.returnRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	refundAmount := refundableTotal(orderReturn)
+	if input.RefundAmount != nil {
+		if input.Status != entity.ReturnStatusReceived {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "A refund amount can only be given when receiving a return")
+		}
+		if *input.RefundAmount > refundAmount {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "Refund amount cannot be more than "+strconv.Itoa(refundAmount))
+		}
+		refundAmount = *input.RefundAmount
+	}
+
+	retryRefund := input.Status == entity.ReturnStatusReceived && orderReturn.Status == entity.ReturnStatusReceived
+	if !retryRefund {
+		if !canTransitionReturn(orderReturn.Status, input.Status) {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "Cannot change return status from "+orderReturn.Status+" to "+input.Status)
+		}
+
+		orderReturn, err = r.returnRepo.UpdateStatus(orderReturn.ID, orderReturn.Status, input.Status, input.Note)
+		if err != nil {
+			if err == repository.ErrReturnStatusChanged {
+				return nil, execption.NewApiExecption(http.StatusConflict, err.Error())
+			}
+			return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if orderReturn.Status != entity.ReturnStatusReceived {
+		return toReturnResponse(orderReturn), nil
+	}
+
+	orderReturn, err = r.returnRepo.ClaimRefund(orderReturn.ID, refundAmount)
+	if err != nil {
+		if err == repository.ErrReturnStatusChanged {
+			return nil, execption.NewApiExecption(http.StatusConflict, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	// Nothing goes through the provider when gift cards and points paid for
+	// everything returned
+	refund := &payment.Refund{}
+	note := "Refunded to gift cards and points"
+	if orderReturn.RefundAmount > 0 {
+		refund, err = r.paymentProvider.Refund(payment.RefundRequest{
+			OrderID: orderReturn.OrderID,
+			Amount:  orderReturn.RefundAmount,
+			Reason:  orderReturn.Reason,
+		})
+		if err != nil {
+			if _, releaseErr := r.returnRepo.ReleaseRefund(orderReturn.ID, "Refund failed: "+err.Error()); releaseErr != nil {
+				return nil, execption.NewApiExecption(http.StatusInternalServerError, releaseErr.Error())
+			}
+			return nil, execption.NewApiExecption(http.StatusBadGateway, "Return received but the refund failed: "+err.Error())
+		}
+		note = "Refunded via " + r.paymentProvider.Name()
+	}
+	if orderReturn.UnrefundedAmount > 0 {
+		note += ", " + strconv.Itoa(orderReturn.UnrefundedAmount) + " not refunded as earlier refunds of the order used it up"
+	}
+
+	orderReturn, err = r.returnRepo.MarkRefunded(orderReturn.ID, refund.Amount, refund.Reference, note)
+	if err != nil {
+		if err == repository.ErrReturnStatusChanged {
+			return nil, execption.NewApiExecption(http.StatusConflict, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toReturnResponse(orderReturn), nil
+}
+
+func canTransitionReturn(from string, to string) bool {
+	for _, status := range returnStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// refundableTotal is what the customer paid for the returned quantities.
+func refundableTotal(orderReturn *entity.Return) int {
+	total := 0
+	for _, item := range orderReturn.Items {
+		total += item.Amount
+	}
+	return total
+}
+
+func toReturnResponse(orderReturn *entity.Return) *dto.ReturnResponse {
+	response := &dto.ReturnResponse{
+		ID:                   orderReturn.ID,
+		OrderID:              orderReturn.OrderID,
+		UserID:               orderReturn.UserID,
+		Status:               orderReturn.Status,
+		Reason:               orderReturn.Reason,
+		Items:                []dto.ReturnItemResponse{},
+		RefundableTotal:      refundableTotal(orderReturn),
+		RefundAmount:         orderReturn.RefundAmount,
+		GiftCardRefundAmount: orderReturn.GiftCardRefundAmount,
+		PointsRefunded:       orderReturn.PointsRefunded,
+		UnrefundedAmount:     orderReturn.UnrefundedAmount,
+		RefundReference:      orderReturn.RefundReference,
+		History:              []dto.ReturnStatusHistoryResponse{},
+		CreatedAt:            orderReturn.CreatedAt.String(),
+		UpdatedAt:            orderReturn.UpdatedAt.String(),
+	}
+
+	if orderReturn.RefundedAt != nil {
+		refundedAt := orderReturn.RefundedAt.String()
+		response.RefundedAt = &refundedAt
+	}
+
+	for _, item := range orderReturn.Items {
+		response.Items = append(response.Items, dto.ReturnItemResponse{
+			ID:          item.ID,
+			OrderItemID: item.OrderItemID,
+			BookID:      item.BookID,
+			Quantity:    item.Quantity,
+			Amount:      item.Amount,
+		})
+	}
+
+	for _, history := range orderReturn.Histories {
+		response.History = append(response.History, dto.ReturnStatusHistoryResponse{
+			Status:    history.Status,
+			Note:      history.Note,
+			CreatedAt: history.CreatedAt.String(),
+		})
+	}
+
+	return response
+}
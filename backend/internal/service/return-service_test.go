@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+func TestCanTransitionReturn(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{entity.ReturnStatusRequested, entity.ReturnStatusApproved, true},
+		{entity.ReturnStatusRequested, entity.ReturnStatusRejected, true},
+		{entity.ReturnStatusApproved, entity.ReturnStatusReceived, true},
+		{entity.ReturnStatusRequested, entity.ReturnStatusReceived, false},
+		{entity.ReturnStatusRejected, entity.ReturnStatusApproved, false},
+		// Refunds go through the provider, not a plain status change
+		{entity.ReturnStatusReceived, entity.ReturnStatusRefunding, false},
+		{entity.ReturnStatusReceived, entity.ReturnStatusRefunded, false},
+		{entity.ReturnStatusRefunding, entity.ReturnStatusReceived, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			if got := canTransitionReturn(tt.from, tt.to); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefundableTotal(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []entity.ReturnItem
+		want  int
+	}{
+		{"no items", nil, 0},
+		{"one item", []entity.ReturnItem{{Quantity: 2, Amount: 19000}}, 19000},
+		{"several items", []entity.ReturnItem{{Quantity: 1, Amount: 9500}, {Quantity: 3, Amount: 30000}}, 39500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := refundableTotal(&entity.Return{Items: tt.items}); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
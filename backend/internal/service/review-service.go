@@ -0,0 +1,156 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+)
+
+type ReviewService interface {
+	GetBookReviews(bookID string) ([]*dto.ReviewResponse, *execption.ApiExecption)
+	CreateReview(userID uint, input binder.CreateReview) (*dto.ReviewResponse, *execption.ApiExecption)
+	UpdateReview(userID uint, input binder.UpdateReview) (*dto.ReviewResponse, *execption.ApiExecption)
+	DeleteReview(userID uint, reviewID string) *execption.ApiExecption
+}
+
+type reviewService struct {
+	reviewRepo repository.ReviewRepository
+	bookRepo   repository.BookRepository
+	orderRepo  repository.OrderRepository
+}
+
+func NewReviewService(reviewRepo repository.ReviewRepository, bookRepo repository.BookRepository, orderRepo repository.OrderRepository) ReviewService {
+	return &reviewService{reviewRepo: reviewRepo, bookRepo: bookRepo, orderRepo: orderRepo}
+}
+
+// GetBookReviews implements ReviewService.
+func (r *reviewService) GetBookReviews(bookID string) ([]*dto.ReviewResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(bookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := r.bookRepo.GetById(uint(uintID)); err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	reviews, err := r.reviewRepo.GetByBook(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.ReviewResponse{}
+	for i := range reviews {
+		responses = append(responses, toReviewResponse(&reviews[i]))
+	}
+
+	return responses, nil
+}
+
+// CreateReview implements ReviewService.
+func (r *reviewService) CreateReview(userID uint, input binder.CreateReview) (*dto.ReviewResponse, *execption.ApiExecption) {
+	bookID, err := strconv.ParseUint(input.BookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if _, err := r.bookRepo.GetById(uint(bookID)); err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	verified, err := r.orderRepo.HasPurchased(userID, uint(bookID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	review := &entity.Review{
+		BookID:           uint(bookID),
+		UserID:           userID,
+		Rating:           input.Rating,
+		Body:             input.Body,
+		VerifiedPurchase: verified,
+	}
+
+	review, err = r.reviewRepo.Create(review)
+	if err != nil {
+		if err == repository.ErrReviewAlreadyExists {
+			return nil, execption.NewApiExecption(http.StatusConflict, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toReviewResponse(review), nil
+}
+
+// UpdateReview implements ReviewService.
+func (r *reviewService) UpdateReview(userID uint, input binder.UpdateReview) (*dto.ReviewResponse, *execption.ApiExecption) {
+	review, apiErr := r.ownReview(userID, input.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// The customer may have bought the book since the review was written
+	verified, err := r.orderRepo.HasPurchased(userID, review.BookID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	review.Rating = input.Rating
+	review.Body = input.Body
+	review.VerifiedPurchase = verified
+
+	review, err = r.reviewRepo.Update(review)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toReviewResponse(review), nil
+}
+
+// DeleteReview implements ReviewService.
+func (r *reviewService) DeleteReview(userID uint, reviewID string) *execption.ApiExecption {
+	review, apiErr := r.ownReview(userID, reviewID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if err := r.reviewRepo.Delete(review); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *reviewService) ownReview(userID uint, reviewID string) (*entity.Review, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(reviewID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	review, err := r.reviewRepo.GetById(uint(uintID))
+	if err != nil || review.UserID != userID {
+		return nil, execption.NewApiExecption(http.StatusNotFound, repository.ErrReviewNotFound.Error())
+	}
+
+	return review, nil
+}
+
+func toReviewResponse(review *entity.Review) *dto.ReviewResponse {
+	return &dto.ReviewResponse{
+		ID:               review.ID,
+		BookID:           review.BookID,
+		UserID:           review.UserID,
+		Rating:           review.Rating,
+		Body:             review.Body,
+		VerifiedPurchase: review.VerifiedPurchase,
+		CreatedAt:        review.CreatedAt.String(),
+		CreatedTime:      review.CreatedAt,
+		UpdatedAt:        review.UpdatedAt.String(),
+		UpdatedTime:      review.UpdatedAt,
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+// volumetricDivisor converts a parcel's volume in mm³ to a weight in grams,
+// the same 6000 cm³/kg factor couriers use.
+const volumetricDivisor = 6000
+
+type shippingOption struct {
+	Method       *entity.ShippingMethod
+	Price        int
+	FreeShipping bool
+}
+
+// matchShippingZone returns the zone with the most specific region matching the
+// address. A postal code prefix beats a province and longer prefixes win.
+func matchShippingZone(zones []entity.ShippingZone, province string, postalCode string) *entity.ShippingZone {
+	var best *entity.ShippingZone
+	bestScore := 0
+
+	for i := range zones {
+		for _, region := range zones[i].Regions {
+			score := regionScore(region, province, postalCode)
+			if score > bestScore {
+				best = &zones[i]
+				bestScore = score
+			}
+		}
+	}
+
+	return best
+}
+
+func regionScore(region entity.ShippingZoneRegion, province string, postalCode string) int {
+	if region.Province == "" && region.PostalCodePrefix == "" {
+		return 0
+	}
+
+	score := 0
+	if region.Province != "" {
+		if !strings.EqualFold(strings.TrimSpace(region.Province), strings.TrimSpace(province)) {
+			return 0
+		}
+		score = 1
+	}
+
+	if region.PostalCodePrefix != "" {
+		if !strings.HasPrefix(strings.TrimSpace(postalCode), region.PostalCodePrefix) {
+			return 0
+		}
+		score += 1 + len(region.PostalCodePrefix)
+	}
+
+	return score
+}
+
+// chargeableWeight is the cart weight in grams, counting each book at the
+// larger of its actual and volumetric weight.
+func chargeableWeight(lines []*cartLine) int {
+	total := 0
+	for _, line := range lines {
+		weight := line.Book.WeightGrams
+		volumetric := line.Book.LengthMm * line.Book.WidthMm * line.Book.HeightMm / volumetricDivisor
+		if volumetric > weight {
+			weight = volumetric
+		}
+		total += weight * line.Quantity
+	}
+	return total
+}
+
+// ratePrice looks up the weight bracket for the weight. A method without a
+// matching bracket cannot ship the cart.
+func ratePrice(method *entity.ShippingMethod, weight int) (int, bool) {
+	for _, rate := range method.Rates {
+		if weight >= rate.MinWeightGrams && (rate.MaxWeightGrams == 0 || weight <= rate.MaxWeightGrams) {
+			return rate.Price, true
+		}
+	}
+	return 0, false
+}
+
+func shippingOptionsFor(zone *entity.ShippingZone, weight int, freeShipping bool) []shippingOption {
+	var options []shippingOption
+	for i := range zone.Methods {
+		method := &zone.Methods[i]
+
+		price, ok := ratePrice(method, weight)
+		if !ok {
+			continue
+		}
+
+		if freeShipping {
+			price = 0
+		}
+
+		options = append(options, shippingOption{Method: method, Price: price, FreeShipping: freeShipping})
+	}
+	return options
+}
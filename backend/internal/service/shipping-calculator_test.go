@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+func TestRatePrice(t *testing.T) {
+	method := &entity.ShippingMethod{Rates: []entity.ShippingRate{
+		{MinWeightGrams: 0, MaxWeightGrams: 1000, Price: 10000},
+		{MinWeightGrams: 1001, MaxWeightGrams: 5000, Price: 20000},
+		{MinWeightGrams: 5001, MaxWeightGrams: 0, Price: 35000},
+	}}
+	capped := &entity.ShippingMethod{Rates: []entity.ShippingRate{
+		{MinWeightGrams: 500, MaxWeightGrams: 2000, Price: 15000},
+	}}
+
+	tests := []struct {
+		name      string
+		method    *entity.ShippingMethod
+		weight    int
+		wantPrice int
+		wantOK    bool
+	}{
+		{"first bracket", method, 0, 10000, true},
+		{"upper bound is inclusive", method, 1000, 10000, true},
+		{"lower bound is inclusive", method, 1001, 20000, true},
+		{"open ended bracket", method, 250000, 35000, true},
+		{"below every bracket", capped, 499, 0, false},
+		{"above every bracket", capped, 2001, 0, false},
+		{"no brackets", &entity.ShippingMethod{}, 100, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, ok := ratePrice(tt.method, tt.weight)
+			if price != tt.wantPrice || ok != tt.wantOK {
+				t.Errorf("got %d, %v, want %d, %v", price, ok, tt.wantPrice, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestChargeableWeight(t *testing.T) {
+	paperback := &entity.Book{WeightGrams: 300, LengthMm: 200, WidthMm: 130, HeightMm: 20}
+	atlas := &entity.Book{WeightGrams: 800, LengthMm: 400, WidthMm: 300, HeightMm: 60}
+
+	tests := []struct {
+		name  string
+		lines []*cartLine
+		want  int
+	}{
+		{"actual weight", []*cartLine{newCartLine(paperback, 2, 0)}, 600},
+		{"volumetric weight", []*cartLine{newCartLine(atlas, 1, 0)}, 1200},
+		{"per line", []*cartLine{newCartLine(paperback, 1, 0), newCartLine(atlas, 2, 0)}, 2700},
+		{"empty cart", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chargeableWeight(tt.lines); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchShippingZone(t *testing.T) {
+	zones := []entity.ShippingZone{
+		{ID: 1, Regions: []entity.ShippingZoneRegion{{Province: "DKI Jakarta"}}},
+		{ID: 2, Regions: []entity.ShippingZoneRegion{{PostalCodePrefix: "10"}}},
+		{ID: 3, Regions: []entity.ShippingZoneRegion{{Province: "DKI Jakarta", PostalCodePrefix: "101"}}},
+		{ID: 4, Regions: []entity.ShippingZoneRegion{{}}},
+	}
+
+	tests := []struct {
+		name       string
+		province   string
+		postalCode string
+		want       uint
+	}{
+		{"province", " dki jakarta ", "12950", 1},
+		{"postal code beats province", "DKI Jakarta", "10210", 2},
+		{"longer prefix wins", "DKI Jakarta", "10110", 3},
+		{"no match", "Bali", "80361", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got uint
+			if zone := matchShippingZone(zones, tt.province, tt.postalCode); zone != nil {
+				got = zone.ID
+			}
+			if got != tt.want {
+				t.Errorf("got zone %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShippingOptionsFor(t *testing.T) {
+	zone := &entity.ShippingZone{Methods: []entity.ShippingMethod{
+		{Name: "Regular", Rates: []entity.ShippingRate{{MinWeightGrams: 0, MaxWeightGrams: 0, Price: 12000}}},
+		{Name: "Same day", Rates: []entity.ShippingRate{{MinWeightGrams: 0, MaxWeightGrams: 2000, Price: 30000}}},
+	}}
+
+	tests := []struct {
+		name         string
+		weight       int
+		freeShipping bool
+		want         map[string]int
+	}{
+		{"every method", 1500, false, map[string]int{"Regular": 12000, "Same day": 30000}},
+		{"too heavy for a method", 2500, false, map[string]int{"Regular": 12000}},
+		{"free shipping", 1500, true, map[string]int{"Regular": 0, "Same day": 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := shippingOptionsFor(zone, tt.weight, tt.freeShipping)
+			if len(options) != len(tt.want) {
+				t.Fatalf("got %d options, want %d", len(options), len(tt.want))
+			}
+			for _, option := range options {
+				if price, ok := tt.want[option.Method.Name]; !ok || option.Price != price || option.FreeShipping != tt.freeShipping {
+					t.Errorf("got %s at %d, want %d", option.Method.Name, option.Price, price)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+)
+
+type ShippingService interface {
+	GetShippingZones() ([]*dto.ShippingZoneResponse, *execption.ApiExecption)
+	GetShippingZone(zoneID string) (*dto.ShippingZoneResponse, *execption.ApiExecption)
+	CreateShippingZone(input binder.CreateShippingZone) (*dto.ShippingZoneResponse, *execption.ApiExecption)
+	UpdateShippingZone(input binder.UpdateShippingZone) (*dto.ShippingZoneResponse, *execption.ApiExecption)
+	DeleteShippingZone(zoneID string) *execption.ApiExecption
+	CreateShippingMethod(input binder.CreateShippingMethod) (*dto.ShippingMethodResponse, *execption.ApiExecption)
+	UpdateShippingMethod(input binder.UpdateShippingMethod) (*dto.ShippingMethodResponse, *execption.ApiExecption)
+	DeleteShippingMethod(methodID string) *execption.ApiExecption
+}
+
+type shippingService struct {
+	shippingRepo repository.ShippingRepository
+}
+
+func NewShippingService(shippingRepo repository.ShippingRepository) ShippingService {
+	return &shippingService{shippingRepo: shippingRepo}
+}
+
+func (s *shippingService) GetShippingZones() ([]*dto.ShippingZoneResponse, *execption.ApiExecption) {
+	zones, err := s.shippingRepo.GetAllZones()
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.ShippingZoneResponse{}
+	for i := range zones {
+		responses = append(responses, toShippingZoneResponse(&zones[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *shippingService) GetShippingZone(zoneID string) (*dto.ShippingZoneResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(zoneID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	zone, err := s.shippingRepo.GetZoneById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return toShippingZoneResponse(zone), nil
+}
+
+func (s *shippingService) CreateShippingZone(input binder.CreateShippingZone) (*dto.ShippingZoneResponse, *execption.ApiExecption) {
+	regions, apiErr := toShippingZoneRegions(input.Regions)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	zone, err := s.shippingRepo.CreateZone(&entity.ShippingZone{Name: input.Name, Regions: regions})
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toShippingZoneResponse(zone), nil
+}
+
+func (s *shippingService) UpdateShippingZone(input binder.UpdateShippingZone) (*dto.ShippingZoneResponse, *execption.ApiExecption) {
+	zoneID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	regions, apiErr := toShippingZoneRegions(input.Regions)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	zone, err := s.shippingRepo.UpdateZone(&entity.ShippingZone{ID: uint(zoneID), Name: input.Name, Regions: regions})
+	if err != nil {
+		if err == repository.ErrShippingZoneNotFound {
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toShippingZoneResponse(zone), nil
+}
+
+func (s *shippingService) DeleteShippingZone(zoneID string) *execption.ApiExecption {
+	uintID, err := strconv.ParseUint(zoneID, 10, 0)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if err := s.shippingRepo.DeleteZone(uint(uintID)); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+func (s *shippingService) CreateShippingMethod(input binder.CreateShippingMethod) (*dto.ShippingMethodResponse, *execption.ApiExecption) {
+	if _, err := s.shippingRepo.GetZoneById(input.ShippingZoneID); err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Shipping zone does not exist")
+	}
+
+	rates, apiErr := toShippingRates(input.Rates)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	method, err := s.shippingRepo.CreateMethod(&entity.ShippingMethod{
+		ShippingZoneID: input.ShippingZoneID,
+		Name:           input.Name,
+		Rates:          rates,
+	})
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toShippingMethodResponse(method), nil
+}
+
+func (s *shippingService) UpdateShippingMethod(input binder.UpdateShippingMethod) (*dto.ShippingMethodResponse, *execption.ApiExecption) {
+	methodID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	rates, apiErr := toShippingRates(input.Rates)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	method, err := s.shippingRepo.UpdateMethod(&entity.ShippingMethod{ID: uint(methodID), Name: input.Name, Rates: rates})
+	if err != nil {
+		if err == repository.ErrShippingMethodNotFound {
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toShippingMethodResponse(method), nil
+}
+
+func (s *shippingService) DeleteShippingMethod(methodID string) *execption.ApiExecption {
+	uintID, err := strconv.ParseUint(methodID, 10, 0)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if err := s.shippingRepo.DeleteMethod(uint(uintID)); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+func toShippingZoneRegions(inputs []binder.ShippingZoneRegion) ([]entity.ShippingZoneRegion, *execption.ApiExecption) {
+	var regions []entity.ShippingZoneRegion
+	for _, input := range inputs {
+		if input.Province == "" && input.PostalCodePrefix == "" {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "Each region needs a province or a postal code prefix")
+		}
+		regions = append(regions, entity.ShippingZoneRegion{
+			Province:         input.Province,
+			PostalCodePrefix: input.PostalCodePrefix,
+		})
+	}
+	return regions, nil
+}
+
+func toShippingRates(inputs []binder.ShippingRate) ([]entity.ShippingRate, *execption.ApiExecption) {
+	var rates []entity.ShippingRate
+	for _, input := range inputs {
+		if input.MaxWeightGrams != 0 && input.MaxWeightGrams < input.MinWeightGrams {
+			return nil, execption.NewApiExecption(http.StatusBadRequest, "max_weight_grams must not be lower than min_weight_grams")
+		}
+		rates = append(rates, entity.ShippingRate{
+			MinWeightGrams: input.MinWeightGrams,
+			MaxWeightGrams: input.MaxWeightGrams,
+			Price:          input.Price,
+		})
+	}
+	return rates, nil
+}
+
+func toShippingZoneResponse(zone *entity.ShippingZone) *dto.ShippingZoneResponse {
+	response := &dto.ShippingZoneResponse{
+		ID:        zone.ID,
+		Name:      zone.Name,
+		Regions:   []dto.ShippingZoneRegionResponse{},
+		Methods:   []dto.ShippingMethodResponse{},
+		CreatedAt: zone.CreatedAt.String(),
+		UpdatedAt: zone.UpdatedAt.String(),
+	}
+
+	for _, region := range zone.Regions {
+		response.Regions = append(response.Regions, dto.ShippingZoneRegionResponse{
+			Province:         region.Province,
+			PostalCodePrefix: region.PostalCodePrefix,
+		})
+	}
+
+	for i := range zone.Methods {
+		response.Methods = append(response.Methods, *toShippingMethodResponse(&zone.Methods[i]))
+	}
+
+	return response
+}
+
+func toShippingMethodResponse(method *entity.ShippingMethod) *dto.ShippingMethodResponse {
+	response := &dto.ShippingMethodResponse{
+		ID:             method.ID,
+		ShippingZoneID: method.ShippingZoneID,
+		Name:           method.Name,
+		Rates:          []dto.ShippingRateResponse{},
+		CreatedAt:      method.CreatedAt.String(),
+		UpdatedAt:      method.UpdatedAt.String(),
+	}
+
+	for _, rate := range method.Rates {
+		response.Rates = append(response.Rates, dto.ShippingRateResponse{
+			MinWeightGrams: rate.MinWeightGrams,
+			MaxWeightGrams: rate.MaxWeightGrams,
+			Price:          rate.Price,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// similarityStopWords are dropped before indexing. The catalogue mixes
+// Indonesian and English text, so both are covered.
+var similarityStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "has": true, "he": true, "in": true, "is": true, "it": true, "its": true,
+	"of": true, "on": true, "or": true, "she": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "were": true, "will": true, "with": true, "his": true, "her": true, "their": true,
+	"yang": true, "dan": true, "di": true, "ke": true, "dari": true, "ini": true, "itu": true, "dengan": true,
+	"untuk": true, "pada": true, "adalah": true, "dalam": true, "tidak": true, "akan": true, "oleh": true,
+	"sebagai": true, "juga": true, "atau": true, "karena": true, "bisa": true, "ada": true, "para": true,
+}
+
+// similarityMatch is a book and its cosine similarity to the queried book.
+type similarityMatch struct {
+	BookID uint
+	Score  float64
+}
+
+// similarityIndex is an in-memory TF-IDF index over book titles and
+// descriptions. Term frequencies are stored per book and weights are derived
+// at query time, so adding or removing a book only touches that book's terms.
+type similarityIndex struct {
+	mu       sync.RWMutex
+	terms    map[uint]map[string]int
+	docFreqs map[string]int
+}
+
+func newSimilarityIndex() *similarityIndex {
+	return &similarityIndex{
+		terms:    map[uint]map[string]int{},
+		docFreqs: map[string]int{},
+	}
+}
+
+// upsert indexes a book, replacing any earlier version of it.
+func (s *similarityIndex) upsert(bookID uint, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(bookID)
+
+	freqs := map[string]int{}
+	for _, token := range tokenize(text) {
+		freqs[token]++
+	}
+
+	s.terms[bookID] = freqs
+	for term := range freqs {
+		s.docFreqs[term]++
+	}
+}
+
+// delete drops a book from the index.
+func (s *similarityIndex) delete(bookID uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(bookID)
+}
+
+func (s *similarityIndex) remove(bookID uint) {
+	freqs, ok := s.terms[bookID]
+	if !ok {
+		return
+	}
+
+	for term := range freqs {
+		s.docFreqs[term]--
+		if s.docFreqs[term] <= 0 {
+			delete(s.docFreqs, term)
+		}
+	}
+	delete(s.terms, bookID)
+}
+
+// similar returns up to limit books ordered by cosine similarity to bookID.
+// Books with nothing in common are left out.
+func (s *similarityIndex) similar(bookID uint, limit int) []similarityMatch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	freqs, ok := s.terms[bookID]
+	if !ok {
+		return nil
+	}
+
+	query, queryNorm := s.vector(freqs)
+	if queryNorm == 0 {
+		return nil
+	}
+
+	matches := []similarityMatch{}
+	for otherID, otherFreqs := range s.terms {
+		if otherID == bookID {
+			continue
+		}
+
+		other, otherNorm := s.vector(otherFreqs)
+		if otherNorm == 0 {
+			continue
+		}
+
+		dot := 0.0
+		for term, weight := range query {
+			dot += weight * other[term]
+		}
+		if dot == 0 {
+			continue
+		}
+
+		matches = append(matches, similarityMatch{BookID: otherID, Score: dot / (queryNorm * otherNorm)})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].BookID < matches[j].BookID
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// vector weights term frequencies with a smoothed inverse document frequency
+// and returns the weights with their euclidean norm.
+func (s *similarityIndex) vector(freqs map[string]int) (map[string]float64, float64) {
+	docs := float64(len(s.terms))
+	weights := make(map[string]float64, len(freqs))
+	norm := 0.0
+
+	for term, freq := range freqs {
+		idf := math.Log((1+docs)/(1+float64(s.docFreqs[term]))) + 1
+		weight := (1 + math.Log(float64(freq))) * idf
+		weights[term] = weight
+		norm += weight * weight
+	}
+
+	return weights, math.Sqrt(norm)
+}
+
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < 2 || similarityStopWords[field] {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
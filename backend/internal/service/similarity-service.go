@@ -0,0 +1,133 @@
+package service
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+type SimilarityService interface {
+	GetSimilarBooks(bookID string) ([]*dto.SimilarBookResponse, *execption.ApiExecption)
+	IndexBook(book *entity.Book)
+	RemoveBook(bookID uint)
+}
+
+type similarityService struct {
+	bookRepo    repository.BookRepository
+	fileStorage storage.Storage
+	limit       int
+
+	mu     sync.Mutex
+	loaded bool
+	index  *similarityIndex
+}
+
+func NewSimilarityService(bookRepo repository.BookRepository, fileStorage storage.Storage, cfg *config.Config) SimilarityService {
+	return &similarityService{bookRepo: bookRepo, fileStorage: fileStorage, limit: cfg.Recommendation.Limit, index: newSimilarityIndex()}
+}
+
+// GetSimilarBooks implements SimilarityService.
+func (s *similarityService) GetSimilarBooks(bookID string) ([]*dto.SimilarBookResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(bookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if err := s.load(); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	book, err := s.bookRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	// The book may have been written by another process since the index was loaded
+	s.index.upsert(book.ID, similarityText(book))
+
+	matches := s.index.similar(book.ID, s.limit)
+	if len(matches) == 0 {
+		return []*dto.SimilarBookResponse{}, nil
+	}
+
+	ids := make([]uint, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.BookID)
+	}
+
+	var books []*entity.Book
+	if err := s.bookRepo.FindByIDs(ids, &books); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	booksByID := make(map[uint]*entity.Book, len(books))
+	for _, book := range books {
+		booksByID[book.ID] = book
+	}
+
+	responses := []*dto.SimilarBookResponse{}
+	for _, match := range matches {
+		book, ok := booksByID[match.BookID]
+		if !ok {
+			s.index.delete(match.BookID)
+			continue
+		}
+		responses = append(responses, &dto.SimilarBookResponse{
+			Book:  toBookResponse(book, s.fileStorage),
+			Score: math.Round(match.Score*10000) / 10000,
+		})
+	}
+
+	return responses, nil
+}
+
+// IndexBook implements SimilarityService. It is called after a book is created
+// or updated so only that book is re-indexed.
+func (s *similarityService) IndexBook(book *entity.Book) {
+	if s.load() != nil {
+		// The full load on the next query will pick the book up
+		return
+	}
+	s.index.upsert(book.ID, similarityText(book))
+}
+
+// RemoveBook implements SimilarityService.
+func (s *similarityService) RemoveBook(bookID uint) {
+	s.index.delete(bookID)
+}
+
+// load fills the index from the database on first use.
+func (s *similarityService) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded {
+		return nil
+	}
+
+	books, err := s.bookRepo.GetAll(repository.BookQuery{})
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		s.index.upsert(books[i].ID, similarityText(&books[i]))
+	}
+	s.loaded = true
+
+	return nil
+}
+
+// similarityText is the text indexed for a book. The title is repeated so its
+// words weigh more than the description's.
+func similarityText(book *entity.Book) string {
+	return book.Title + " " + book.Title + " " + book.Description
+}
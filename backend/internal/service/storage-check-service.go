@@ -0,0 +1,145 @@
+package service
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+// bookImagePrefix is where saveImage stores book images and their variants.
+const bookImagePrefix = "books/"
+
+type StorageCheckService interface {
+	CheckStorage(deleteOrphans bool) (*dto.StorageReport, error)
+	RunStorageCheck() error
+}
+
+type storageCheckService struct {
+	bookImageRepo repository.BookImageRepository
+	imageFileRepo repository.ImageFileRepository
+	fileStorage   storage.Storage
+	config        config.StorageConfig
+}
+
+func NewStorageCheckService(bookImageRepo repository.BookImageRepository, imageFileRepo repository.ImageFileRepository, fileStorage storage.Storage, cfg *config.Config) StorageCheckService {
+	return &storageCheckService{bookImageRepo: bookImageRepo, imageFileRepo: imageFileRepo, fileStorage: fileStorage, config: cfg.Storage}
+}
+
+// CheckStorage implements StorageCheckService. It compares the stored book
+// images with the ones book_images points to. Unused files younger than the
+// grace period are only counted, since an upload may still be on its way
+// into the database.
+func (s *storageCheckService) CheckStorage(deleteOrphans bool) (*dto.StorageReport, error) {
+	now := time.Now()
+
+	// References are loaded before listing, so a file uploaded in between
+	// is at worst a young orphan
+	images, err := s.bookImageRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	referenced := imageKeys(images)
+
+	objects, err := s.fileStorage.List(bookImagePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.StorageReport{
+		CheckedAt: now.String(),
+		Files:     len(objects),
+		Orphans:   []dto.StorageOrphan{},
+		Missing:   []dto.StorageMissingFile{},
+	}
+
+	stored := map[string]bool{}
+	for _, object := range objects {
+		stored[object.Key] = true
+		if _, ok := referenced[object.Key]; ok {
+			continue
+		}
+
+		if now.Sub(object.ModTime) < s.config.OrphanGracePeriod {
+			report.PendingOrphans++
+			continue
+		}
+
+		orphan := dto.StorageOrphan{Key: object.Key, Size: object.Size, ModifiedAt: object.ModTime.String()}
+		if deleteOrphans {
+			// The record goes first, so a new upload of the same image
+			// stores it again instead of reusing the deleted file
+			if err := s.imageFileRepo.Forget(object.Key); err != nil {
+				log.Printf("failed to forget orphaned file %s: %v", object.Key, err)
+			} else if err := s.fileStorage.Delete(object.Key); err != nil {
+				log.Printf("failed to delete orphaned file %s: %v", object.Key, err)
+			} else {
+				orphan.Deleted = true
+			}
+		}
+		report.Orphans = append(report.Orphans, orphan)
+	}
+
+	// Images deleted while the storage was listed would look missing, so
+	// only the ones still there are reported
+	images, err = s.bookImageRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for key, image := range imageKeys(images) {
+		if !stored[key] {
+			report.Missing = append(report.Missing, dto.StorageMissingFile{BookID: image.BookID, ImageID: image.ID, Key: key})
+		}
+	}
+	sort.Slice(report.Missing, func(i, j int) bool {
+		if report.Missing[i].ImageID != report.Missing[j].ImageID {
+			return report.Missing[i].ImageID < report.Missing[j].ImageID
+		}
+		return report.Missing[i].Key < report.Missing[j].Key
+	})
+
+	return report, nil
+}
+
+// RunStorageCheck implements StorageCheckService. It is the scheduled form
+// of CheckStorage and logs what it found.
+func (s *storageCheckService) RunStorageCheck() error {
+	report, err := s.CheckStorage(s.config.DeleteOrphans)
+	if err != nil {
+		return err
+	}
+
+	deleted := 0
+	for _, orphan := range report.Orphans {
+		if orphan.Deleted {
+			deleted++
+		} else {
+			log.Printf("storage check: %s is not used by any book", orphan.Key)
+		}
+	}
+	for _, missing := range report.Missing {
+		log.Printf("storage check: image %d of book %d points to missing file %s", missing.ImageID, missing.BookID, missing.Key)
+	}
+
+	log.Printf("storage check: %d files, %d orphaned (%d deleted, %d within the grace period), %d missing",
+		report.Files, len(report.Orphans), deleted, report.PendingOrphans, len(report.Missing))
+	return nil
+}
+
+// imageKeys maps every storage key used by the images, variants included,
+// to the image using it.
+func imageKeys(images []entity.BookImage) map[string]*entity.BookImage {
+	keys := map[string]*entity.BookImage{}
+	for i := range images {
+		keys[images[i].Path] = &images[i]
+		for _, key := range images[i].Variants {
+			keys[key] = &images[i]
+		}
+	}
+	return keys
+}
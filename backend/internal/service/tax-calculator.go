@@ -0,0 +1,51 @@
+package service
+
+import (
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+// taxRateFor returns the rate in basis points for a book. When a book sits in
+// several taxed categories the highest rate wins, so the result does not depend
+// on category order.
+func taxRateFor(book *entity.Book, taxConfig config.TaxConfig) int {
+	rate := -1
+	for _, category := range book.Categories {
+		if category.TaxRate != nil && category.TaxRate.Rate > rate {
+			rate = category.TaxRate.Rate
+		}
+	}
+
+	if rate < 0 {
+		return taxConfig.DefaultRate
+	}
+	return rate
+}
+
+// applyTax sets the tax on every line from its discounted amount. All amounts
+// are integer minor units and each line is rounded half up on its own.
+func applyTax(lines []*cartLine, taxConfig config.TaxConfig) int {
+	total := 0
+	for _, line := range lines {
+		line.TaxRate = taxRateFor(line.Book, taxConfig)
+		line.Tax = taxOn(line.remaining(), line.TaxRate, taxConfig.PricesIncludeTax)
+		total += line.Tax
+	}
+	return total
+}
+
+func taxOn(amount int, rate int, inclusive bool) int {
+	if rate == 0 || amount == 0 {
+		return 0
+	}
+
+	a := int64(amount)
+	r := int64(rate)
+
+	if inclusive {
+		net := (a*10000 + (10000+r)/2) / (10000 + r)
+		return int(a - net)
+	}
+
+	return int((a*r + 5000) / 10000)
+}
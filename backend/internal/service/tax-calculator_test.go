@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+)
+
+func TestTaxOn(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    int
+		rate      int
+		inclusive bool
+		want      int
+	}{
+		{"exclusive", 10000, 1100, false, 1100},
+		{"exclusive rounds half up", 995, 1000, false, 100},
+		{"exclusive rounds down below half", 994, 1000, false, 99},
+		{"inclusive", 11100, 1100, true, 1100},
+		{"inclusive rounds the net amount", 1000, 1100, true, 99},
+		{"inclusive rounds half up", 21, 1000, true, 2},
+		{"zero rate", 10000, 0, false, 0},
+		{"zero amount", 0, 1100, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taxOn(tt.amount, tt.rate, tt.inclusive); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaxRateFor(t *testing.T) {
+	taxConfig := config.TaxConfig{DefaultRate: 500}
+	reduced := entity.Category{TaxRate: &entity.TaxRate{Rate: 500}}
+	standard := entity.Category{TaxRate: &entity.TaxRate{Rate: 1100}}
+	exempt := entity.Category{TaxRate: &entity.TaxRate{Rate: 0}}
+	untaxed := entity.Category{}
+
+	tests := []struct {
+		name       string
+		categories []entity.Category
+		want       int
+	}{
+		{"no categories", nil, 500},
+		{"categories without a rate", []entity.Category{untaxed}, 500},
+		{"category rate", []entity.Category{untaxed, standard}, 1100},
+		{"highest rate", []entity.Category{standard, reduced}, 1100},
+		{"zero rate is not the default", []entity.Category{exempt}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taxRateFor(&entity.Book{Categories: tt.categories}, taxConfig); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTax(t *testing.T) {
+	book := &entity.Book{Categories: []entity.Category{{TaxRate: &entity.TaxRate{Rate: 1100}}}}
+
+	tests := []struct {
+		name      string
+		inclusive bool
+		wantTax   int
+		wantTotal int
+	}{
+		{"exclusive adds the tax", false, 1051, 10606},
+		{"inclusive takes the tax out", true, 946, 9555},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discounted := newCartLine(book, 1, 5000)
+			discounted.Discount = 445
+			lines := []*cartLine{newCartLine(book, 1, 5000), discounted}
+
+			if got := applyTax(lines, config.TaxConfig{PricesIncludeTax: tt.inclusive}); got != tt.wantTax {
+				t.Errorf("tax %d, want %d", got, tt.wantTax)
+			}
+
+			total := 0
+			for _, line := range lines {
+				total += line.total(tt.inclusive)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("total %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+)
+
+type TaxRateService interface {
+	GetTaxRates() ([]*dto.TaxRateResponse, *execption.ApiExecption)
+	GetTaxRate(taxRateID string) (*dto.TaxRateResponse, *execption.ApiExecption)
+	CreateTaxRate(input binder.CreateTaxRate) (*dto.TaxRateResponse, *execption.ApiExecption)
+	UpdateTaxRate(input binder.UpdateTaxRate) (*dto.TaxRateResponse, *execption.ApiExecption)
+	DeleteTaxRate(taxRateID string) *execption.ApiExecption
+}
+
+type taxRateService struct {
+	taxRateRepo repository.TaxRateRepository
+}
+
+func NewTaxRateService(taxRateRepo repository.TaxRateRepository) TaxRateService {
+	return &taxRateService{taxRateRepo: taxRateRepo}
+}
+
+func (s *taxRateService) GetTaxRates() ([]*dto.TaxRateResponse, *execption.ApiExecption) {
+	taxRates, err := s.taxRateRepo.GetAll()
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.TaxRateResponse{}
+	for i := range taxRates {
+		responses = append(responses, toTaxRateResponse(&taxRates[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *taxRateService) GetTaxRate(taxRateID string) (*dto.TaxRateResponse, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(taxRateID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	taxRate, err := s.taxRateRepo.GetById(uint(uintID))
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return toTaxRateResponse(taxRate), nil
+}
+
+func (s *taxRateService) CreateTaxRate(input binder.CreateTaxRate) (*dto.TaxRateResponse, *execption.ApiExecption) {
+	taxRate := &entity.TaxRate{
+		Name: input.Name,
+		Rate: input.Rate,
+	}
+
+	taxRate, err := s.taxRateRepo.Create(taxRate)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toTaxRateResponse(taxRate), nil
+}
+
+func (s *taxRateService) UpdateTaxRate(input binder.UpdateTaxRate) (*dto.TaxRateResponse, *execption.ApiExecption) {
+	taxRateID, err := strconv.ParseUint(input.ID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	taxRate := &entity.TaxRate{
+		ID:   uint(taxRateID),
+		Name: input.Name,
+		Rate: input.Rate,
+	}
+
+	taxRate, err = s.taxRateRepo.Update(taxRate)
+	if err != nil {
+		if err == repository.ErrTaxRateNotFound {
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return toTaxRateResponse(taxRate), nil
+}
+
+func (s *taxRateService) DeleteTaxRate(taxRateID string) *execption.ApiExecption {
+	uintID, err := strconv.ParseUint(taxRateID, 10, 0)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if err := s.taxRateRepo.Delete(uint(uintID)); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+func toTaxRateResponse(taxRate *entity.TaxRate) *dto.TaxRateResponse {
+	return &dto.TaxRateResponse{
+		ID:        taxRate.ID,
+		Name:      taxRate.Name,
+		Rate:      taxRate.Rate,
+		CreatedAt: taxRate.CreatedAt.String(),
+		UpdatedAt: taxRate.UpdatedAt.String(),
+	}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/signer"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+	"github.com/aws-cakap-intern/book-store/pkg/upload"
+	"github.com/google/uuid"
+)
+
+// uploadPrefix is where direct uploads wait until a book uses them.
+const uploadPrefix = "incoming/"
+
+type UploadService interface {
+	CreateUpload(input binder.CreateUpload) (*dto.UploadResponse, *execption.ApiExecption)
+	PutUpload(input binder.PutUpload, content io.Reader) *execption.ApiExecption
+	StoreUpload(image *upload.Image) (*dto.UploadPreviewResponse, *execption.ApiExecption)
+	OpenUpload(uploadID string) (*upload.Image, *execption.ApiExecption)
+	FinishUpload(uploadID string)
+	ExpireUploads() error
+}
+
+type uploadService struct {
+	uploadRepo     repository.UploadRepository
+	fileStorage    storage.Storage
+	imageValidator *upload.ImageValidator
+	urlSigner      *signer.Signer
+	config         config.UploadConfig
+}
+
+func NewUploadService(uploadRepo repository.UploadRepository, fileStorage storage.Storage, imageValidator *upload.ImageValidator, urlSigner *signer.Signer, cfg *config.Config) UploadService {
+	return &uploadService{uploadRepo: uploadRepo, fileStorage: fileStorage, imageValidator: imageValidator, urlSigner: urlSigner, config: cfg.Upload}
+}
+
+// CreateUpload implements UploadService. Storages that can presign hand out
+// a URL to upload to them directly; local storage gets a signed link to
+// PutUpload instead.
+func (u *uploadService) CreateUpload(input binder.CreateUpload) (*dto.UploadResponse, *execption.ApiExecption) {
+	if err := u.imageValidator.CheckDeclared(input.ContentType, input.Size); err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	now := time.Now()
+	newUpload := &entity.Upload{
+		ID:          uuid.New().String(),
+		ContentType: input.ContentType,
+		Size:        input.Size,
+		ExpiresAt:   now.Add(u.config.ClaimPeriod),
+	}
+	newUpload.ObjectKey = uploadPrefix + newUpload.ID
+
+	urlExpires := now.Add(u.config.URLExpiry)
+
+	var url string
+	if presigner, ok := u.fileStorage.(storage.Presigner); ok {
+		presigned, err := presigner.PresignPut(newUpload.ObjectKey, input.ContentType, u.config.URLExpiry)
+		if err != nil {
+			return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error creating upload URL")
+		}
+		url = presigned
+	} else {
+		url = strings.TrimRight(u.config.DirectURL, "/") + "/" + newUpload.ID + "?" + u.urlSigner.Query(uploadResource(newUpload.ID), urlExpires).Encode()
+	}
+
+	if _, err := u.uploadRepo.Create(newUpload); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return &dto.UploadResponse{
+		UploadID:  newUpload.ID,
+		Method:    http.MethodPut,
+		URL:       url,
+		Headers:   map[string]string{"Content-Type": input.ContentType},
+		ExpiresAt: urlExpires.String(),
+	}, nil
+}
+
+// PutUpload implements UploadService. It receives direct uploads for local
+// storage, which has no presigned URLs of its own. The image is checked
+// before it is stored, since local files are served as they are.
+func (u *uploadService) PutUpload(input binder.PutUpload, content io.Reader) *execption.ApiExecption {
+	if err := u.urlSigner.Verify(uploadResource(input.ID), input.Expires, input.Signature); err != nil {
+		if err == signer.ErrExpired {
+			return execption.NewApiExecption(http.StatusForbidden, "Upload link has expired")
+		}
+		return execption.NewApiExecption(http.StatusForbidden, "Invalid upload link")
+	}
+
+	pending, err := u.uploadRepo.GetById(input.ID)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	image, err := u.imageValidator.ValidateReader(content)
+	if err != nil {
+		return execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if err := u.fileStorage.Put(pending.ObjectKey, image.File, image.Size, image.ContentType); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, "Error saving image")
+	}
+
+	return nil
+}
+
+// StoreUpload implements UploadService. It turns an image the server already
+// has into an upload, so it can be used by a book later.
+func (u *uploadService) StoreUpload(image *upload.Image) (*dto.UploadPreviewResponse, *execption.ApiExecption) {
+	newUpload := &entity.Upload{
+		ID:          uuid.New().String(),
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		ExpiresAt:   time.Now().Add(u.config.ClaimPeriod),
+	}
+	newUpload.ObjectKey = uploadPrefix + newUpload.ID
+
+	if err := u.fileStorage.Put(newUpload.ObjectKey, image.File, image.Size, image.ContentType); err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error saving image")
+	}
+
+	if _, err := u.uploadRepo.Create(newUpload); err != nil {
+		deleteFile(u.fileStorage, newUpload.ObjectKey)
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return &dto.UploadPreviewResponse{
+		UploadID:    newUpload.ID,
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+		URL:         u.fileStorage.URL(newUpload.ObjectKey),
+		ExpiresAt:   newUpload.ExpiresAt.String(),
+	}, nil
+}
+
+// OpenUpload implements UploadService. The uploaded file is validated like
+// an image sent with a form, since the client may have sent anything.
+func (u *uploadService) OpenUpload(uploadID string) (*upload.Image, *execption.ApiExecption) {
+	pending, err := u.uploadRepo.GetById(uploadID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Upload not found or expired")
+	}
+
+	file, err := u.fileStorage.Get(pending.ObjectKey)
+	if err == storage.ErrObjectNotFound {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, "Nothing has been uploaded for this upload yet")
+	}
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, "Error reading upload")
+	}
+	defer file.Close()
+
+	image, err := u.imageValidator.ValidateReader(file)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	return image, nil
+}
+
+// FinishUpload implements UploadService. It is called once a book has stored
+// its own copy of the image, so failures are only logged; the expiry job
+// removes whatever is left.
+func (u *uploadService) FinishUpload(uploadID string) {
+	pending, err := u.uploadRepo.GetById(uploadID)
+	if err != nil {
+		return
+	}
+
+	deleteFile(u.fileStorage, pending.ObjectKey)
+	if err := u.uploadRepo.Delete(pending); err != nil {
+		log.Printf("failed to delete upload %s: %v", pending.ID, err)
+	}
+}
+
+// ExpireUploads implements UploadService. Uploads whose file cannot be
+// deleted are kept, so the next run tries again.
+func (u *uploadService) ExpireUploads() error {
+	expired, err := u.uploadRepo.GetExpired(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range expired {
+		if err := u.fileStorage.Delete(expired[i].ObjectKey); err != nil {
+			log.Printf("failed to delete expired upload %s: %v", expired[i].ID, err)
+			continue
+		}
+		if err := u.uploadRepo.Delete(&expired[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadResource names an upload in signed links.
+func uploadResource(uploadID string) string {
+	return "upload:" + uploadID
+}
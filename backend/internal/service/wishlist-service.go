@@ -0,0 +1,248 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"github.com/aws-cakap-intern/book-store/internal/dto"
+	"github.com/aws-cakap-intern/book-store/internal/entity"
+	"github.com/aws-cakap-intern/book-store/internal/http/binder"
+	"github.com/aws-cakap-intern/book-store/internal/repository"
+	"github.com/aws-cakap-intern/book-store/pkg/execption"
+	"github.com/aws-cakap-intern/book-store/pkg/storage"
+)
+
+type WishlistService interface {
+	GetWishlists(userID uint) ([]*dto.WishlistResponse, *execption.ApiExecption)
+	GetWishlist(userID uint, wishlistID string) (*dto.WishlistResponse, *execption.ApiExecption)
+	GetSharedWishlist(token string) (*dto.WishlistResponse, *execption.ApiExecption)
+	CreateWishlist(userID uint, input binder.CreateWishlist) (*dto.WishlistResponse, *execption.ApiExecption)
+	UpdateWishlist(userID uint, input binder.UpdateWishlist) (*dto.WishlistResponse, *execption.ApiExecption)
+	DeleteWishlist(userID uint, wishlistID string) *execption.ApiExecption
+	ResetShareToken(userID uint, wishlistID string) (*dto.WishlistResponse, *execption.ApiExecption)
+	AddItem(userID uint, input binder.AddWishlistItem) (*dto.WishlistResponse, *execption.ApiExecption)
+	RemoveItem(userID uint, input binder.RemoveWishlistItem) (*dto.WishlistResponse, *execption.ApiExecption)
+}
+
+type wishlistService struct {
+	wishlistRepo repository.WishlistRepository
+	bookRepo     repository.BookRepository
+	fileStorage  storage.Storage
+}
+
+func NewWishlistService(wishlistRepo repository.WishlistRepository, bookRepo repository.BookRepository, fileStorage storage.Storage) WishlistService {
+	return &wishlistService{wishlistRepo: wishlistRepo, bookRepo: bookRepo, fileStorage: fileStorage}
+}
+
+// GetWishlists implements WishlistService.
+func (w *wishlistService) GetWishlists(userID uint) ([]*dto.WishlistResponse, *execption.ApiExecption) {
+	wishlists, err := w.wishlistRepo.GetAllByUser(userID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	responses := []*dto.WishlistResponse{}
+	for i := range wishlists {
+		responses = append(responses, w.toWishlistResponse(&wishlists[i], true))
+	}
+
+	return responses, nil
+}
+
+// GetWishlist implements WishlistService.
+func (w *wishlistService) GetWishlist(userID uint, wishlistID string) (*dto.WishlistResponse, *execption.ApiExecption) {
+	wishlist, apiErr := w.ownWishlist(userID, wishlistID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	return w.toWishlistResponse(wishlist, true), nil
+}
+
+// GetSharedWishlist implements WishlistService.
+func (w *wishlistService) GetSharedWishlist(token string) (*dto.WishlistResponse, *execption.ApiExecption) {
+	wishlist, err := w.wishlistRepo.GetByShareToken(token)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	return w.toWishlistResponse(wishlist, false), nil
+}
+
+// CreateWishlist implements WishlistService.
+func (w *wishlistService) CreateWishlist(userID uint, input binder.CreateWishlist) (*dto.WishlistResponse, *execption.ApiExecption) {
+	shareToken, err := newShareToken()
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	wishlist, err := w.wishlistRepo.Create(&entity.Wishlist{
+		UserID:     userID,
+		Name:       input.Name,
+		ShareToken: shareToken,
+	})
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return w.toWishlistResponse(wishlist, true), nil
+}
+
+// UpdateWishlist implements WishlistService.
+func (w *wishlistService) UpdateWishlist(userID uint, input binder.UpdateWishlist) (*dto.WishlistResponse, *execption.ApiExecption) {
+	wishlist, apiErr := w.ownWishlist(userID, input.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	wishlist.Name = input.Name
+
+	wishlist, err := w.wishlistRepo.Update(wishlist)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return w.toWishlistResponse(wishlist, true), nil
+}
+
+// DeleteWishlist implements WishlistService.
+func (w *wishlistService) DeleteWishlist(userID uint, wishlistID string) *execption.ApiExecption {
+	wishlist, apiErr := w.ownWishlist(userID, wishlistID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if err := w.wishlistRepo.Delete(wishlist.ID); err != nil {
+		return execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return nil
+}
+
+// ResetShareToken implements WishlistService. The old link stops working.
+func (w *wishlistService) ResetShareToken(userID uint, wishlistID string) (*dto.WishlistResponse, *execption.ApiExecption) {
+	wishlist, apiErr := w.ownWishlist(userID, wishlistID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	shareToken, err := newShareToken()
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+	wishlist.ShareToken = shareToken
+
+	wishlist, err = w.wishlistRepo.Update(wishlist)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return w.toWishlistResponse(wishlist, true), nil
+}
+
+// AddItem implements WishlistService.
+func (w *wishlistService) AddItem(userID uint, input binder.AddWishlistItem) (*dto.WishlistResponse, *execption.ApiExecption) {
+	wishlist, apiErr := w.ownWishlist(userID, input.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	book, err := w.bookRepo.GetById(input.BookID)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+	}
+
+	err = w.wishlistRepo.AddItem(&entity.WishlistItem{
+		WishlistID:     wishlist.ID,
+		BookID:         book.ID,
+		PriceWhenAdded: book.Price,
+	})
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return w.GetWishlist(userID, input.ID)
+}
+
+// RemoveItem implements WishlistService.
+func (w *wishlistService) RemoveItem(userID uint, input binder.RemoveWishlistItem) (*dto.WishlistResponse, *execption.ApiExecption) {
+	wishlist, apiErr := w.ownWishlist(userID, input.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	bookID, err := strconv.ParseUint(input.BookID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	if err := w.wishlistRepo.RemoveItem(wishlist.ID, uint(bookID)); err != nil {
+		if err == repository.ErrWishlistItemNotFound {
+			return nil, execption.NewApiExecption(http.StatusNotFound, err.Error())
+		}
+		return nil, execption.NewApiExecption(http.StatusInternalServerError, err.Error())
+	}
+
+	return w.GetWishlist(userID, input.ID)
+}
+
+func (w *wishlistService) ownWishlist(userID uint, wishlistID string) (*entity.Wishlist, *execption.ApiExecption) {
+	uintID, err := strconv.ParseUint(wishlistID, 10, 0)
+	if err != nil {
+		return nil, execption.NewApiExecption(http.StatusBadRequest, err.Error())
+	}
+
+	wishlist, err := w.wishlistRepo.GetById(uint(uintID))
+	if err != nil || wishlist.UserID != userID {
+		return nil, execption.NewApiExecption(http.StatusNotFound, repository.ErrWishlistNotFound.Error())
+	}
+
+	return wishlist, nil
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// toWishlistResponse builds the response. The share token is only included for
+// the owner, never on the shared read-only view.
+func (w *wishlistService) toWishlistResponse(wishlist *entity.Wishlist, withShareToken bool) *dto.WishlistResponse {
+	response := &dto.WishlistResponse{
+		ID:        wishlist.ID,
+		Name:      wishlist.Name,
+		Items:     []dto.WishlistItemResponse{},
+		CreatedAt: wishlist.CreatedAt.String(),
+		UpdatedAt: wishlist.UpdatedAt.String(),
+	}
+
+	if withShareToken {
+		response.ShareToken = wishlist.ShareToken
+	}
+
+	for _, item := range wishlist.Items {
+		priceDrop := item.PriceWhenAdded - item.Book.Price
+		if priceDrop < 0 {
+			priceDrop = 0
+		}
+
+		response.Items = append(response.Items, dto.WishlistItemResponse{
+			BookID:         item.BookID,
+			Title:          item.Book.Title,
+			Cover:          coverURLs(w.fileStorage, item.Book.Images),
+			Price:          item.Book.Price,
+			PriceWhenAdded: item.PriceWhenAdded,
+			PriceDropped:   priceDrop > 0,
+			PriceDrop:      priceDrop,
+			InStock:        item.Book.Stock == nil || *item.Book.Stock > 0,
+			AddedAt:        item.CreatedAt.String(),
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,181 @@
+package ebookmeta
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// maxXMLBytes limits how much of the container and package documents is
+// read, so a crafted archive cannot make us unpack gigabytes. Covers are
+// limited to maxCoverBytes when the caller sets no limit.
+const (
+	maxXMLBytes   = 1 << 20
+	maxCoverBytes = 20 << 20
+)
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// opfPackage is the part of an OPF package document we read. Element names
+// match in any namespace, so both EPUB 2 and EPUB 3 documents fit.
+type opfPackage struct {
+	Metadata struct {
+		Titles       []string        `xml:"title"`
+		Creators     []opfCreator    `xml:"creator"`
+		Descriptions []string        `xml:"description"`
+		Languages    []string        `xml:"language"`
+		Identifiers  []opfIdentifier `xml:"identifier"`
+		Publishers   []string        `xml:"publisher"`
+		Metas        []opfMeta       `xml:"meta"`
+	} `xml:"metadata"`
+	Items []opfItem `xml:"manifest>item"`
+}
+
+type opfCreator struct {
+	Name string `xml:",chardata"`
+	Role string `xml:"role,attr"`
+}
+
+type opfIdentifier struct {
+	Value  string `xml:",chardata"`
+	Scheme string `xml:"scheme,attr"`
+}
+
+type opfMeta struct {
+	Name    string `xml:"name,attr"`
+	Content string `xml:"content,attr"`
+}
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+// ExtractEPUB reads the metadata from the OPF package document of an EPUB.
+// Covers larger than coverLimit are left out.
+func ExtractEPUB(file io.ReaderAt, size int64, coverLimit int64) (*Metadata, error) {
+	if coverLimit <= 0 {
+		coverLimit = maxCoverBytes
+	}
+
+	archive, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, ErrUnreadable
+	}
+
+	var container epubContainer
+	if err := readXML(archive, "META-INF/container.xml", &container); err != nil || len(container.Rootfiles) == 0 {
+		return nil, ErrUnreadable
+	}
+	opfPath := container.Rootfiles[0].FullPath
+
+	var opf opfPackage
+	if err := readXML(archive, opfPath, &opf); err != nil {
+		return nil, ErrUnreadable
+	}
+
+	metadata := &Metadata{Authors: []string{}}
+	if len(opf.Metadata.Titles) > 0 {
+		metadata.Title = cleanText(opf.Metadata.Titles[0])
+	}
+	for _, creator := range opf.Metadata.Creators {
+		// EPUB 2 marks illustrators, editors and so on with a role
+		if creator.Role != "" && creator.Role != "aut" {
+			continue
+		}
+		if name := cleanText(creator.Name); name != "" {
+			metadata.Authors = append(metadata.Authors, name)
+		}
+	}
+	if len(opf.Metadata.Descriptions) > 0 {
+		metadata.Description = cleanText(opf.Metadata.Descriptions[0])
+	}
+	if len(opf.Metadata.Languages) > 0 {
+		metadata.Language = strings.TrimSpace(opf.Metadata.Languages[0])
+	}
+	if len(opf.Metadata.Publishers) > 0 {
+		metadata.Publisher = cleanText(opf.Metadata.Publishers[0])
+	}
+	for _, identifier := range opf.Metadata.Identifiers {
+		if isbn := normalizeISBN(identifier.Value); isbn != "" {
+			metadata.ISBN = isbn
+			break
+		}
+	}
+
+	if item := opf.coverItem(); item != nil {
+		href, err := url.PathUnescape(item.Href)
+		if err == nil {
+			metadata.Cover, _ = readFile(archive, path.Join(path.Dir(opfPath), href), coverLimit)
+		}
+	}
+
+	return metadata, nil
+}
+
+// coverItem finds the cover image in the manifest: the EPUB 3 cover-image
+// property, then the EPUB 2 cover meta, then any image called cover.
+func (opf *opfPackage) coverItem() *opfItem {
+	for i := range opf.Items {
+		for _, property := range strings.Fields(opf.Items[i].Properties) {
+			if property == "cover-image" {
+				return &opf.Items[i]
+			}
+		}
+	}
+
+	for _, meta := range opf.Metadata.Metas {
+		if meta.Name != "cover" {
+			continue
+		}
+		for i := range opf.Items {
+			if opf.Items[i].ID == meta.Content && strings.HasPrefix(opf.Items[i].MediaType, "image/") {
+				return &opf.Items[i]
+			}
+		}
+	}
+
+	for i := range opf.Items {
+		name := strings.ToLower(opf.Items[i].ID + " " + opf.Items[i].Href)
+		if strings.HasPrefix(opf.Items[i].MediaType, "image/") && strings.Contains(name, "cover") {
+			return &opf.Items[i]
+		}
+	}
+	return nil
+}
+
+func readXML(archive *zip.Reader, name string, v interface{}) error {
+	content, err := readFile(archive, name, maxXMLBytes)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(content, v)
+}
+
+// readFile reads a file from the archive, failing when it is larger than max.
+func readFile(archive *zip.Reader, name string, max int64) ([]byte, error) {
+	entry, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer entry.Close()
+
+	content, err := io.ReadAll(io.LimitReader(entry, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > max {
+		return nil, ErrUnreadable
+	}
+	return content, nil
+}
@@ -0,0 +1,104 @@
+package ebookmeta
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var ErrUnreadable = errors.New("file metadata could not be read")
+
+// Metadata is what an ebook file says about itself. Fields the file does not
+// have are left empty. Cover holds the raw cover image, when there is one.
+type Metadata struct {
+	Title       string
+	Authors     []string
+	Description string
+	Language    string
+	ISBN        string
+	Publisher   string
+	Cover       []byte
+}
+
+var (
+	htmlTag      = regexp.MustCompile(`<[^>]*>`)
+	spaces       = regexp.MustCompile(`\s+`)
+	isbnInText   = regexp.MustCompile(`(?i)\bISBN(?:-1[03])?\s*:?\s*([0-9][0-9\- ]{8,16}[0-9X])\b`)
+	isbnSeparate = regexp.MustCompile(`[\s-]`)
+)
+
+// cleanText turns a metadata value, which may hold HTML, into plain text on
+// one line.
+func cleanText(value string) string {
+	value = htmlTag.ReplaceAllString(value, " ")
+	value = html.UnescapeString(value)
+	return strings.TrimSpace(spaces.ReplaceAllString(value, " "))
+}
+
+// normalizeISBN returns the digits of a valid ISBN-10 or ISBN-13, ignoring
+// hyphens, spaces and a "urn:isbn:" or "isbn:" prefix, or "" when value is
+// not a valid ISBN.
+func normalizeISBN(value string) string {
+	value = strings.TrimSpace(strings.ToUpper(value))
+	value = strings.TrimPrefix(value, "URN:")
+	value = strings.TrimPrefix(value, "ISBN:")
+	value = isbnSeparate.ReplaceAllString(value, "")
+
+	switch len(value) {
+	case 10:
+		sum := 0
+		for i, c := range value {
+			digit := int(c - '0')
+			if c == 'X' && i == 9 {
+				digit = 10
+			} else if c < '0' || c > '9' {
+				return ""
+			}
+			sum += digit * (10 - i)
+		}
+		if sum%11 != 0 {
+			return ""
+		}
+	case 13:
+		sum := 0
+		for i, c := range value {
+			if c < '0' || c > '9' {
+				return ""
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += int(c-'0') * weight
+		}
+		if sum%10 != 0 {
+			return ""
+		}
+	default:
+		return ""
+	}
+	return value
+}
+
+// findISBN returns the first valid ISBN written as "ISBN ..." in text.
+func findISBN(text string) string {
+	for _, match := range isbnInText.FindAllStringSubmatch(text, -1) {
+		if isbn := normalizeISBN(match[1]); isbn != "" {
+			return isbn
+		}
+	}
+	return ""
+}
+
+// splitAuthors splits an author list written as one value, such as
+// "Jane Doe; John Roe".
+func splitAuthors(value string) []string {
+	authors := []string{}
+	for _, author := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '&' }) {
+		if author = cleanText(author); author != "" {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
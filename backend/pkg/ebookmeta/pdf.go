@@ -0,0 +1,38 @@
+package ebookmeta
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// ExtractPDF reads the metadata from the info dictionary of a PDF, and the
+// language from its catalog. PDFs have no cover image apart from their first
+// page, so Cover is always empty. The ISBN is looked for in the subject and
+// keywords.
+func ExtractPDF(file io.ReaderAt, size int64) (metadata *Metadata, err error) {
+	// The PDF reader panics on some damaged files
+	defer func() {
+		if recover() != nil {
+			metadata, err = nil, ErrUnreadable
+		}
+	}()
+
+	reader, err := pdf.NewReader(file, size)
+	if err != nil {
+		return nil, ErrUnreadable
+	}
+
+	info := reader.Trailer().Key("Info")
+	subject := cleanText(info.Key("Subject").Text())
+	keywords := cleanText(info.Key("Keywords").Text())
+
+	return &Metadata{
+		Title:       cleanText(info.Key("Title").Text()),
+		Authors:     splitAuthors(info.Key("Author").Text()),
+		Description: subject,
+		Language:    strings.TrimSpace(reader.Trailer().Key("Root").Key("Lang").Text()),
+		ISBN:        findISBN(subject + " " + keywords),
+	}, nil
+}
@@ -0,0 +1,9 @@
+package openapi
+
+import _ "embed"
+
+// DocsPage is a self-contained page that renders the document served next to
+// it as openapi.json.
+//
+//go:embed docs.html
+var DocsPage []byte
@@ -0,0 +1,323 @@
+// Package openapi builds an OpenAPI 3 document from the route list. Request
+// schemas come from the Input binder of each route, read through its param,
+// query, form and json tags and its validate rules; response schemas come
+// from the Output and are wrapped in the response.Response envelope.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/aws-cakap-intern/book-store/pkg/route"
+)
+
+const bearerAuth = "bearerAuth"
+
+type Document struct {
+	OpenAPI    string                  `json:"openapi"`
+	Info       Info                    `json:"info"`
+	Servers    []Server                `json:"servers"`
+	Paths      map[string]PathItem     `json:"paths"`
+	Components Components              `json:"components"`
+	operations map[string]bool         `json:"-"`
+	schemas    map[string]reflect.Type `json:"-"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path by lowercase HTTP method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string              `json:"tags"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Responses       map[string]*Response       `json:"responses"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat"`
+}
+
+// NewDocument returns a document without paths for the API served under
+// basePath.
+func NewDocument(title, version, basePath string) *Document {
+	d := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Servers: []Server{{URL: basePath}},
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		operations: map[string]bool{},
+		schemas:    map[string]reflect.Type{},
+	}
+
+	meta := d.schemaOf(reflect.TypeOf(response.Meta{}))
+	d.Components.Responses = map[string]*Response{
+		"Error":           envelope("The request failed.", meta, &Schema{Type: "object", Nullable: true}),
+		"ValidationError": envelope("The input is not valid; data maps each invalid field to its problem.", meta, &Schema{Type: "object", Nullable: true, AdditionalProperties: &Schema{Type: "string"}}),
+		"Unauthorized":    envelope("The bearer token is missing or not valid.", meta, &Schema{Type: "object", Nullable: true}),
+		"Forbidden":       envelope("The bearer token is not one of a staff member.", meta, &Schema{Type: "object", Nullable: true}),
+	}
+	return d
+}
+
+// AddGroup documents the routes of group. Its private routes require the JWT
+// bearer token, and its admin routes one with a staff role. The operations of a group under its own prefix, such as
+// /api/v2, are tagged and named after it.
+func (d *Document) AddGroup(group *route.Group) {
+	prefix := strings.TrimPrefix(group.Prefix, d.Servers[0].URL)
+	d.addRoutes(prefix, group.PublicRoutes, accessPublic, group.Deprecation != nil)
+	d.addRoutes(prefix, group.PrivateRoutes, accessPrivate, group.Deprecation != nil)
+	d.addRoutes(prefix, group.AdminRoutes, accessAdmin, group.Deprecation != nil)
+}
+
+type access int
+
+const (
+	accessPublic access = iota
+	accessPrivate
+	accessAdmin
+)
+
+func (d *Document) addRoutes(prefix string, routes []*route.Route, access access, deprecated bool) {
+	for _, r := range routes {
+		path := prefix + pathParam.ReplaceAllString(r.Path, "{$1}")
+		if d.Paths[path] == nil {
+			d.Paths[path] = PathItem{}
+		}
+		op := d.operation(prefix, r, access)
+		op.Deprecated = deprecated
+		d.Paths[path][strings.ToLower(r.Method)] = op
+	}
+}
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+func (d *Document) operation(prefix string, r *route.Route, access access) *Operation {
+	receiver, method := handlerName(r)
+	version := strings.Trim(prefix, "/")
+
+	id := method + strings.ToUpper(version)
+	if d.operations[id] {
+		id = strings.TrimSuffix(receiver, "Handler") + id
+	}
+	d.operations[id] = true
+
+	tag := strings.SplitN(strings.TrimPrefix(r.Path, "/"), "/", 2)[0]
+	if version != "" {
+		tag = version + "/" + tag
+	}
+
+	op := &Operation{
+		Tags:        []string{tag},
+		Summary:     summary(method),
+		OperationID: id,
+		Responses:   map[string]*Response{},
+	}
+
+	if r.Input != nil {
+		op.Parameters, op.RequestBody = d.input(reflect.TypeOf(r.Input))
+		op.Responses["400"] = &Response{Ref: "#/components/responses/ValidationError"}
+	}
+	for _, name := range pathParam.FindAllStringSubmatch(r.Path, -1) {
+		if !hasParameter(op.Parameters, name[1]) {
+			op.Parameters = append(op.Parameters, &Parameter{Name: name[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	op.Responses[strconv.Itoa(status)] = d.output(r.Output, http.StatusText(status))
+
+	if access != accessPublic {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+		op.Responses["401"] = &Response{Ref: "#/components/responses/Unauthorized"}
+	}
+	if access == accessAdmin {
+		op.Description = "Only for users with the admin or staff role."
+		op.Responses["403"] = &Response{Ref: "#/components/responses/Forbidden"}
+	}
+	op.Responses["default"] = &Response{Ref: "#/components/responses/Error"}
+	return op
+}
+
+// input splits a binder into its path and query parameters and the body.
+// A binder with form tags is sent as a form, any other body as JSON.
+func (d *Document) input(t reflect.Type) ([]*Parameter, *RequestBody) {
+	var parameters []*Parameter
+
+	formTag := "json"
+	for _, field := range fields(t) {
+		if field.Tag.Get("form") != "" {
+			formTag = "form"
+		}
+	}
+
+	body := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	hasFile := false
+	for _, field := range fields(t) {
+		if name := field.Tag.Get("param"); name != "" {
+			parameters = append(parameters, &Parameter{Name: name, In: "path", Required: true, Schema: d.fieldSchema(field, t)})
+			continue
+		}
+		if name := field.Tag.Get("query"); name != "" {
+			parameters = append(parameters, &Parameter{Name: name, In: "query", Required: isRequired(field), Schema: d.fieldSchema(field, t)})
+			continue
+		}
+
+		name := fieldName(field, formTag)
+		if name == "" {
+			continue
+		}
+		if field.Type == fileHeaderType {
+			hasFile = true
+		}
+		body.Properties[name] = d.fieldSchema(field, t)
+		if isRequired(field) {
+			body.Required = append(body.Required, name)
+		}
+	}
+
+	if len(body.Properties) == 0 {
+		return parameters, nil
+	}
+
+	requestBody := &RequestBody{Required: len(body.Required) > 0, Content: map[string]*MediaType{}}
+	switch {
+	case formTag == "json":
+		requestBody.Content["application/json"] = &MediaType{Schema: body}
+	case hasFile:
+		requestBody.Content["multipart/form-data"] = &MediaType{Schema: body}
+	default:
+		requestBody.Content["application/x-www-form-urlencoded"] = &MediaType{Schema: body}
+		requestBody.Content["multipart/form-data"] = &MediaType{Schema: body}
+	}
+	return parameters, requestBody
+}
+
+// output describes a successful response: the raw file for a route.File,
+// otherwise the JSON envelope around the data.
+func (d *Document) output(output interface{}, description string) *Response {
+	if file, ok := output.(route.File); ok {
+		res := &Response{Description: description, Content: map[string]*MediaType{}}
+		for _, contentType := range file.ContentTypes {
+			res.Content[contentType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+		return res
+	}
+
+	data := &Schema{Type: "object", Nullable: true}
+	if output != nil {
+		data = d.schemaOf(reflect.TypeOf(output))
+	}
+	return envelope(description, d.schemaOf(reflect.TypeOf(response.Meta{})), data)
+}
+
+func envelope(description string, meta, data *Schema) *Response {
+	return &Response{
+		Description: description,
+		Content: map[string]*MediaType{
+			"application/json": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"meta": meta, "data": data},
+				Required:   []string{"meta", "data"},
+			}},
+		},
+	}
+}
+
+func hasParameter(parameters []*Parameter, name string) bool {
+	for _, p := range parameters {
+		if p.In == "path" && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// handlerName returns the receiver type and method of a handler method value,
+// such as "BookHandler" and "GetBooks".
+func handlerName(r *route.Route) (receiver, method string) {
+	name := runtime.FuncForPC(reflect.ValueOf(r.Handler).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+
+	dot := strings.LastIndex(name, ".")
+	method = name[dot+1:]
+	receiver = strings.Trim(name[strings.LastIndex(name[:dot], ".")+1:dot], "(*)")
+	return receiver, method
+}
+
+// summary turns a method name like GetInvoicePDF into "Get invoice PDF".
+func summary(method string) string {
+	var words []string
+	runes := []rune(method)
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && !(unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			continue
+		}
+		word := string(runes[start:i])
+		if len(words) > 0 && strings.ToUpper(word) != word {
+			word = strings.ToLower(word)
+		}
+		words = append(words, word)
+		start = i
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"mime/multipart"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	fileHeaderType = reflect.TypeOf(&multipart.FileHeader{})
+	timeType       = reflect.TypeOf(time.Time{})
+)
+
+// schemaOf returns the JSON schema of t. Named structs are added to the
+// components once and referenced from then on.
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	if t == fileHeaderType {
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	nullable := false
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var s *Schema
+	switch t.Kind() {
+	case reflect.Bool:
+		s = &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		s = &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		s = &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		s = &Schema{Type: "number"}
+	case reflect.String:
+		s = &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		s = &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		s = &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			s = &Schema{Type: "string", Format: "date-time"}
+			break
+		}
+		if t.Name() == "" {
+			s = d.structSchema(t)
+			break
+		}
+		// A $ref cannot be marked nullable, so the referenced schema speaks
+		// for both the value and the pointer.
+		return &Schema{Ref: "#/components/schemas/" + d.component(t)}
+	default:
+		s = &Schema{}
+	}
+
+	s.Nullable = nullable
+	return s
+}
+
+// component adds the schema of the named struct t to the components and
+// returns its name, prefixed with the package when another type already
+// took the plain name.
+func (d *Document) component(t reflect.Type) string {
+	name := t.Name()
+	if other, ok := d.schemas[name]; ok && other != t {
+		pkg := path.Base(t.PkgPath())
+		name = string(unicode.ToUpper(rune(pkg[0]))) + pkg[1:] + name
+	}
+
+	if _, ok := d.schemas[name]; !ok {
+		d.schemas[name] = t
+		d.Components.Schemas[name] = d.structSchema(t)
+	}
+	return name
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range fields(t) {
+		name := fieldName(field, "json")
+		if name == "" {
+			continue
+		}
+		s.Properties[name] = d.fieldSchema(field, t)
+		if isRequired(field) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// fieldSchema returns the schema of a struct field narrowed by its validate
+// rules. Rules after dive apply to the items of a slice.
+func (d *Document) fieldSchema(field reflect.StructField, parent reflect.Type) *Schema {
+	s := d.schemaOf(field.Type)
+
+	target := s
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if target == nil || target.Ref != "" {
+			break
+		}
+
+		switch name {
+		case "dive":
+			target = target.Items
+		case "min", "max":
+			applyBound(target, name, param)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				if target.Type == "integer" {
+					n, err := strconv.Atoi(value)
+					if err == nil {
+						target.Enum = append(target.Enum, n)
+						continue
+					}
+				}
+				target.Enum = append(target.Enum, value)
+			}
+		case "uuid":
+			target.Format = "uuid"
+		case "email":
+			target.Format = "email"
+		case "datetime":
+			if param == "2006-01-02" {
+				target.Format = "date"
+			} else {
+				target.Description = "Formatted as " + param + "."
+			}
+		case "required_if":
+			other, value, _ := strings.Cut(param, " ")
+			if otherField, ok := parent.FieldByName(other); ok {
+				other = fieldName(otherField, nameTag(field))
+			}
+			target.Description = "Required when " + other + " is " + value + "."
+		}
+	}
+	return s
+}
+
+func applyBound(s *Schema, rule, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	length := int(n)
+
+	switch {
+	case s.Type == "integer" || s.Type == "number":
+		if rule == "min" {
+			s.Minimum = &n
+		} else {
+			s.Maximum = &n
+		}
+	case s.Type == "array":
+		if rule == "min" {
+			s.MinItems = &length
+		} else {
+			s.MaxItems = &length
+		}
+	case s.Type == "string" && s.Format != "binary":
+		if rule == "min" {
+			s.MinLength = &length
+		} else {
+			s.MaxLength = &length
+		}
+	}
+}
+
+// fields returns the exported fields of t with embedded structs flattened.
+func fields(t reflect.Type) []reflect.StructField {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var result []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			result = append(result, fields(field.Type)...)
+			continue
+		}
+		if field.IsExported() {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// fieldName returns the name of field under tag, the field name for json
+// when there is no tag, or "" when the field is left out.
+func fieldName(field reflect.StructField, tag string) string {
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" && tag == "json" && field.Tag.Get("param") == "" && field.Tag.Get("query") == "" {
+		return field.Name
+	}
+	return name
+}
+
+func nameTag(field reflect.StructField) string {
+	for _, tag := range []string{"param", "query", "form"} {
+		if field.Tag.Get(tag) != "" {
+			return tag
+		}
+	}
+	return "json"
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "dive" {
+			return false
+		}
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package payment
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// manualProvider is used when payments are settled outside the system, e.g.
+// by bank transfer. Every call succeeds and staff move the money by hand
+// using the returned references.
+type manualProvider struct{}
+
+func NewManualProvider() Provider {
+	return &manualProvider{}
+}
+
+// Name implements Provider.
+func (m *manualProvider) Name() string {
+	return "manual"
+}
+
+// Authorize implements Provider.
+func (m *manualProvider) Authorize(request AuthorizeRequest) (*Authorization, error) {
+	if request.Amount < 0 {
+		return nil, ErrAuthorizationFailed
+	}
+
+	return &Authorization{Reference: manualReference("AUTH"), Amount: request.Amount}, nil
+}
+
+// Capture implements Provider.
+func (m *manualProvider) Capture(request CaptureRequest) (*Capture, error) {
+	if request.AuthorizationReference == "" || request.Amount < 0 {
+		return nil, ErrCaptureFailed
+	}
+
+	return &Capture{Reference: manualReference("CAPTURE"), Amount: request.Amount}, nil
+}
+
+// Void implements Provider.
+func (m *manualProvider) Void(authorizationReference string) error {
+	return nil
+}
+
+// Refund implements Provider.
+func (m *manualProvider) Refund(request RefundRequest) (*Refund, error) {
+	if request.Amount < 0 {
+		return nil, ErrRefundFailed
+	}
+
+	return &Refund{Reference: manualReference("REFUND"), Amount: request.Amount}, nil
+}
+
+func manualReference(kind string) string {
+	return fmt.Sprintf("MANUAL-%s-%s", kind, uuid.New().String())
+}
@@ -0,0 +1,57 @@
+package payment
+
+import "errors"
+
+var (
+	ErrAuthorizationFailed = errors.New("payment authorization failed")
+	ErrCaptureFailed       = errors.New("payment capture failed")
+	ErrRefundFailed        = errors.New("refund failed")
+)
+
+// AuthorizeRequest asks the provider to hold an amount for an order without
+// charging it yet.
+type AuthorizeRequest struct {
+	OrderID uint
+	Amount  int
+}
+
+// Authorization is a hold on the customer's funds.
+type Authorization struct {
+	Reference string
+	Amount    int
+}
+
+// CaptureRequest charges an earlier authorization.
+type CaptureRequest struct {
+	OrderID                uint
+	AuthorizationReference string
+	Amount                 int
+}
+
+// Capture is the provider's record of a charge.
+type Capture struct {
+	Reference string
+	Amount    int
+}
+
+// RefundRequest asks the provider to pay an amount back for an order.
+type RefundRequest struct {
+	OrderID uint
+	Amount  int
+	Reason  string
+}
+
+// Refund is the provider's record of a refund.
+type Refund struct {
+	Reference string
+	Amount    int
+}
+
+// Provider is a payment gateway.
+type Provider interface {
+	Name() string
+	Authorize(request AuthorizeRequest) (*Authorization, error)
+	Capture(request CaptureRequest) (*Capture, error)
+	Void(authorizationReference string) error
+	Refund(request RefundRequest) (*Refund, error)
+}
@@ -0,0 +1,24 @@
+package route
+
+import "time"
+
+// Group is a version of the API served under Prefix. Private routes need a
+// signed in user and admin routes a user with a staff role. Mapper, when
+// set, turns the data of every JSON response of the group into the shapes of
+// that version, so versions can share handlers and services.
+type Group struct {
+	Prefix        string
+	PublicRoutes  []*Route
+	PrivateRoutes []*Route
+	AdminRoutes   []*Route
+	Mapper        func(data interface{}) interface{}
+	Deprecation   *Deprecation
+}
+
+// Deprecation tells the clients of a group that it is deprecated since
+// Since, is removed at Sunset and is replaced by the group at Successor.
+type Deprecation struct {
+	Since     time.Time
+	Sunset    time.Time
+	Successor string
+}
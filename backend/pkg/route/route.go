@@ -6,4 +6,17 @@ type Route struct {
 	Method  string
 	Path    string
 	Handler echo.HandlerFunc
+
+	// Input is the binder struct the handler binds, Output the data of a
+	// successful response and Status its code when it is not 200 OK. They
+	// only document the route.
+	Input  interface{}
+	Output interface{}
+	Status int
+}
+
+// File is the Output of a route that answers with the raw file instead of
+// the JSON response.
+type File struct {
+	ContentTypes []string
 }
\ No newline at end of file
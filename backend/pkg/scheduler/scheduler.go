@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a task run in the background every Interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Start runs every job once and then on its interval until ctx is cancelled.
+// Jobs with a zero interval are disabled.
+func Start(ctx context.Context, jobs []*Job) {
+	for _, job := range jobs {
+		if job.Interval <= 0 {
+			continue
+		}
+		go run(ctx, job)
+	}
+}
+
+func run(ctx context.Context, job *Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(); err != nil {
+			log.Printf("job %s failed: %v", job.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
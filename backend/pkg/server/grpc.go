@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type GRPCServer struct {
+	*grpc.Server
+}
+
+// NewGRPCServer returns a gRPC server with server reflection, so tools like
+// grpcurl can list and call its services without the proto files. Messages
+// may be up to maxMessageBytes.
+func NewGRPCServer(maxMessageBytes int) *GRPCServer {
+	srv := grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageBytes))
+	reflection.Register(srv)
+	return &GRPCServer{srv}
+}
+
+// Run serves in the background beside the HTTP server.
+func (s *GRPCServer) Run(port string) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	go func() {
+		err := s.Serve(listener)
+		log.Fatal(err)
+	}()
+}
@@ -11,6 +11,9 @@ import (
 
 	"github.com/aws-cakap-intern/book-store/pkg/response"
 	"github.com/aws-cakap-intern/book-store/pkg/route"
+	"github.com/aws-cakap-intern/book-store/pkg/token"
+	"github.com/golang-jwt/jwt/v5"
+	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -19,22 +22,42 @@ type Server struct {
 	*echo.Echo
 }
 
-func NewServer(publicRoutes []*route.Route) *Server {
+// NewServer sets up the routes of each group. Files in uploadsDir are served
+// at /api/uploads; an empty uploadsDir serves nothing.
+func NewServer(groups []*route.Group, secretKey string, uploadsDir string) *Server {
 	e := echo.New()
 
 	e.Use(middleware.CORS())
 
-	e.Static("/api/uploads", "uploads")
+	if uploadsDir != "" {
+		e.Static("/api/uploads", uploadsDir)
+	}
 
 	e.GET("/", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, response.SuccessResponse(http.StatusOK, "Hello, World!", nil))
 	})
 
-	v1 := e.Group("api")
+	for _, group := range groups {
+		var middlewares []echo.MiddlewareFunc
+		if group.Deprecation != nil {
+			middlewares = append(middlewares, deprecationHeaders(group.Deprecation))
+		}
+		if group.Mapper != nil {
+			middlewares = append(middlewares, mapResponses(group.Mapper))
+		}
+
+		g := e.Group(group.Prefix)
+
+		for _, v := range group.PublicRoutes {
+			g.Add(v.Method, v.Path, v.Handler, middlewares...)
+		}
+
+		for _, v := range group.PrivateRoutes {
+			g.Add(v.Method, v.Path, v.Handler, append([]echo.MiddlewareFunc{JWTProtection(secretKey)}, middlewares...)...)
+		}
 
-	if len(publicRoutes) > 0 {
-		for _, v := range publicRoutes {
-			v1.Add(v.Method, v.Path, v.Handler)
+		for _, v := range group.AdminRoutes {
+			g.Add(v.Method, v.Path, v.Handler, append([]echo.MiddlewareFunc{JWTProtection(secretKey), RequireRole(token.RoleAdmin, token.RoleStaff)}, middlewares...)...)
 		}
 	}
 
@@ -69,3 +92,35 @@ func gracefulShutdown(srv *Server) {
 		}
 	}()
 }
+
+func JWTProtection(secretKey string) echo.MiddlewareFunc {
+	return echojwt.WithConfig(echojwt.Config{
+		NewClaimsFunc: func(c echo.Context) jwt.Claims {
+			return new(token.JwtCustomClaims)
+		},
+		SigningKey: []byte(secretKey),
+		ErrorHandler: func(c echo.Context, err error) error {
+			return c.JSON(http.StatusUnauthorized, response.ErrorResponse(http.StatusUnauthorized, "You must be logged in to access this resource"))
+		},
+	})
+}
+
+// RequireRole lets through only users whose token has one of roles. It runs
+// after JWTProtection, which puts the token in the context.
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := c.Get("user").(*jwt.Token)
+			if ok {
+				if claims, ok := user.Claims.(*token.JwtCustomClaims); ok {
+					for _, role := range roles {
+						if claims.Role == role {
+							return next(c)
+						}
+					}
+				}
+			}
+			return c.JSON(http.StatusForbidden, response.ErrorResponse(http.StatusForbidden, "You are not allowed to access this resource"))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aws-cakap-intern/book-store/pkg/response"
+	"github.com/aws-cakap-intern/book-store/pkg/route"
+	"github.com/labstack/echo/v4"
+)
+
+// deprecationHeaders marks every response of a deprecated group with the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers and links to the
+// group that replaces it.
+func deprecationHeaders(deprecation *route.Deprecation) echo.MiddlewareFunc {
+	since := fmt.Sprintf("@%d", deprecation.Since.Unix())
+	sunset := deprecation.Sunset.UTC().Format(http.TimeFormat)
+	link := fmt.Sprintf("<%s>; rel=\"successor-version\"", deprecation.Successor)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set("Deprecation", since)
+			header.Set("Sunset", sunset)
+			header.Add("Link", link)
+			return next(c)
+		}
+	}
+}
+
+// mapResponses passes the data of the JSON responses of a group through its
+// mapper before they are written.
+func mapResponses(mapper func(data interface{}) interface{}) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(&mappedContext{Context: c, mapper: mapper})
+		}
+	}
+}
+
+type mappedContext struct {
+	echo.Context
+	mapper func(data interface{}) interface{}
+}
+
+func (c *mappedContext) JSON(code int, i interface{}) error {
+	if res, ok := i.(response.Response); ok && res.Data != nil {
+		res.Data = c.mapper(res.Data)
+		i = res
+	}
+	return c.Context.JSON(code, i)
+}
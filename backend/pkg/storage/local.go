@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// localStorage keeps files in a directory on disk. The server serves that
+// directory itself, so it only works with a single replica.
+type localStorage struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalStorage(dir string, publicURL string) Storage {
+	return &localStorage{dir: dir, publicURL: strings.TrimRight(publicURL, "/")}
+}
+
+// Put implements Storage. The file is written under a temporary name and
+// renamed, so readers never see a partial file.
+func (l *localStorage) Put(key string, content io.Reader, size int64, contentType string) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, content); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), filePath)
+}
+
+// Get implements Storage.
+func (l *localStorage) Get(key string) (io.ReadCloser, error) {
+	filePath, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete implements Storage.
+func (l *localStorage) Delete(key string) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// URL implements Storage.
+func (l *localStorage) URL(key string) string {
+	return l.publicURL + "/" + strings.TrimLeft(key, "/")
+}
+
+// List implements Storage. Files left behind by an interrupted Put are
+// listed too, so they can be cleaned up.
+func (l *localStorage) List(prefix string) ([]Object, error) {
+	// Only the directory the prefix points into needs walking
+	root := l.dir
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		root = filepath.Join(l.dir, filepath.FromSlash(path.Clean("/"+prefix[:i])))
+	}
+
+	objects := []Object{}
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(l.dir, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relative)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// path maps a key to a file inside the storage directory. Keys that would
+// leave the directory are refused.
+func (l *localStorage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+strings.TrimLeft(key, "/") {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(cleaned)), nil
+}
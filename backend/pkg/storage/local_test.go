@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), "/api/uploads/")
+	testStorage(t, s, "")
+
+	t.Run("url under the public url", func(t *testing.T) {
+		if got := s.URL("books/cover.jpg"); got != "/api/uploads/books/cover.jpg" {
+			t.Errorf("got %q", got)
+		}
+	})
+
+	t.Run("keys stay inside the directory", func(t *testing.T) {
+		for _, key := range []string{"", "/", "../secret", "books/../../secret", "books//cover.jpg"} {
+			if err := s.Put(key, strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("put %q: got %v, want %v", key, err, ErrInvalidKey)
+			}
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3Storage keeps files in a bucket of any S3-compatible service, such as
+// AWS S3 or MinIO.
+type s3Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+func NewS3Storage(cfg *config.StorageConfig) (Storage, error) {
+	if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+		return nil, ErrMissingS3Config
+	}
+
+	client, err := minio.New(cfg.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.S3AccessKey, cfg.S3SecretKey, ""),
+		Secure: cfg.S3UseSSL,
+		Region: cfg.S3Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a public URL, objects are linked straight from the bucket
+	publicURL := strings.TrimRight(cfg.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = client.EndpointURL().String() + "/" + cfg.S3Bucket
+	}
+
+	return &s3Storage{client: client, bucket: cfg.S3Bucket, publicURL: publicURL}, nil
+}
+
+// Put implements Storage.
+func (s *s3Storage) Put(key string, content io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(context.Background(), s.bucket, key, content, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+// Get implements Storage. The object is looked up first, since a missing
+// object is otherwise only reported on the first read.
+func (s *s3Storage) Get(key string) (io.ReadCloser, error) {
+	ctx := context.Background()
+	if _, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{}); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+}
+
+// Delete implements Storage.
+func (s *s3Storage) Delete(key string) error {
+	return s.client.RemoveObject(context.Background(), s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// List implements Storage.
+func (s *s3Storage) List(prefix string) ([]Object, error) {
+	objects := []Object{}
+	for info := range s.client.ListObjects(context.Background(), s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return nil, info.Err
+		}
+		objects = append(objects, Object{Key: info.Key, Size: info.Size, ModTime: info.LastModified})
+	}
+	return objects, nil
+}
+
+// PresignPut implements Presigner. The content type is part of the
+// signature, so the bucket refuses uploads of any other type.
+func (s *s3Storage) PresignPut(key string, contentType string, expires time.Duration) (string, error) {
+	presigned, err := s.client.PresignHeader(context.Background(), http.MethodPut, s.bucket, key, expires, nil, http.Header{
+		"Content-Type": []string{contentType},
+	})
+	if err != nil {
+		return "", err
+	}
+	return presigned.String(), nil
+}
+
+// URL implements Storage.
+func (s *s3Storage) URL(key string) string {
+	return s.publicURL + "/" + (&url.URL{Path: strings.TrimLeft(key, "/")}).EscapedPath()
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+)
+
+// TestS3Storage runs against the bucket in STORAGE_TEST_S3_BUCKET and is
+// skipped when it is not set. With the MinIO of docker-compose.yaml and a
+// bucket named books:
+//
+//	STORAGE_TEST_S3_ENDPOINT=localhost:9000 STORAGE_TEST_S3_BUCKET=books \
+//	STORAGE_TEST_S3_ACCESS_KEY=cakap STORAGE_TEST_S3_SECRET_KEY=cakap12345 \
+//	go test ./pkg/storage
+//
+// Objects are kept under a prefix of their own and removed afterwards.
+func TestS3Storage(t *testing.T) {
+	bucket := os.Getenv("STORAGE_TEST_S3_BUCKET")
+	if bucket == "" {
+		t.Skip("STORAGE_TEST_S3_BUCKET is not set")
+	}
+
+	useSSL, _ := strconv.ParseBool(os.Getenv("STORAGE_TEST_S3_USE_SSL"))
+	s, err := NewS3Storage(&config.StorageConfig{
+		S3Endpoint:  os.Getenv("STORAGE_TEST_S3_ENDPOINT"),
+		S3Region:    os.Getenv("STORAGE_TEST_S3_REGION"),
+		S3Bucket:    bucket,
+		S3AccessKey: os.Getenv("STORAGE_TEST_S3_ACCESS_KEY"),
+		S3SecretKey: os.Getenv("STORAGE_TEST_S3_SECRET_KEY"),
+		S3UseSSL:    useSSL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testStorage(t, s, "storage-test/"+strconv.FormatInt(time.Now().UnixNano(), 10)+"/")
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/aws-cakap-intern/book-store/config"
+)
+
+var (
+	ErrObjectNotFound  = errors.New("object not found")
+	ErrInvalidKey      = errors.New("invalid object key")
+	ErrUnknownDriver   = errors.New("unknown storage driver")
+	ErrMissingS3Config = errors.New("s3 storage needs an endpoint and a bucket")
+	ErrMissingPrivate  = errors.New("s3 storage needs a private bucket for private files")
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// Object describes a stored file.
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage keeps uploaded files. Keys are slash separated paths such as
+// "books/cover.jpg", relative to the root of the storage.
+type Storage interface {
+	// Put stores content under key, replacing anything already there. Size
+	// may be -1 when it is not known.
+	Put(key string, content io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key. The caller must close it.
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object
+	// is not an error.
+	Delete(key string) error
+	// URL returns the address clients can download the object from.
+	URL(key string) string
+	// List returns every object whose key starts with prefix.
+	List(prefix string) ([]Object, error)
+}
+
+// Presigner is implemented by storages that clients can upload to directly.
+type Presigner interface {
+	// PresignPut returns a URL that accepts a PUT of one object under key
+	// until expires. The upload must be sent with the given Content-Type.
+	PresignPut(key string, contentType string, expires time.Duration) (string, error)
+}
+
+// NewStorage returns the storage backend chosen by cfg.Driver.
+func NewStorage(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case DriverLocal:
+		return NewLocalStorage(cfg.LocalDir, cfg.PublicURL), nil
+	case DriverS3:
+		return NewS3Storage(cfg)
+	default:
+		return nil, ErrUnknownDriver
+	}
+}
+
+// NewPrivateStorage returns the storage for files that must not be public,
+// such as ebooks and invoices. They are handed out by the server itself, so
+// URL is of no use on it.
+func NewPrivateStorage(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case DriverLocal:
+		return NewLocalStorage(cfg.PrivateDir, ""), nil
+	case DriverS3:
+		if cfg.S3PrivateBucket == "" {
+			return nil, ErrMissingPrivate
+		}
+		privateCfg := *cfg
+		privateCfg.S3Bucket = cfg.S3PrivateBucket
+		privateCfg.PublicURL = ""
+		return NewS3Storage(&privateCfg)
+	default:
+		return nil, ErrUnknownDriver
+	}
+}
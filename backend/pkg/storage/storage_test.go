@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testStorage checks the behaviour every Storage must share. Keys are made
+// under prefix, so a shared bucket can be used.
+func testStorage(t *testing.T, s Storage, prefix string) {
+	cover := prefix + "books/cover.jpg"
+	back := prefix + "books/back.jpg"
+	invoice := prefix + "invoices/INV-1.pdf"
+
+	t.Cleanup(func() {
+		for _, key := range []string{cover, back, invoice} {
+			s.Delete(key)
+		}
+	})
+
+	t.Run("get a missing object", func(t *testing.T) {
+		if _, err := s.Get(cover); !errors.Is(err, ErrObjectNotFound) {
+			t.Fatalf("got %v, want %v", err, ErrObjectNotFound)
+		}
+	})
+
+	t.Run("put and get", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			key     string
+			content string
+			size    int64
+		}{
+			{"known size", cover, "front cover", int64(len("front cover"))},
+			{"unknown size", back, "back cover", -1},
+			{"replace", cover, "new front cover", int64(len("new front cover"))},
+			{"other prefix", invoice, "%PDF-1.4", int64(len("%PDF-1.4"))},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := s.Put(tt.key, strings.NewReader(tt.content), tt.size, "application/octet-stream"); err != nil {
+					t.Fatal(err)
+				}
+				if got := read(t, s, tt.key); got != tt.content {
+					t.Errorf("got %q, want %q", got, tt.content)
+				}
+			})
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		tests := []struct {
+			prefix string
+			want   map[string]int64
+		}{
+			{prefix + "books/", map[string]int64{cover: int64(len("new front cover")), back: int64(len("back cover"))}},
+			{prefix + "books/co", map[string]int64{cover: int64(len("new front cover"))}},
+			{prefix + "invoices/", map[string]int64{invoice: int64(len("%PDF-1.4"))}},
+			{prefix + "missing/", map[string]int64{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.prefix, func(t *testing.T) {
+				objects, err := s.List(tt.prefix)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if objects == nil {
+					t.Error("got nil, want an empty list")
+				}
+
+				got := map[string]int64{}
+				for _, object := range objects {
+					got[object.Key] = object.Size
+					if object.ModTime.IsZero() {
+						t.Errorf("%s has no modification time", object.Key)
+					}
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("url", func(t *testing.T) {
+		if url := s.URL(cover); !strings.HasSuffix(url, "/"+cover) {
+			t.Errorf("got %q, want it to end with /%s", url, cover)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := s.Delete(cover); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Get(cover); !errors.Is(err, ErrObjectNotFound) {
+			t.Errorf("got %v after delete, want %v", err, ErrObjectNotFound)
+		}
+		if got := read(t, s, back); got != "back cover" {
+			t.Errorf("deleting %s changed %s to %q", cover, back, got)
+		}
+	})
+
+	t.Run("delete a missing object", func(t *testing.T) {
+		if err := s.Delete(prefix + "books/missing.jpg"); err != nil {
+			t.Errorf("got %v, want no error", err)
+		}
+	})
+}
+
+func read(t *testing.T, s Storage, key string) string {
+	t.Helper()
+
+	object, err := s.Get(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer object.Close()
+
+	var content bytes.Buffer
+	if _, err := io.Copy(&content, object); err != nil {
+		t.Fatal(err)
+	}
+	return content.String()
+}
@@ -0,0 +1,15 @@
+package token
+
+import "github.com/golang-jwt/jwt/v5"
+
+// The roles of the staff who run the store. Customers have any other role.
+const (
+	RoleAdmin = "admin"
+	RoleStaff = "staff"
+)
+
+type JwtCustomClaims struct {
+	ID   uint   `json:"id"`
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,82 @@
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	EbookFormatPDF  = "pdf"
+	EbookFormatEPUB = "epub"
+)
+
+// ebookTypes maps the content types accepted for ebooks to their format.
+var ebookTypes = map[string]string{
+	"application/pdf":      EbookFormatPDF,
+	"application/epub+zip": EbookFormatEPUB,
+}
+
+// Ebook is an uploaded ebook file that passed validation. File is rewound to
+// the start.
+type Ebook struct {
+	File        multipart.File
+	Size        int64
+	ContentType string
+	Extension   string
+	Format      string
+}
+
+// EbookValidator checks uploaded ebook files.
+type EbookValidator struct {
+	maxBytes int64
+}
+
+func NewEbookValidator(cfg *config.EbookConfig) *EbookValidator {
+	return &EbookValidator{maxBytes: cfg.MaxFileBytes}
+}
+
+// Validate makes sure the file is a PDF or EPUB within the size limit. Like
+// images, the type is sniffed from the content. The returned errors are
+// written for the user.
+func (v *EbookValidator) Validate(file multipart.File) (*Ebook, error) {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.New("file could not be read")
+	}
+	if size == 0 {
+		return nil, errors.New("file is empty")
+	}
+	if v.maxBytes > 0 && size > v.maxBytes {
+		return nil, fmt.Errorf("file must be at most %s", formatSize(v.maxBytes))
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("file could not be read")
+	}
+	detected, err := mimetype.DetectReader(file)
+	if err != nil {
+		return nil, errors.New("file could not be read")
+	}
+
+	format, ok := ebookTypes[detected.String()]
+	if !ok {
+		return nil, errors.New("file must be a pdf or epub")
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("file could not be read")
+	}
+
+	return &Ebook{
+		File:        file,
+		Size:        size,
+		ContentType: detected.String(),
+		Extension:   detected.Extension(),
+		Format:      format,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package upload
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+
+	"github.com/aws-cakap-intern/book-store/config"
+	"github.com/gabriel-vasile/mimetype"
+	_ "golang.org/x/image/webp"
+)
+
+// Image is an uploaded image that passed validation. File is rewound to the
+// start, Decoded holds its pixels and Hash is the hex SHA-256 of the file.
+type Image struct {
+	File        multipart.File
+	Size        int64
+	Hash        string
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Decoded     image.Image
+}
+
+// ImageValidator checks uploaded images against the configured limits.
+type ImageValidator struct {
+	types     []string
+	maxBytes  int64
+	maxWidth  int
+	maxHeight int
+}
+
+func NewImageValidator(cfg *config.UploadConfig) *ImageValidator {
+	return &ImageValidator{
+		types:     cfg.ImageTypes,
+		maxBytes:  cfg.MaxImageBytes,
+		maxWidth:  cfg.MaxImageWidth,
+		maxHeight: cfg.MaxImageHeight,
+	}
+}
+
+// Validate makes sure the file is a real image of an allowed type within the
+// size limits. The type is sniffed from the content, so the file name and the
+// Content-Type sent by the client are ignored. Dimensions are checked from
+// the header before the image is decoded, so an oversized image is never
+// loaded into memory. The returned errors are written for the user.
+func (v *ImageValidator) Validate(file multipart.File) (*Image, error) {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.New("image could not be read")
+	}
+	if size == 0 {
+		return nil, errors.New("image is empty")
+	}
+	if v.maxBytes > 0 && size > v.maxBytes {
+		return nil, fmt.Errorf("image must be at most %s", formatSize(v.maxBytes))
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("image could not be read")
+	}
+	detected, err := mimetype.DetectReader(file)
+	if err != nil {
+		return nil, errors.New("image could not be read")
+	}
+	if !v.allowed(detected) {
+		return nil, fmt.Errorf("image must be one of these types: %s", strings.Join(v.typeNames(), ", "))
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("image could not be read")
+	}
+	imageConfig, format, err := image.DecodeConfig(file)
+	if err != nil || "image/"+format != detected.String() {
+		return nil, errors.New("image is not a valid " + typeName(detected.String()) + " file")
+	}
+	if (v.maxWidth > 0 && imageConfig.Width > v.maxWidth) || (v.maxHeight > 0 && imageConfig.Height > v.maxHeight) {
+		return nil, fmt.Errorf("image must be at most %dx%d pixels", v.maxWidth, v.maxHeight)
+	}
+
+	// A valid header can still be followed by broken data
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("image could not be read")
+	}
+	decoded, _, err := image.Decode(file)
+	if err != nil {
+		return nil, errors.New("image is damaged or incomplete")
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("image could not be read")
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, errors.New("image could not be read")
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.New("image could not be read")
+	}
+
+	return &Image{
+		File:        file,
+		Size:        size,
+		Hash:        hex.EncodeToString(hash.Sum(nil)),
+		ContentType: detected.String(),
+		Extension:   detected.Extension(),
+		Width:       imageConfig.Width,
+		Height:      imageConfig.Height,
+		Decoded:     decoded,
+	}, nil
+}
+
+// ValidateReader is Validate for images that do not come from a form, such
+// as direct uploads. At most the size limit is read into memory.
+func (v *ImageValidator) ValidateReader(content io.Reader) (*Image, error) {
+	if v.maxBytes > 0 {
+		content = io.LimitReader(content, v.maxBytes+1)
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, errors.New("image could not be read")
+	}
+
+	return v.Validate(memoryFile{bytes.NewReader(data)})
+}
+
+// CheckDeclared checks the type and size a client announces before sending
+// an image, with the same errors Validate gives for the image itself.
+func (v *ImageValidator) CheckDeclared(contentType string, size int64) error {
+	allowed := false
+	for _, allowedType := range v.types {
+		if strings.TrimSpace(allowedType) == contentType {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("image must be one of these types: %s", strings.Join(v.typeNames(), ", "))
+	}
+
+	if v.maxBytes > 0 && size > v.maxBytes {
+		return fmt.Errorf("image must be at most %s", formatSize(v.maxBytes))
+	}
+	return nil
+}
+
+// MaxBytes returns the largest image size allowed, or 0 for no limit.
+func (v *ImageValidator) MaxBytes() int64 {
+	return v.maxBytes
+}
+
+func (v *ImageValidator) allowed(detected *mimetype.MIME) bool {
+	for _, allowedType := range v.types {
+		if detected.Is(strings.TrimSpace(allowedType)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *ImageValidator) typeNames() []string {
+	names := []string{}
+	for _, allowedType := range v.types {
+		names = append(names, typeName(strings.TrimSpace(allowedType)))
+	}
+	return names
+}
+
+// typeName turns "image/jpeg" into "jpeg".
+func typeName(contentType string) string {
+	return strings.TrimPrefix(contentType, "image/")
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20 && bytes%(1<<20) == 0:
+		return strconv.FormatInt(bytes>>20, 10) + " MB"
+	case bytes >= 1<<10 && bytes%(1<<10) == 0:
+		return strconv.FormatInt(bytes>>10, 10) + " KB"
+	default:
+		return strconv.FormatInt(bytes, 10) + " bytes"
+	}
+}
+
+// memoryFile is an image held in memory, passed around like an uploaded file.
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error {
+	return nil
+}
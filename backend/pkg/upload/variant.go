@@ -0,0 +1,105 @@
+package upload
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"sort"
+	"strconv"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/aws-cakap-intern/book-store/config"
+	"golang.org/x/image/draw"
+)
+
+// VariantWebP names the WebP copy of an image.
+const VariantWebP = "webp"
+
+// Variant is a resized or re-encoded copy of an uploaded image. Name is the
+// width in pixels for resized copies, or VariantWebP.
+type Variant struct {
+	Name        string
+	Width       int
+	ContentType string
+	Extension   string
+	Content     []byte
+}
+
+// VariantGenerator makes the smaller copies of uploaded images that pages
+// show instead of the original.
+type VariantGenerator struct {
+	widths []int
+	webp   bool
+}
+
+func NewVariantGenerator(cfg *config.UploadConfig) *VariantGenerator {
+	widths := append([]int{}, cfg.VariantWidths...)
+	sort.Ints(widths)
+	return &VariantGenerator{widths: widths, webp: cfg.VariantWebP}
+}
+
+// Widths returns the configured variant widths, smallest first.
+func (g *VariantGenerator) Widths() []int {
+	return g.widths
+}
+
+// Generate scales the image down to each configured width, keeping its
+// aspect ratio. Images are never scaled up, so widths at or above the
+// original's are skipped and the original should be used for them. The WebP
+// copy is made from the largest of those sizes, since WebP is encoded
+// lossless and a full-size copy would often be bigger than the original.
+func (g *VariantGenerator) Generate(img *Image) ([]Variant, error) {
+	var variants []Variant
+	largest, largestWidth := img.Decoded, img.Width
+
+	for _, width := range g.widths {
+		if width <= 0 || width >= img.Width {
+			continue
+		}
+
+		height := img.Height * width / img.Width
+		if height < 1 {
+			height = 1
+		}
+
+		resized := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(resized, resized.Bounds(), img.Decoded, img.Decoded.Bounds(), draw.Src, nil)
+		largest, largestWidth = resized, width
+
+		variant, err := encode(resized, img.ContentType)
+		if err != nil {
+			return nil, err
+		}
+		variant.Name = strconv.Itoa(width)
+		variant.Width = width
+		variants = append(variants, *variant)
+	}
+
+	if g.webp {
+		var buf bytes.Buffer
+		if err := nativewebp.Encode(&buf, largest, nil); err != nil {
+			return nil, err
+		}
+		variants = append(variants, Variant{Name: VariantWebP, Width: largestWidth, ContentType: "image/webp", Extension: ".webp", Content: buf.Bytes()})
+	}
+
+	return variants, nil
+}
+
+// encode writes JPEG originals back as JPEG and everything else as PNG, so
+// transparency is kept.
+func encode(img image.Image, contentType string) (*Variant, error) {
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		return &Variant{ContentType: "image/jpeg", Extension: ".jpg", Content: buf.Bytes()}, nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return &Variant{ContentType: "image/png", Extension: ".png", Content: buf.Bytes()}, nil
+}
@@ -13,6 +13,7 @@ function AddBook() {
   });
   const [categories, setCategories] = useState([]);
   const [image, setImage] = useState(null);
+  const [cover, setCover] = useState(null);
 
   useEffect(() => {
     const fetchCategories = async () => {
@@ -63,6 +64,29 @@ function AddBook() {
     setImage(e.target.files[0]);
   };
 
+  // Prefills the form from the metadata of an EPUB or PDF file
+  const handleEbookChange = async (e) => {
+    const formData = new FormData();
+    formData.append('file', e.target.files[0]);
+
+    try {
+      const response = await axios.post(`${process.env.REACT_APP_API_URL}/books/extract`, formData, {
+        headers: {
+          'Content-Type': 'multipart/form-data',
+        },
+      });
+      const draft = response.data.data;
+      setBook(prevBook => ({
+        ...prevBook,
+        title: draft.book.title || prevBook.title,
+        description: draft.book.description || prevBook.description,
+      }));
+      setCover(draft.cover);
+    } catch (error) {
+      console.error('Error reading ebook:', error);
+    }
+  };
+
   const handleSubmit = async (e) => {
     e.preventDefault();
     const formData = new FormData();
@@ -70,7 +94,11 @@ function AddBook() {
     formData.append('price', book.price);
     formData.append('description', book.description);
     formData.append('categories', book.categories);
-    formData.append('image', image);
+    if (image) {
+      formData.append('image', image);
+    } else if (cover) {
+      formData.append('upload_id', cover.upload_id);
+    }
 
     try {
       await axios.post(`${process.env.REACT_APP_API_URL}/books`, formData, {
@@ -88,6 +116,15 @@ function AddBook() {
     <div className="max-w-2xl mx-auto">
       <h1 className="text-3xl font-bold mb-6">Add New Book</h1>
       <form onSubmit={handleSubmit} className="space-y-4">
+        <div>
+          <label className="block text-sm font-medium text-gray-700">Fill in from ebook (optional)</label>
+          <input
+            type="file"
+            onChange={handleEbookChange}
+            className="mt-1 block w-full"
+            accept=".epub,.pdf,application/epub+zip,application/pdf"
+          />
+        </div>
         <div>
           <label className="block text-sm font-medium text-gray-700">Title</label>
           <input
@@ -134,12 +171,15 @@ function AddBook() {
         </div>
         <div>
           <label className="block text-sm font-medium text-gray-700">Image</label>
+          {cover && !image && (
+            <img src={cover.url} alt="Cover from ebook" className="mt-1 h-32" />
+          )}
           <input
             type="file"
             onChange={handleImageChange}
             className="mt-1 block w-full"
             accept="image/*"
-            required
+            required={!cover}
           />
         </div>
         <div className="flex justify-end space-x-4">
@@ -2,6 +2,15 @@ import React, { useState, useEffect } from 'react';
 import { Link } from 'react-router-dom';
 import axios from 'axios';
 
+// imageSrcSet lists the resized copies of a book image by width, so the
+// browser can pick the smallest one that fits.
+function imageSrcSet(urls) {
+  return Object.keys(urls)
+    .filter((name) => /^\d+$/.test(name))
+    .map((width) => `${urls[width]} ${width}w`)
+    .join(', ');
+}
+
 function BookList() {
   const [books, setBooks] = useState([]);
   const [error, setError] = useState(null);
@@ -61,9 +70,11 @@ function BookList() {
         <div className="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-6">
           {books.map((book) => (
             <div key={book.id} className="bg-white rounded-lg shadow-md overflow-hidden">
-              {book.imagePath && (
+              {book.cover?.original && (
                 <img
-                  src={`${process.env.REACT_APP_API_URL}/${book.imagePath}`}
+                  src={book.cover['400'] || book.cover.original}
+                  srcSet={imageSrcSet(book.cover)}
+                  sizes="(min-width: 1024px) 33vw, (min-width: 768px) 50vw, 100vw"
                   alt={book.title}
 
                   className="w-full h-48 object-cover"